@@ -10,11 +10,13 @@ type DefineEnumValueExpression struct {
 	Description DescriptionExpression
 	Name        NameExpression
 	Directives  []DirectiveExpression
+	Trivia      Trivia
 }
 
 func (d *DefineEnumValueExpression) Eval(enum *gql.Enum) {
 	enum.Values = append(enum.Values, &gql.EnumValue{
 		Name:        d.Name.Eval(),
+		Position:    d.Name.Position(),
 		Description: d.Description.Eval(),
 		Directives:  evalDirectives(d.Directives),
 	})
@@ -0,0 +1,168 @@
+package ast
+
+import "github.com/RettyEng/gqlcodegen/gql"
+
+type ExecutableDocument struct {
+	Definitions []ExecutableDefinitionExpression
+	Trivia      Trivia
+}
+
+func (d *ExecutableDocument) Eval() *gql.ExecutableDocument {
+	doc := &gql.ExecutableDocument{}
+	for _, e := range d.Definitions {
+		e.Eval(doc)
+	}
+	return doc
+}
+
+type ExecutableDefinitionExpression interface {
+	Eval(doc *gql.ExecutableDocument)
+}
+
+// OperationExpression is an operation definition. Name is nil for an
+// anonymous operation. Pos is the position of the first token.
+type OperationExpression struct {
+	OperationType gql.OperationType
+	Name          NameExpression
+	Pos           gql.Position
+	Variables     []*VariableDefinitionExpression
+	Directives    []DirectiveExpression
+	SelectionSet  []SelectionExpression
+	Trivia        Trivia
+}
+
+func (e *OperationExpression) Eval(doc *gql.ExecutableDocument) {
+	op := &gql.Operation{
+		Type:         e.OperationType,
+		Position:     e.Pos,
+		Directives:   evalDirectives(e.Directives),
+		SelectionSet: evalSelectionSet(e.SelectionSet),
+	}
+	if e.Name != nil {
+		op.Name = e.Name.Eval()
+	}
+	for _, v := range e.Variables {
+		op.Variables = append(op.Variables, v.Eval())
+	}
+	doc.Operations = append(doc.Operations, op)
+}
+
+type VariableDefinitionExpression struct {
+	Name         NameExpression
+	Type         TypeRefExpression
+	DefaultValue ValueExpression
+	Directives   []DirectiveExpression
+	Trivia       Trivia
+}
+
+func (e *VariableDefinitionExpression) Eval() *gql.VariableDefinition {
+	var value gql.Value = nil
+	if e.DefaultValue != nil {
+		value = e.DefaultValue.Eval()
+	}
+	return &gql.VariableDefinition{
+		Name:       e.Name.Eval(),
+		Position:   e.Name.Position(),
+		Type:       e.Type.Eval(),
+		Default:    value,
+		Directives: evalDirectives(e.Directives),
+	}
+}
+
+type FragmentExpression struct {
+	Name          NameExpression
+	TypeCondition TypeRefExpression
+	Directives    []DirectiveExpression
+	SelectionSet  []SelectionExpression
+	Trivia        Trivia
+}
+
+func (e *FragmentExpression) Eval(doc *gql.ExecutableDocument) {
+	doc.Fragments = append(doc.Fragments, &gql.Fragment{
+		Name:          e.Name.Eval(),
+		Position:      e.Name.Position(),
+		TypeCondition: e.TypeCondition.Eval(),
+		Directives:    evalDirectives(e.Directives),
+		SelectionSet:  evalSelectionSet(e.SelectionSet),
+	})
+}
+
+type SelectionExpression interface {
+	Eval() gql.Selection
+}
+
+func evalSelectionSet(exp []SelectionExpression) []gql.Selection {
+	var ret []gql.Selection
+	for _, e := range exp {
+		ret = append(ret, e.Eval())
+	}
+	return ret
+}
+
+// FieldExpression is a selected field. Alias is nil if the field is not
+// aliased.
+type FieldExpression struct {
+	Alias        NameExpression
+	Name         NameExpression
+	Args         map[string]ValueExpression
+	ArgNames     []string
+	Directives   []DirectiveExpression
+	SelectionSet []SelectionExpression
+	Trivia       Trivia
+}
+
+func (e *FieldExpression) Eval() gql.Selection {
+	args := map[string]gql.Value{}
+	for name, v := range e.Args {
+		args[name] = v.Eval()
+	}
+	f := &gql.Field{
+		Name:         e.Name.Eval(),
+		Position:     e.Name.Position(),
+		Args:         args,
+		ArgNames:     e.ArgNames,
+		Directives:   evalDirectives(e.Directives),
+		SelectionSet: evalSelectionSet(e.SelectionSet),
+	}
+	if e.Alias != nil {
+		f.Alias = e.Alias.Eval()
+		f.Position = e.Alias.Position()
+	}
+	return f
+}
+
+type FragmentSpreadExpression struct {
+	Name       NameExpression
+	Directives []DirectiveExpression
+	Trivia     Trivia
+}
+
+func (e *FragmentSpreadExpression) Eval() gql.Selection {
+	return &gql.FragmentSpread{
+		Name:       e.Name.Eval(),
+		Position:   e.Name.Position(),
+		Directives: evalDirectives(e.Directives),
+	}
+}
+
+// InlineFragmentExpression is an inline fragment. TypeCondition is nil if
+// omitted. Pos is the position of the spread token.
+type InlineFragmentExpression struct {
+	TypeCondition TypeRefExpression
+	Pos           gql.Position
+	Directives    []DirectiveExpression
+	SelectionSet  []SelectionExpression
+	Trivia        Trivia
+}
+
+func (e *InlineFragmentExpression) Eval() gql.Selection {
+	f := &gql.InlineFragment{
+		Position:     e.Pos,
+		Directives:   evalDirectives(e.Directives),
+		SelectionSet: evalSelectionSet(e.SelectionSet),
+	}
+	if e.TypeCondition != nil {
+		f.TypeCondition = e.TypeCondition.Eval()
+	}
+	return f
+}
@@ -11,11 +11,13 @@ type DefineInterfaceFieldExpression struct {
 	DescriptionExp DescriptionExpression
 	ArgsExp        []InputValueExpression
 	DirectivesExp  []DirectiveExpression
+	Trivia         Trivia
 }
 
 func (d *DefineInterfaceFieldExpression) Eval(i *gql.Interface) {
 	f := &gql.ObjectField{
 		Name:        d.NameExp.Eval(),
+		Position:    d.NameExp.Position(),
 		Type:        d.TypeExp.Eval(),
 		Description: d.DescriptionExp.Eval(),
 		Args:        evalInputValues(d.ArgsExp),
@@ -20,11 +20,13 @@ type DefineFieldExpression struct {
 	Description DescriptionExpression
 	Directives  []DirectiveExpression
 	Args        []InputValueExpression
+	Trivia      Trivia
 }
 
 func (e *DefineFieldExpression) Eval(object *gql.Object) {
 	f := &gql.ObjectField{
 		Name:        e.Name.Eval(),
+		Position:    e.Name.Position(),
 		Type:        e.TypeRef.Eval(),
 		Description: e.Description.Eval(),
 		Directives:  evalDirectives(e.Directives),
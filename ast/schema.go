@@ -7,7 +7,8 @@ type SchemaInternalExpression interface {
 }
 
 type DefineQueryExpression struct {
-	Type TypeRefExpression
+	Type   TypeRefExpression
+	Trivia Trivia
 }
 
 func (d *DefineQueryExpression) Eval(schema *gql.Schema) {
@@ -15,7 +16,8 @@ func (d *DefineQueryExpression) Eval(schema *gql.Schema) {
 }
 
 type DefineMutationExpression struct {
-	Type TypeRefExpression
+	Type   TypeRefExpression
+	Trivia Trivia
 }
 
 func (d *DefineMutationExpression) Eval(schema *gql.Schema) {
@@ -23,7 +25,8 @@ func (d *DefineMutationExpression) Eval(schema *gql.Schema) {
 }
 
 type DefineSubscriptionExpression struct {
-	Type TypeRefExpression
+	Type   TypeRefExpression
+	Trivia Trivia
 }
 
 func (d *DefineSubscriptionExpression) Eval(schema *gql.Schema) {
@@ -1,25 +1,64 @@
 package ast
 
 import (
-	"log"
-
 	"github.com/RettyEng/gqlcodegen/gql"
 )
 
 type TopLevel struct {
 	Expressions []DefinitionExpression
+	Trivia      Trivia
+}
+
+// EvalError is an error of a schema found in evaluation, such as an extension
+// of an undefined type.
+type EvalError struct {
+	Position gql.Position
+	Message  string
 }
 
-func (t *TopLevel) Eval() *gql.TypeSystem {
+func (e *EvalError) Error() string {
+	return e.Position.String() + ": " + e.Message
+}
+
+func undefinedTarget(kind string, name NameExpression) error {
+	return &EvalError{
+		Position: name.Position(),
+		Message:  "cannot extend undefined " + kind + " " + name.Eval(),
+	}
+}
+
+// Eval evaluates the definitions into a type system. Extensions are evaluated
+// after all the definitions, so they may precede the definitions they extend.
+// Errors in the definitions are returned as *EvalError.
+func (t *TopLevel) Eval() (*gql.TypeSystem, error) {
 	sys := gql.NewTypeSystem()
-	for _, e := range t.Expressions {
-		e.Eval(sys)
+	for _, extensions := range []bool{false, true} {
+		for _, e := range t.Expressions {
+			if IsExtension(e) != extensions {
+				continue
+			}
+			if err := e.Eval(sys); err != nil {
+				return nil, err
+			}
+		}
 	}
-	return sys
+	return sys, nil
 }
 
 type DefinitionExpression interface {
-	Eval(system *gql.TypeSystem)
+	Eval(system *gql.TypeSystem) error
+}
+
+// IsExtension returns whether exp extends a definition, which must be
+// evaluated after the definition.
+func IsExtension(exp DefinitionExpression) bool {
+	switch exp.(type) {
+	case *ExtendSchemaExpression, *ExtendScalarExpression, *ExtendObjectExpression,
+		*ExtendInterfaceExpression, *ExtendUnionExpression, *ExtendEnumExpression,
+		*ExtendInputObjectExpression:
+		return true
+	}
+	return false
 }
 
 func evalDirectives(exp []DirectiveExpression) []*gql.DirectiveRef {
@@ -41,54 +80,63 @@ func evalInputValues(exp []InputValueExpression) []*gql.InputValue {
 type DefineSchemaExpression struct {
 	DirectiveExpressions []DirectiveExpression
 	Expressions          []SchemaInternalExpression
+	Trivia               Trivia
 }
 
-func (d *DefineSchemaExpression) Eval(system *gql.TypeSystem) {
+func (d *DefineSchemaExpression) Eval(system *gql.TypeSystem) error {
 	system.Schema.Directives = evalDirectives(d.DirectiveExpressions)
 	for _, e := range d.Expressions {
 		e.Eval(system.Schema)
 	}
+	return nil
 }
 
 type ExtendSchemaExpression struct {
 	DirectiveExpressions []DirectiveExpression
 	Expressions          []SchemaInternalExpression
+	Trivia               Trivia
 }
 
-func (e *ExtendSchemaExpression) Eval(system *gql.TypeSystem) {
+func (e *ExtendSchemaExpression) Eval(system *gql.TypeSystem) error {
 	directives := evalDirectives(e.DirectiveExpressions)
 	system.Schema.Directives = append(system.Schema.Directives, directives...)
 	for _, e := range e.Expressions {
 		e.Eval(system.Schema)
 	}
+	return nil
 }
 
 type DefineScalarExpression struct {
 	DescriptionExpression DescriptionExpression
 	NameExpression        NameExpression
 	DirectiveExpressions  []DirectiveExpression
+	Trivia                Trivia
 }
 
-func (d *DefineScalarExpression) Eval(system *gql.TypeSystem) {
+func (d *DefineScalarExpression) Eval(system *gql.TypeSystem) error {
 	system.ScalarTypes[d.NameExpression.Eval()] = &gql.Scalar{
 		Description: d.DescriptionExpression.Eval(),
 		Name:        d.NameExpression.Eval(),
+		Position:    d.NameExpression.Position(),
 		Directives:  evalDirectives(d.DirectiveExpressions),
 	}
+	return nil
 }
 
 type ExtendScalarExpression struct {
 	NameExpression       NameExpression
 	DirectiveExpressions []DirectiveExpression
+	Trivia               Trivia
 }
 
-func (e *ExtendScalarExpression) Eval(system *gql.TypeSystem) {
+func (e *ExtendScalarExpression) Eval(system *gql.TypeSystem) error {
 	s, ok := system.ScalarTypes[e.NameExpression.Eval()]
 	if !ok {
-		log.Fatalf("extend target not found")
+		return undefinedTarget("scalar", e.NameExpression)
 	}
 	s.Directives =
 		append(s.Directives, evalDirectives(e.DirectiveExpressions)...)
+	return nil
 }
 
 type DefineObjectExpression struct {
@@ -96,30 +144,34 @@ type DefineObjectExpression struct {
 	NameExpression        NameExpression
 	DirectiveExpressions  []DirectiveExpression
 	ObjectExpression      []ObjectInternalExpression
+	Trivia                Trivia
 }
 
-func (d *DefineObjectExpression) Eval(system *gql.TypeSystem) {
+func (d *DefineObjectExpression) Eval(system *gql.TypeSystem) error {
 	obj := &gql.Object{
 		Description: d.DescriptionExpression.Eval(),
 		Name:        d.NameExpression.Eval(),
+		Position:    d.NameExpression.Position(),
 		Directives:  evalDirectives(d.DirectiveExpressions),
 	}
 	for _, e := range d.ObjectExpression {
 		e.Eval(obj)
 	}
 	system.ObjectTypes[d.NameExpression.Eval()] = obj
+	return nil
 }
 
 type ExtendObjectExpression struct {
 	NameExpression       NameExpression
 	DirectiveExpressions []DirectiveExpression
 	ObjectExpression     []ObjectInternalExpression
+	Trivia               Trivia
 }
 
-func (e *ExtendObjectExpression) Eval(system *gql.TypeSystem) {
+func (e *ExtendObjectExpression) Eval(system *gql.TypeSystem) error {
 	obj, ok := system.ObjectTypes[e.NameExpression.Eval()]
 	if !ok {
-		log.Fatalf("extend target not found")
+		return undefinedTarget("type", e.NameExpression)
 	}
 	obj.Directives =
 		append(obj.Directives, evalDirectives(e.DirectiveExpressions)...)
@@ -127,6 +179,7 @@ func (e *ExtendObjectExpression) Eval(system *gql.TypeSystem) {
 		exp.Eval(obj)
 	}
 	system.ObjectTypes[e.NameExpression.Eval()] = obj
+	return nil
 }
 
 type DefineInterfaceExpression struct {
@@ -134,36 +187,41 @@ type DefineInterfaceExpression struct {
 	NameExpression        NameExpression
 	DirectiveExpressions  []DirectiveExpression
 	InterfaceExpression   []InterfaceInternalExpression
+	Trivia                Trivia
 }
 
-func (d *DefineInterfaceExpression) Eval(system *gql.TypeSystem) {
+func (d *DefineInterfaceExpression) Eval(system *gql.TypeSystem) error {
 	i := &gql.Interface{
 		Description: d.DescriptionExpression.Eval(),
 		Name:        d.NameExpression.Eval(),
+		Position:    d.NameExpression.Position(),
 		Directives:  evalDirectives(d.DirectiveExpressions),
 	}
 	for _, exp := range d.InterfaceExpression {
 		exp.Eval(i)
 	}
 	system.InterfaceTypes[d.NameExpression.Eval()] = i
+	return nil
 }
 
 type ExtendInterfaceExpression struct {
 	NameExpression       NameExpression
 	DirectiveExpressions []DirectiveExpression
 	InterfaceExpression  []InterfaceInternalExpression
+	Trivia               Trivia
 }
 
-func (e *ExtendInterfaceExpression) Eval(system *gql.TypeSystem) {
+func (e *ExtendInterfaceExpression) Eval(system *gql.TypeSystem) error {
 	i, ok := system.InterfaceTypes[e.NameExpression.Eval()]
 	if !ok {
-		log.Fatalf("extend target not found")
+		return undefinedTarget("interface", e.NameExpression)
 	}
 	i.Directives = append(i.Directives, evalDirectives(e.DirectiveExpressions)...)
 	for _, exp := range e.InterfaceExpression {
 		exp.Eval(i)
 	}
 	system.InterfaceTypes[e.NameExpression.Eval()] = i
+	return nil
 }
 
 type DefineUnionExpression struct {
@@ -171,36 +229,41 @@ type DefineUnionExpression struct {
 	NameExpression        NameExpression
 	DirectiveExpressions  []DirectiveExpression
 	UnionExpression       []UnionInternalExpression
+	Trivia                Trivia
 }
 
-func (d *DefineUnionExpression) Eval(system *gql.TypeSystem) {
+func (d *DefineUnionExpression) Eval(system *gql.TypeSystem) error {
 	u := &gql.Union{
 		Description: d.DescriptionExpression.Eval(),
 		Name:        d.NameExpression.Eval(),
+		Position:    d.NameExpression.Position(),
 		Directives:  evalDirectives(d.DirectiveExpressions),
 	}
 	for _, e := range d.UnionExpression {
 		e.Eval(u)
 	}
 	system.UnionTypes[d.NameExpression.Eval()] = u
+	return nil
 }
 
 type ExtendUnionExpression struct {
 	NameExpression       NameExpression
 	DirectiveExpressions []DirectiveExpression
 	UnionExpression      []UnionInternalExpression
+	Trivia               Trivia
 }
 
-func (e *ExtendUnionExpression) Eval(system *gql.TypeSystem) {
+func (e *ExtendUnionExpression) Eval(system *gql.TypeSystem) error {
 	u, ok := system.UnionTypes[e.NameExpression.Eval()]
 	if !ok {
-		log.Fatalf("extend target not found")
+		return undefinedTarget("union", e.NameExpression)
 	}
 	u.Directives = append(u.Directives, evalDirectives(e.DirectiveExpressions)...)
 	for _, e := range e.UnionExpression {
 		e.Eval(u)
 	}
 	system.UnionTypes[e.NameExpression.Eval()] = u
+	return nil
 }
 
 type DefineEnumExpression struct {
@@ -208,36 +271,41 @@ type DefineEnumExpression struct {
 	NameExpression        NameExpression
 	DirectiveExpressions  []DirectiveExpression
 	EnumExpression        []EnumInternalExpression
+	Trivia                Trivia
 }
 
-func (d *DefineEnumExpression) Eval(system *gql.TypeSystem) {
+func (d *DefineEnumExpression) Eval(system *gql.TypeSystem) error {
 	enum := &gql.Enum{
 		Description: d.DescriptionExpression.Eval(),
 		Name:        d.NameExpression.Eval(),
+		Position:    d.NameExpression.Position(),
 		Directives:  evalDirectives(d.DirectiveExpressions),
 	}
 	for _, e := range d.EnumExpression {
 		e.Eval(enum)
 	}
 	system.EnumTypes[d.NameExpression.Eval()] = enum
+	return nil
 }
 
 type ExtendEnumExpression struct {
 	NameExpression       NameExpression
 	DirectiveExpressions []DirectiveExpression
 	EnumExpression       []EnumInternalExpression
+	Trivia               Trivia
 }
 
-func (e *ExtendEnumExpression) Eval(system *gql.TypeSystem) {
+func (e *ExtendEnumExpression) Eval(system *gql.TypeSystem) error {
 	enum, ok := system.EnumTypes[e.NameExpression.Eval()]
 	if !ok {
-		log.Fatalf("extend target not found")
+		return undefinedTarget("enum", e.NameExpression)
 	}
 	enum.Directives = append(enum.Directives, evalDirectives(e.DirectiveExpressions)...)
 	for _, e := range e.EnumExpression {
 		e.Eval(enum)
 	}
 	system.EnumTypes[e.NameExpression.Eval()] = enum
+	return nil
 }
 
 type DefineInputObjectExpression struct {
@@ -245,32 +313,37 @@ type DefineInputObjectExpression struct {
 	NameExpression                    NameExpression
 	DirectiveExpressions              []DirectiveExpression
 	DefineInputObjectFieldExpressions []InputValueExpression
+	Trivia                            Trivia
 }
 
-func (d *DefineInputObjectExpression) Eval(system *gql.TypeSystem) {
+func (d *DefineInputObjectExpression) Eval(system *gql.TypeSystem) error {
 	obj := &gql.InputObject{
 		Description: d.DescriptionExpression.Eval(),
 		Name:        d.NameExpression.Eval(),
+		Position:    d.NameExpression.Position(),
 		Directives:  evalDirectives(d.DirectiveExpressions),
 		InputValue:  evalInputValues(d.DefineInputObjectFieldExpressions),
 	}
 	system.InputObjectTypes[d.NameExpression.Eval()] = obj
+	return nil
 }
 
 type ExtendInputObjectExpression struct {
 	NameExpression                    NameExpression
 	DirectiveExpressions              []DirectiveExpression
 	DefineInputObjectFieldExpressions []InputValueExpression
+	Trivia                            Trivia
 }
 
-func (e *ExtendInputObjectExpression) Eval(system *gql.TypeSystem) {
+func (e *ExtendInputObjectExpression) Eval(system *gql.TypeSystem) error {
 	obj, ok := system.InputObjectTypes[e.NameExpression.Eval()]
 	if !ok {
-		log.Fatalf("extend target not found")
+		return undefinedTarget("input", e.NameExpression)
 	}
 	obj.Directives = append(obj.Directives, evalDirectives(e.DirectiveExpressions)...)
 	obj.InputValue = append(obj.InputValue, evalInputValues(e.DefineInputObjectFieldExpressions)...)
 	system.InputObjectTypes[e.NameExpression.Eval()] = obj
+	return nil
 }
 
 type DirectiveDefinition struct {
@@ -278,16 +351,19 @@ type DirectiveDefinition struct {
 	NameExpression        NameExpression
 	ArgsExpression        []InputValueExpression
 	Expressions           []DirectiveInternalExpression
+	Trivia                Trivia
 }
 
-func (d *DirectiveDefinition) Eval(system *gql.TypeSystem) {
+func (d *DirectiveDefinition) Eval(system *gql.TypeSystem) error {
 	directive := &gql.Directive{
 		Description: d.DescriptionExpression.Eval(),
 		Name:        d.NameExpression.Eval(),
+		Position:    d.NameExpression.Position(),
 		Arguments:   evalInputValues(d.ArgsExpression),
 	}
 	for _, e := range d.Expressions {
 		e.Eval(directive)
 	}
 	system.Directives[d.NameExpression.Eval()] = directive
+	return nil
 }
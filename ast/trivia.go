@@ -0,0 +1,29 @@
+package ast
+
+import "github.com/RettyEng/gqlcodegen/lexer/token"
+
+// Trivia holds tokens around a node which are insignificant for the schema,
+// such as comments.
+type Trivia struct {
+	// Leading precedes the node. Trivia between tokens of the node which do
+	// not belong to its children are also held here.
+	Leading []*token.Token
+	// Trailing follows the node on the same line.
+	Trailing []*token.Token
+	// Closing precedes the end of the node, such as the closing bracket of a
+	// block or the end of the source.
+	Closing []*token.Token
+	// Line is the line where the node starts.
+	Line int
+}
+
+// Comments returns comments in tokens.
+func Comments(tokens []*token.Token) []*token.Token {
+	var ret []*token.Token
+	for _, t := range tokens {
+		if t.Type() == token.TypeComment {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
@@ -20,6 +20,7 @@ func (exp *TypeRefExpressionImpl) Eval() *gql.TypeRef {
 		InnerType:  inner,
 		Name:       exp.Name.Eval(),
 		IsNullable: exp.IsNullable,
+		Position:   exp.Name.Position(),
 	}
 }
 
@@ -46,22 +47,52 @@ func (exp *ListValueExpressionImpl) Eval() gql.Value {
 		child = append(child, e.Eval())
 	}
 	return &gql.List{
-		"[]",
-		child,
+		ValueString: "[]",
+		Child:       child,
+	}
+}
+
+type VariableExpressionImpl struct {
+	Name string
+}
+
+func (exp *VariableExpressionImpl) Eval() gql.Value {
+	return &gql.Variable{Name: exp.Name}
+}
+
+type ObjectValueExpressionImpl struct {
+	Fields     map[string]ValueExpression
+	FieldNames []string
+}
+
+func (exp *ObjectValueExpressionImpl) Eval() gql.Value {
+	fields := map[string]gql.Value{}
+	for name, v := range exp.Fields {
+		fields[name] = v.Eval()
+	}
+	return &gql.ObjectValue{
+		Fields:     fields,
+		FieldNames: exp.FieldNames,
 	}
 }
 
 type NameExpression interface {
 	Eval() string
+	Position() gql.Position
 }
 type NameExpressionImpl struct {
 	Name string
+	Pos  gql.Position
 }
 
 func (exp *NameExpressionImpl) Eval() string {
 	return exp.Name
 }
 
+func (exp *NameExpressionImpl) Position() gql.Position {
+	return exp.Pos
+}
+
 type DescriptionExpression interface {
 	Eval() string
 }
@@ -83,8 +114,10 @@ type DirectiveExpression interface {
 	Eval() *gql.DirectiveRef
 }
 type DirectiveExpressionImpl struct {
-	Name string
-	Args map[string]ValueExpression
+	Name     string
+	Pos      gql.Position
+	Args     map[string]ValueExpression
+	ArgNames []string
 }
 
 func (exp *DirectiveExpressionImpl) Eval() *gql.DirectiveRef {
@@ -93,8 +126,10 @@ func (exp *DirectiveExpressionImpl) Eval() *gql.DirectiveRef {
 		args[name] = v.Eval()
 	}
 	return &gql.DirectiveRef{
-		Name: exp.Name,
-		Args: args,
+		Name:     exp.Name,
+		Position: exp.Pos,
+		Args:     args,
+		ArgNames: exp.ArgNames,
 	}
 }
 
@@ -107,6 +142,7 @@ type InputValueExpressionImpl struct {
 	Type         TypeRefExpression
 	DefaultValue ValueExpression
 	Directives   []DirectiveExpression
+	Trivia       Trivia
 }
 
 func (exp *InputValueExpressionImpl) Eval() *gql.InputValue {
@@ -117,6 +153,7 @@ func (exp *InputValueExpressionImpl) Eval() *gql.InputValue {
 	return &gql.InputValue{
 		Description: exp.Description.Eval(),
 		Name:        exp.Name.Eval(),
+		Position:    exp.Name.Position(),
 		Type:        exp.Type.Eval(),
 		Default:     value,
 		Directives:  evalDirectives(exp.Directives),
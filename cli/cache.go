@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/RettyEng/gqlcodegen/generator"
+)
+
+var useCache = flag.Bool("cache", true, "skip generation when the inputs are unchanged")
+
+// cacheFile is the name of the cache in the package directory.
+const cacheFile = ".gqlcodegen-cache.json"
+
+// cache is the cache of the current generation, or nil if it is disabled.
+var cache *generationCache
+
+// generationCache records the hash of the inputs of each generation and the
+// hashes of the files it wrote. Generations are keyed by their targets, so
+// that generations of different targets in the same package keep their own
+// entries.
+type generationCache struct {
+	path    string
+	key     string
+	inputs  string
+	entries map[string]*cacheEntry
+
+	mu    sync.Mutex
+	files map[string]string
+}
+
+type cacheEntry struct {
+	Inputs string `json:"inputs"`
+	// Files holds the hashes of the contents of the files written, by their
+	// paths relative to the package.
+	Files map[string]string `json:"files"`
+}
+
+// openCache reads the cache of the package directory dir. It returns nil if
+// the cache is disabled or the targets include scaffold, which depends on
+// the sources of the package as well.
+func openCache(dir string, targets []string) *generationCache {
+	if !*useCache {
+		return nil
+	}
+	for _, t := range targets {
+		if t == "scaffold" {
+			return nil
+		}
+	}
+	c := &generationCache{
+		path:    path.Join(dir, cacheFile),
+		key:     strings.Join(targets, ","),
+		inputs:  inputsHash(dir, targets),
+		entries: map[string]*cacheEntry{},
+		files:   map[string]string{},
+	}
+	if b, e := ioutil.ReadFile(c.path); e == nil {
+		// A broken cache is the same as no cache.
+		_ = json.Unmarshal(b, &c.entries)
+	}
+	return c
+}
+
+// upToDate returns whether the inputs are the same as the last generation
+// and the files it wrote are unchanged.
+func (c *generationCache) upToDate() bool {
+	if c == nil {
+		return false
+	}
+	entry, ok := c.entries[c.key]
+	if !ok || entry.Inputs != c.inputs {
+		return false
+	}
+	for p, h := range entry.Files {
+		if fileHash(filepath.Join(filepath.Dir(c.path), p)) != h {
+			return false
+		}
+	}
+	return true
+}
+
+// record records the file p written by the generation.
+func (c *generationCache) record(p string) {
+	if c == nil {
+		return
+	}
+	rel, e := filepath.Rel(filepath.Dir(c.path), p)
+	if e != nil {
+		rel = p
+	}
+	h := fileHash(p)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.files[filepath.ToSlash(rel)] = h
+}
+
+// save writes the cache with the generation.
+func (c *generationCache) save() error {
+	if c == nil {
+		return nil
+	}
+	c.entries[c.key] = &cacheEntry{Inputs: c.inputs, Files: c.files}
+	b, e := json.MarshalIndent(c.entries, "", "  ")
+	if e != nil {
+		return e
+	}
+	return ioutil.WriteFile(c.path, append(b, '\n'), 0644)
+}
+
+// ignoredFlags are flags which do not change generated files, and are not
+// a part of the inputs.
+var ignoredFlags = map[string]bool{
+	"cache": true, "parallel": true, "watch": true, "watch-interval": true,
+}
+
+// inputsHash returns the hash of everything generation depends on: the
+// generator, the flags, and the contents of the schema files and of the
+// operations of the targets generated from them.
+func inputsHash(dir string, targets []string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "version %s %s\n", generator.Version, executableStamp())
+	fmt.Fprintf(h, "package %s\n", dir)
+	flag.VisitAll(func(f *flag.Flag) {
+		if !ignoredFlags[f.Name] {
+			fmt.Fprintf(h, "flag %s=%s\n", f.Name, f.Value)
+		}
+	})
+
+	files := strings.Split(*schema, ",")
+	for _, t := range targets {
+		if readsOperations(t) {
+			ops, _ := filepath.Glob(path.Join(*operations, "*.graphql"))
+			sort.Strings(ops)
+			files = append(files, ops...)
+		}
+	}
+	for _, p := range files {
+		fmt.Fprintf(h, "file %s %s\n", p, fileHash(p))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// executableStamp returns the size and the modification time of the running
+// command, so that a rebuilt generator invalidates the cache even if Version
+// is the same. They are used instead of the hash of its content, which would
+// cost more than the generation itself.
+func executableStamp() string {
+	p, e := os.Executable()
+	if e != nil {
+		return ""
+	}
+	info, e := os.Stat(p)
+	if e != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d %d", info.Size(), info.ModTime().UnixNano())
+}
+
+// fileHash returns the hash of the content of the file p, or "" if it
+// cannot be read.
+func fileHash(p string) string {
+	f, e := os.Open(p)
+	if e != nil {
+		return ""
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, e := io.Copy(h, f); e != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// cacheTest is a package directory with a schema and operations.
+type cacheTest struct {
+	t   *testing.T
+	dir string
+}
+
+func newCacheTest(t *testing.T) *cacheTest {
+	dir := t.TempDir()
+	c := &cacheTest{t, dir}
+	c.write("schema.graphqls", "type Query { a: Int }")
+	c.write("ops/q.graphql", "{ a }")
+	*schema = filepath.Join(dir, "schema.graphqls")
+	*operations = filepath.Join(dir, "ops")
+	return c
+}
+
+func (c *cacheTest) write(name, src string) string {
+	c.t.Helper()
+	p := filepath.Join(c.dir, name)
+	if e := os.MkdirAll(filepath.Dir(p), 0755); e != nil {
+		c.t.Fatal(e)
+	}
+	if e := ioutil.WriteFile(p, []byte(src), 0644); e != nil {
+		c.t.Fatal(e)
+	}
+	return p
+}
+
+// generate records a generation of the targets writing out_gql.go.
+func (c *cacheTest) generate(targets ...string) {
+	c.t.Helper()
+	gc := openCache(c.dir, targets)
+	gc.record(c.write("out_gql.go", "package out"))
+	if e := gc.save(); e != nil {
+		c.t.Fatal(e)
+	}
+}
+
+func (c *cacheTest) upToDate(targets ...string) bool {
+	return openCache(c.dir, targets).upToDate()
+}
+
+func TestCache(t *testing.T) {
+	defer func(s, o string, u bool) { *schema, *operations, *useCache = s, o, u }(*schema, *operations, *useCache)
+	*useCache = true
+
+	tests := []struct {
+		name   string
+		change func(c *cacheTest)
+		// server and client are whether generations of the targets are
+		// up to date after the change.
+		server, client bool
+	}{
+		{
+			name:   "unchanged",
+			change: func(c *cacheTest) {},
+			server: true,
+			client: true,
+		},
+		{
+			name:   "schema",
+			change: func(c *cacheTest) { c.write("schema.graphqls", "type Query { a: Int b: Int }") },
+		},
+		{
+			name:   "operation",
+			change: func(c *cacheTest) { c.write("ops/q.graphql", "{ a b }") },
+			server: true,
+		},
+		{
+			name:   "operation added",
+			change: func(c *cacheTest) { c.write("ops/p.graphql", "{ b }") },
+			server: true,
+		},
+		{
+			name: "flag",
+			change: func(c *cacheTest) {
+				if e := flag.Set("suffix", "_generated"); e != nil {
+					t.Fatal(e)
+				}
+			},
+		},
+		{
+			name: "ignored flag",
+			change: func(c *cacheTest) {
+				if e := flag.Set("watch-interval", "1s"); e != nil {
+					t.Fatal(e)
+				}
+			},
+			server: true,
+			client: true,
+		},
+		{
+			name:   "generated file",
+			change: func(c *cacheTest) { c.write("out_gql.go", "package edited") },
+		},
+		{
+			name: "generated file removed",
+			change: func(c *cacheTest) {
+				if e := os.Remove(filepath.Join(c.dir, "out_gql.go")); e != nil {
+					t.Fatal(e)
+				}
+			},
+		},
+		{
+			name: "cache broken",
+			change: func(c *cacheTest) {
+				c.write(cacheFile, "{")
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer restoreFlags("suffix", "watch-interval")()
+			c := newCacheTest(t)
+
+			c.generate("server")
+			c.generate("client")
+			if !c.upToDate("server") || !c.upToDate("client") {
+				t.Fatal("generation is not cached")
+			}
+			if c.upToDate("server", "client") {
+				t.Error("generation of other targets is cached")
+			}
+			tt.change(c)
+			if got := c.upToDate("server"); got != tt.server {
+				t.Errorf("server is up to date: %v, want %v", got, tt.server)
+			}
+			if got := c.upToDate("client"); got != tt.client {
+				t.Errorf("client is up to date: %v, want %v", got, tt.client)
+			}
+		})
+	}
+}
+
+// restoreFlags returns a function restoring the values of the flags names.
+func restoreFlags(names ...string) func() {
+	values := map[string]string{}
+	for _, n := range names {
+		values[n] = flag.Lookup(n).Value.String()
+	}
+	return func() {
+		for n, v := range values {
+			_ = flag.Set(n, v)
+		}
+	}
+}
+
+func TestCacheDisabled(t *testing.T) {
+	defer func(s, o string, u bool) { *schema, *operations, *useCache = s, o, u }(*schema, *operations, *useCache)
+	c := newCacheTest(t)
+
+	*useCache = true
+	c.generate("server", "scaffold")
+	if _, e := os.Stat(filepath.Join(c.dir, cacheFile)); !os.IsNotExist(e) {
+		t.Errorf("generation with scaffold writes the cache")
+	}
+	if c.upToDate("server", "scaffold") {
+		t.Error("generation with scaffold is cached")
+	}
+
+	c.generate("server")
+	*useCache = false
+	if c.upToDate("server") {
+		t.Error("generation is cached with -cache=false")
+	}
+}
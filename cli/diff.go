@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/RettyEng/gqlcodegen/diff"
+)
+
+// runDiff reports changes from the old schema to the new one. It exits with
+// 1 if any change is breaking. Each schema may be a comma separated list of
+// files.
+//
+//	gqlcodegen diff [-json] old.graphqls new.graphqls
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print changes as JSON")
+	_ = fs.Parse(args)
+	if fs.NArg() != 2 {
+		log.Fatal("usage: gqlcodegen diff [-json] old.graphqls new.graphqls")
+	}
+
+	changes := diff.Compare(loadTypeSystem(fs.Arg(0)), loadTypeSystem(fs.Arg(1)))
+	if *asJSON {
+		if changes == nil {
+			changes = []*diff.Change{}
+		}
+		b, e := json.MarshalIndent(changes, "", "  ")
+		if e != nil {
+			log.Fatal(e)
+		}
+		fmt.Println(string(b))
+	} else {
+		for _, c := range changes {
+			fmt.Println(c)
+		}
+	}
+	if diff.HasBreaking(changes) {
+		os.Exit(1)
+	}
+}
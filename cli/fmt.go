@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/RettyEng/gqlcodegen/parser"
+	"github.com/RettyEng/gqlcodegen/printer"
+)
+
+// runFmt formats schema files keeping their comments. The formatted schema
+// is written to stdout unless -w or -l is given. The standard input is
+// formatted if no file is given.
+//
+//	gqlcodegen fmt [-w] [-l] [file ...]
+func runFmt(args []string) {
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	write := fs.Bool("w", false, "write result to the source file instead of stdout")
+	list := fs.Bool("l", false, "list files whose formatting differs")
+	_ = fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		src, e := ioutil.ReadAll(os.Stdin)
+		if e != nil {
+			fmt.Fprintln(os.Stderr, e)
+			os.Exit(2)
+		}
+		out, e := format("<standard input>", src)
+		if e != nil {
+			fmt.Fprintln(os.Stderr, e)
+			os.Exit(2)
+		}
+		_, _ = os.Stdout.Write(out)
+		return
+	}
+
+	failed := false
+	for _, file := range fs.Args() {
+		if e := formatFile(file, *write, *list); e != nil {
+			fmt.Fprintln(os.Stderr, e)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(2)
+	}
+}
+
+func formatFile(file string, write, list bool) error {
+	src, e := ioutil.ReadFile(file)
+	if e != nil {
+		return e
+	}
+	out, e := format(file, src)
+	if e != nil {
+		return e
+	}
+	changed := !bytes.Equal(src, out)
+	if list && changed {
+		fmt.Println(file)
+	}
+	if write && changed {
+		info, e := os.Stat(file)
+		if e != nil {
+			return e
+		}
+		return ioutil.WriteFile(file, out, info.Mode())
+	}
+	if !write && !list {
+		_, e = os.Stdout.Write(out)
+	}
+	return e
+}
+
+func format(filename string, src []byte) ([]byte, error) {
+	top, e := parser.NewFileParser(filename, bytes.NewReader(src)).Parse()
+	if e != nil {
+		return nil, e
+	}
+	buff := bytes.NewBuffer(nil)
+	if e := printer.Fprint(buff, top); e != nil {
+		return nil, e
+	}
+	return buff.Bytes(), nil
+}
@@ -0,0 +1,80 @@
+package cli
+
+import "testing"
+
+func TestFormatKeepsComments(t *testing.T) {
+	src := `# schema comment
+
+# type comment
+type Query { # type trailing
+  # field comment
+  truck(
+    # argument comment
+    id: ID! # argument trailing
+  ): Truck # field trailing
+  # closing comment
+}
+
+enum Maker {
+  # enum value comment
+  ISUZU # enum value trailing
+  HINO
+}
+
+# input comment
+input Filter {
+  # input field comment
+  maker: Maker
+}
+# end comment
+`
+	want := `# schema comment
+
+# type comment
+# type trailing
+type Query {
+  # field comment
+  truck(
+    # argument comment
+    id: ID! # argument trailing
+  ): Truck # field trailing
+  # closing comment
+}
+
+enum Maker {
+  # enum value comment
+  ISUZU # enum value trailing
+  HINO
+}
+
+# input comment
+input Filter {
+  # input field comment
+  maker: Maker
+}
+
+# end comment
+`
+	out, e := format("schema.graphqls", []byte(src))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if string(out) != want {
+		t.Errorf("got\n%s\nwant\n%s", out, want)
+	}
+
+	again, e := format("schema.graphqls", out)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if string(again) != string(out) {
+		t.Errorf("formatting is not stable:\n%s", again)
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	_, e := format("schema.graphqls", []byte("type Query {\n  a: Int\n"))
+	if e == nil || e.Error() != "schema.graphqls:3:1: unexpected eof" {
+		t.Errorf("got %v", e)
+	}
+}
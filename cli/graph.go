@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/RettyEng/gqlcodegen/graph"
+)
+
+// runGraph writes the types of the schema and their relations as a diagram
+// to stdout.
+//
+//	gqlcodegen graph -schema=schema.graphqls [-format=dot|mermaid] [-root=Query] [-depth=2]
+func runGraph(args []string) {
+	fs := flag.NewFlagSet("graph", flag.ExitOnError)
+	fs.StringVar(schema, "schema", "", "comma separated")
+	format := fs.String("format", "dot", "dot or mermaid")
+	root := fs.String("root", "", "type to start from (default: all types)")
+	depth := fs.Int("depth", 0, "maximum number of edges from -root (0: no limit)")
+	_ = fs.Parse(args)
+
+	g := graph.Build(loadTypeSystem(*schema), *root, *depth)
+	if *root != "" && len(g.Nodes) == 0 {
+		log.Fatalf("%s is not an object, interface, union or input type", *root)
+	}
+	var e error
+	switch *format {
+	case "dot":
+		e = g.WriteDOT(os.Stdout)
+	case "mermaid":
+		e = g.WriteMermaid(os.Stdout)
+	default:
+		log.Fatalf("unknown format %s", *format)
+	}
+	if e != nil {
+		log.Fatal(e)
+	}
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/RettyEng/gqlcodegen/introspection"
+)
+
+// runIntrospect writes the introspection result of the schema as JSON to
+// the file given by -o, or to stdout.
+//
+//	gqlcodegen introspect -schema=schema.graphqls [-o schema.json]
+func runIntrospect(args []string) {
+	fs := flag.NewFlagSet("introspect", flag.ExitOnError)
+	fs.StringVar(schema, "schema", "", "comma separated")
+	out := fs.String("o", "", "output file (default: stdout)")
+	_ = fs.Parse(args)
+
+	ts := loadTypeSystem(*schema)
+	b, e := json.MarshalIndent(introspection.Introspect(ts), "", "  ")
+	if e != nil {
+		log.Fatal(e)
+	}
+	b = append(b, '\n')
+	if *out == "" {
+		_, _ = os.Stdout.Write(b)
+		return
+	}
+	if e := ioutil.WriteFile(*out, b, 0644); e != nil {
+		log.Fatal(e)
+	}
+}
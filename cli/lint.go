@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/lint"
+)
+
+// runLint reports problems of the schema found by the lint rules. It exits
+// with 1 if any problem is an error.
+//
+//	gqlcodegen lint -schema=schema.graphqls [-severity=rule=error,rule=off,...]
+func runLint(args []string) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	fs.StringVar(schema, "schema", "", "comma separated")
+	severity := fs.String("severity", "", "comma separated list of rule=off|warning|error")
+	list := fs.Bool("list", false, "list the rules")
+	_ = fs.Parse(args)
+
+	conf := lint.DefaultConfig()
+	if *list {
+		for _, r := range conf.Rules {
+			fmt.Println(r.Name())
+		}
+		return
+	}
+	parseSeverities(conf, *severity)
+
+	ts := loadTypeSystem(*schema)
+	s := lint.NewSuppressions()
+	for _, p := range strings.Split(*schema, ",") {
+		if isIntrospection(p) {
+			continue
+		}
+		f, e := os.Open(p)
+		if e != nil {
+			log.Fatal(e)
+		}
+		e = s.Add(p, bufio.NewReader(f))
+		f.Close()
+		if e != nil {
+			log.Fatal(e)
+		}
+	}
+
+	problems := lint.Lint(ts, conf, s)
+	for _, p := range problems {
+		fmt.Fprintln(os.Stderr, p)
+	}
+	if lint.HasErrors(problems) {
+		os.Exit(1)
+	}
+}
+
+func parseSeverities(conf *lint.Config, str string) {
+	if str == "" {
+		return
+	}
+	known := map[string]bool{}
+	for _, r := range conf.Rules {
+		known[r.Name()] = true
+	}
+	for _, kv := range strings.Split(str, ",") {
+		pair := strings.SplitN(kv, "=", 2)
+		if len(pair) != 2 || !known[pair[0]] {
+			log.Fatalf("illegal severity %s", kv)
+		}
+		sev, e := lint.ParseSeverity(pair[1])
+		if e != nil {
+			log.Fatal(e)
+		}
+		conf.Severity[pair[0]] = sev
+	}
+}
@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/RettyEng/gqlcodegen/lsp"
+)
+
+// runLsp runs the language server over stdin and stdout.
+//
+//	gqlcodegen lsp
+func runLsp(args []string) {
+	fs := flag.NewFlagSet("lsp", flag.ExitOnError)
+	_ = fs.Parse(args)
+
+	if e := lsp.NewServer(os.Stdin, os.Stdout).Serve(); e != nil {
+		log.Fatal(e)
+	}
+}
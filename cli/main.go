@@ -0,0 +1,286 @@
+// Package cli is the command gqlcodegen, which custom mains run with
+// plugins of their own.
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/ast"
+	"github.com/RettyEng/gqlcodegen/generator"
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/introspection"
+	"github.com/RettyEng/gqlcodegen/parser"
+	"github.com/RettyEng/gqlcodegen/printer"
+)
+
+var (
+	fileSuffix        = flag.String("suffix", "_gql", "")
+	enumPackagePrefix = flag.String("enum-pkg-prefix", "", "")
+	scalarPackage     = flag.String("scalar-pkg", "", "")
+	generateTarget    = flag.String("target", "", "comma separated")
+	schema            = flag.String("schema", "", "comma separated")
+	operations        = flag.String("ops", "", "directory of operations for the client target")
+	parallelism       = flag.Int("parallel", runtime.GOMAXPROCS(0), "number of files generated in parallel")
+)
+
+func createConfig(packageName, packagePath string) *generator.Config {
+	return &generator.Config{
+		EnumPackagePrefix: *enumPackagePrefix,
+		ScalarPackage:     *scalarPackage,
+		Package: &generator.Package{
+			Name: packageName,
+			Path: packagePath,
+		},
+		FileSuffix: *fileSuffix,
+		Operations: *operations,
+		Parallel:   *parallelism,
+	}
+}
+
+func createGenerator(
+	packageName, packagePath string, root *gql.TypeSystem,
+) *generator.Generator {
+	return generator.NewGenerator(root, createConfig(packageName, packagePath))
+}
+
+// readsOperations returns whether the plugin of the target reads the
+// operations under -ops besides the schema.
+func readsOperations(target string) bool {
+	p, ok := generator.Lookup(target)
+	if !ok {
+		return false
+	}
+	r, ok := p.(generator.OperationsPlugin)
+	return ok && r.ReadsOperations()
+}
+
+// commands are subcommands run with the arguments following their names.
+var commands = map[string]func(args []string){
+	"diff":         runDiff,
+	"fmt":          runFmt,
+	"graph":        runGraph,
+	"lint":         runLint,
+	"lsp":          runLsp,
+	"introspect":   runIntrospect,
+	"validate-ops": runValidateOps,
+	"verify":       runVerify,
+}
+
+// Main runs the command gqlcodegen. The targets are the plugins registered
+// to package generator, so a main which imports packages registering
+// plugins runs them as well:
+//
+//	package main
+//
+//	import (
+//		"github.com/RettyEng/gqlcodegen/cli"
+//		_ "example.com/myplugin"
+//	)
+//
+//	func main() {
+//		cli.Main()
+//	}
+func Main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			cmd(os.Args[2:])
+			return
+		}
+	}
+	flag.Parse()
+	if *watch {
+		runWatch()
+		return
+	}
+
+	packagePath, _ := filepath.Abs(".")
+	packageName := path.Base(packagePath)
+
+	if args := flag.Args(); len(args) > 0 {
+		packagePath = path.Dir(args[0])
+		packageName = path.Base(packagePath)
+	}
+	cache = openCache(packagePath, strings.Split(*generateTarget, ","))
+	if cache.upToDate() {
+		return
+	}
+	typeSystem := loadTypeSystem(*schema)
+	conf := createConfig(packageName, packagePath)
+	conf.Schema = schemaSource(*schema)
+
+	if errs := generate(typeSystem, conf); len(errs) > 0 {
+		for _, e := range errs {
+			log.Println(e)
+		}
+		os.Exit(1)
+	}
+	if e := cache.save(); e != nil {
+		log.Fatal(e)
+	}
+}
+
+// generate runs the plugins of the targets in order and writes the files
+// they generate. It returns the errors of the targets which failed.
+func generate(ts *gql.TypeSystem, conf *generator.Config) []error {
+	var plugins []generator.Plugin
+	for _, t := range strings.Split(*generateTarget, ",") {
+		p, ok := generator.Lookup(t)
+		if !ok {
+			log.Fatalf("unknown target %s, not one of %s", t, strings.Join(generator.Plugins(), ", "))
+		}
+		plugins = append(plugins, p)
+	}
+	var errs []error
+	for _, p := range plugins {
+		files, e := p.Generate(context.Background(), ts, *conf)
+		if e == nil {
+			e = writeFiles(conf.Package.Path, files)
+		}
+		if e == nil {
+			continue
+		}
+		// An error of a plugin may have a line for each file which failed.
+		for _, line := range strings.Split(e.Error(), "\n") {
+			errs = append(errs, fmt.Errorf("%s: %s", p.Name(), line))
+		}
+	}
+	return errs
+}
+
+// schemaSource returns the source of the schema files in SDL. Introspection
+// results are printed in SDL.
+func schemaSource(schemaPaths string) string {
+	var sources []string
+	for _, p := range strings.Split(schemaPaths, ",") {
+		if isIntrospection(p) {
+			ts, e := loadIntrospection(p)
+			if e != nil {
+				log.Fatal(e)
+			}
+			var b bytes.Buffer
+			if e := printer.Fprint(&b, ts); e != nil {
+				log.Fatal(e)
+			}
+			sources = append(sources, b.String())
+			continue
+		}
+		src, e := ioutil.ReadFile(p)
+		if e != nil {
+			log.Fatalf("error occured while loading schema: %v", e)
+		}
+		sources = append(sources, string(src))
+	}
+	return strings.Join(sources, "\n")
+}
+
+// writeFiles writes the files into the directory of the package dir.
+func writeFiles(dir string, files []generator.File) error {
+	for _, f := range files {
+		p := path.Join(dir, f.Path)
+		if e := os.MkdirAll(path.Dir(p), 0755); e != nil {
+			return e
+		}
+		if e := writeFile(p, f.Content); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// writeFile writes content to p unless p already has the same content.
+func writeFile(p string, content []byte) error {
+	if old, e := ioutil.ReadFile(p); e != nil || !bytes.Equal(old, content) {
+		if e := ioutil.WriteFile(p, content, 0644); e != nil {
+			return e
+		}
+	}
+	cache.record(p)
+	return nil
+}
+
+// loadTypeSystem loads the comma separated schema files, which are SDL or
+// introspection results with the extension .json.
+func loadTypeSystem(schemaPaths string) *gql.TypeSystem {
+	ts, e := loadSchemas(strings.Split(schemaPaths, ","))
+	if e != nil {
+		log.Fatal(e)
+	}
+	return ts
+}
+
+// loadSchemas loads the schema files as one schema. Definitions of SDL files
+// and types of introspection results are added in the order of the files,
+// and extensions in SDL files are evaluated after all of them so that they
+// can extend types of any file. A type defined twice is an error.
+func loadSchemas(schemaPaths []string) (*gql.TypeSystem, error) {
+	ts := gql.NewTypeSystem()
+	var extensions []ast.DefinitionExpression
+	for _, p := range schemaPaths {
+		if isIntrospection(p) {
+			loaded, e := loadIntrospection(p)
+			if e != nil {
+				return nil, e
+			}
+			if e := ts.Add(loaded); e != nil {
+				return nil, e
+			}
+			continue
+		}
+		top, e := loadSchema(p)
+		if e != nil {
+			return nil, e
+		}
+		for _, exp := range top.Expressions {
+			if ast.IsExtension(exp) {
+				extensions = append(extensions, exp)
+				continue
+			}
+			def := gql.NewTypeSystem()
+			if e := exp.Eval(def); e != nil {
+				return nil, e
+			}
+			if e := ts.Add(def); e != nil {
+				return nil, e
+			}
+		}
+	}
+	for _, exp := range extensions {
+		if e := exp.Eval(ts); e != nil {
+			return nil, e
+		}
+	}
+	return ts, nil
+}
+
+func isIntrospection(schemaPath string) bool {
+	return filepath.Ext(schemaPath) == ".json"
+}
+
+func loadIntrospection(schemaPath string) (*gql.TypeSystem, error) {
+	f, e := os.Open(schemaPath)
+	if e != nil {
+		return nil, fmt.Errorf("error occured while loading schema: %v", e)
+	}
+	defer f.Close()
+	return introspection.Load(schemaPath, bufio.NewReader(f))
+}
+
+func loadSchema(schemaPath string) (*ast.TopLevel, error) {
+	f, e := os.Open(schemaPath)
+	if e != nil {
+		return nil, fmt.Errorf("error occured while loading schema: %v", e)
+	}
+	defer f.Close()
+	return parser.NewFileParser(schemaPath, bufio.NewReader(f)).Parse()
+}
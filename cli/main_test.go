@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/introspection"
+	"github.com/RettyEng/gqlcodegen/parser"
+)
+
+func TestLoadSchemas(t *testing.T) {
+	dir := t.TempDir()
+	upstream := parser.NewParser(strings.NewReader(`
+schema { query: Query }
+type Query { truck: Truck }
+type Truck { maker: String! }
+`)).ParseAndEvalSchema()
+	b, e := json.Marshal(introspection.Introspect(upstream))
+	if e != nil {
+		t.Fatal(e)
+	}
+	files := map[string]string{
+		"upstream.json":      string(b),
+		"extension.graphqls": "extend type Truck { capacity: Int! }\nextend type Query { garage: Garage }",
+		"garage.graphqls":    "type Garage { trucks: [Truck!]! }",
+		"truck.graphqls":     "type Truck { number: String! }",
+		"schema.graphqls":    "schema { query: Root }\ntype Root { a: Int }",
+	}
+	for name, src := range files {
+		if e := ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0644); e != nil {
+			t.Fatal(e)
+		}
+	}
+	paths := func(names ...string) []string {
+		var ps []string
+		for _, n := range names {
+			ps = append(ps, filepath.Join(dir, n))
+		}
+		return ps
+	}
+
+	ts, e := loadSchemas(paths("extension.graphqls", "upstream.json", "garage.graphqls"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	var fields []string
+	for _, f := range ts.ObjectTypes["Truck"].Fields {
+		fields = append(fields, f.Name)
+	}
+	if got := strings.Join(fields, ","); got != "maker,capacity" {
+		t.Errorf("got fields %s of Truck, want maker,capacity", got)
+	}
+	if ts.Schema.Query.Name != "Query" || len(ts.ObjectTypes["Query"].Fields) != 2 {
+		t.Errorf("extension of Query is not evaluated on the introspected one")
+	}
+
+	tests := []struct {
+		names []string
+		want  string
+	}{
+		{
+			names: []string{"upstream.json", "truck.graphqls"},
+			want:  "truck.graphqls:1:6: type Truck is already defined at upstream.json",
+		},
+		{
+			names: []string{"truck.graphqls", "upstream.json"},
+			want:  "upstream.json: type Truck is already defined at truck.graphqls:1:6",
+		},
+		{
+			names: []string{"schema.graphqls", "upstream.json"},
+			want:  "upstream.json: query root type Query conflicts with Root at schema.graphqls:1:17",
+		},
+		{
+			names: []string{"extension.graphqls", "garage.graphqls"},
+			want:  "extension.graphqls:1:13: cannot extend undefined type Truck",
+		},
+		{
+			names: []string{"garage.graphqls", "garage.graphqls"},
+			want:  "garage.graphqls:1:6: type Garage is already defined at garage.graphqls:1:6",
+		},
+	}
+	for _, tt := range tests {
+		_, e := loadSchemas(paths(tt.names...))
+		if e == nil {
+			t.Errorf("%v: loaded without an error", tt.names)
+			continue
+		}
+		if got := strings.Replace(e.Error(), dir+string(filepath.Separator), "", -1); got != tt.want {
+			t.Errorf("%v: got %q, want %q", tt.names, got, tt.want)
+		}
+	}
+}
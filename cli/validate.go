@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/RettyEng/gqlcodegen/ast"
+	"github.com/RettyEng/gqlcodegen/parser"
+	"github.com/RettyEng/gqlcodegen/validator"
+)
+
+// runValidateOps validates operations in the files against the schema.
+// Operations and fragments in all the files are validated as one document,
+// so fragments may be shared between files.
+//
+//	gqlcodegen validate-ops -schema=schema.graphqls ops/*.graphql
+func runValidateOps(args []string) {
+	fs := flag.NewFlagSet("validate-ops", flag.ExitOnError)
+	fs.StringVar(schema, "schema", "", "comma separated")
+	_ = fs.Parse(args)
+
+	ts := loadTypeSystem(*schema)
+	doc := &ast.ExecutableDocument{}
+	failed := false
+	for _, file := range expandFiles(fs.Args()) {
+		d, e := parser.ParseExecutableFile(file)
+		if e != nil {
+			fmt.Fprintln(os.Stderr, e)
+			failed = true
+			continue
+		}
+		doc.Definitions = append(doc.Definitions, d.Definitions...)
+	}
+	if failed {
+		os.Exit(1)
+	}
+
+	errs := validator.Validate(ts, doc.Eval())
+	for _, e := range errs {
+		fmt.Fprintln(os.Stderr, e)
+	}
+	if len(errs) > 0 {
+		os.Exit(1)
+	}
+}
+
+// expandFiles expands glob patterns in args which are not expanded by the
+// shell.
+func expandFiles(args []string) []string {
+	var files []string
+	for _, a := range args {
+		matches, e := filepath.Glob(a)
+		if e != nil || len(matches) == 0 {
+			files = append(files, a)
+			continue
+		}
+		files = append(files, matches...)
+	}
+	return files
+}
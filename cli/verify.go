@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/generator"
+)
+
+// runVerify reports methods of resolver implementations which do not match
+// the schema.
+//
+//	gqlcodegen verify -schema=schema.graphqls [-bind=QueryResolver=Query,...] [dir]
+func runVerify(args []string) {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	fs.StringVar(enumPackagePrefix, "enum-pkg-prefix", "", "")
+	fs.StringVar(scalarPackage, "scalar-pkg", "", "")
+	fs.StringVar(schema, "schema", "", "comma separated")
+	bind := fs.String("bind", "", "comma separated list of Resolver=Type")
+	_ = fs.Parse(args)
+
+	dir := "."
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+	pkg := loadPackage(dir)
+	g := createGenerator(pkg.Name(), dir, loadTypeSystem(*schema))
+
+	var problems []*generator.Problem
+	e := generator.Run(func() {
+		problems = g.Verify(pkg, parseBindings(*bind))
+	})
+	if e != nil {
+		log.Fatal(e)
+	}
+	for _, p := range problems {
+		fmt.Fprintln(os.Stderr, p)
+	}
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+func parseBindings(str string) map[string]string {
+	bindings := map[string]string{}
+	if str == "" {
+		return bindings
+	}
+	for _, b := range strings.Split(str, ",") {
+		kv := strings.SplitN(b, "=", 2)
+		if len(kv) != 2 {
+			log.Fatalf("illegal binding %s", b)
+		}
+		bindings[kv[0]] = kv[1]
+	}
+	return bindings
+}
+
+// loadPackage type-checks the go package in dir. Type errors are ignored
+// since the package is not expected to satisfy the schema yet.
+func loadPackage(dir string) *types.Package {
+	files, e := filepath.Glob(path.Join(dir, "*.go"))
+	if e != nil {
+		log.Fatal(e)
+	}
+	fset := token.NewFileSet()
+	var parsed []*ast.File
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+		file, e := parser.ParseFile(fset, f, nil, 0)
+		if e != nil {
+			log.Fatalf("error occured while parsing %s: %v", f, e)
+		}
+		parsed = append(parsed, file)
+	}
+	if len(parsed) == 0 {
+		log.Fatalf("no go files in %s", dir)
+	}
+
+	abs, _ := filepath.Abs(dir)
+	conf := &types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(abs, fset, parsed, nil)
+	return pkg
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	watch         = flag.Bool("watch", false, "regenerate on changes of the schema and operations")
+	watchInterval = flag.Duration("watch-interval", 500*time.Millisecond, "polling interval of -watch")
+)
+
+// stamp identifies a version of a watched file. The hash of the content
+// tells changes which keep the size within the resolution of the
+// modification time.
+type stamp struct {
+	modTime time.Time
+	size    int64
+	sum     [sha256.Size]byte
+}
+
+// runWatch generates the targets, and regenerates the targets affected
+// whenever the schema files or the operations change. Files are polled, and
+// changes are collected until a poll finds no more of them, so that a burst
+// of saves regenerates once.
+//
+// Generation runs in a child process of the same command, so that its
+// errors are printed without stopping the watch.
+func runWatch() {
+	targets := strings.Split(*generateTarget, ",")
+	regenerate(targets)
+
+	last := watchedFiles()
+	changed := map[string]bool{}
+	for range time.Tick(*watchInterval) {
+		current := watchedFiles()
+		diff := changedFiles(last, current)
+		last = current
+		for _, p := range diff {
+			changed[p] = true
+		}
+		if len(diff) > 0 || len(changed) == 0 {
+			continue
+		}
+		if affected := affectedTargets(targets, changed); len(affected) > 0 {
+			regenerate(affected)
+		}
+		changed = map[string]bool{}
+	}
+}
+
+// watchedFiles returns the stamps of the schema files and the operations of
+// the client target. Missing files are omitted, so that removing a file is
+// a change.
+func watchedFiles() map[string]stamp {
+	paths := strings.Split(*schema, ",")
+	if *operations != "" {
+		ops, _ := filepath.Glob(path.Join(*operations, "*.graphql"))
+		paths = append(paths, ops...)
+	}
+	files := map[string]stamp{}
+	for _, p := range paths {
+		info, e := os.Stat(p)
+		if e != nil {
+			continue
+		}
+		src, e := ioutil.ReadFile(p)
+		if e != nil {
+			continue
+		}
+		files[p] = stamp{info.ModTime(), info.Size(), sha256.Sum256(src)}
+	}
+	return files
+}
+
+func changedFiles(old, new map[string]stamp) []string {
+	var changed []string
+	for p, s := range new {
+		if o, ok := old[p]; !ok || o != s {
+			changed = append(changed, p)
+		}
+	}
+	for p := range old {
+		if _, ok := new[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// affectedTargets returns the targets depending on the changed files. Every
+// target depends on the schema, and only the targets whose plugins read the
+// operations depend on them.
+func affectedTargets(targets []string, changed map[string]bool) []string {
+	for _, p := range strings.Split(*schema, ",") {
+		if changed[p] {
+			return targets
+		}
+	}
+	var affected []string
+	for _, t := range targets {
+		if readsOperations(t) {
+			affected = append(affected, t)
+		}
+	}
+	return affected
+}
+
+// regenerate runs the command with the same flags and arguments for the
+// targets instead of watching.
+func regenerate(targets []string) {
+	self, e := os.Executable()
+	if e != nil {
+		log.Printf("cannot regenerate: %v", e)
+		return
+	}
+	var args []string
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "watch", "watch-interval", "target":
+			return
+		}
+		args = append(args, "-"+f.Name+"="+f.Value.String())
+	})
+	args = append(args, "-target="+strings.Join(targets, ","))
+	args = append(args, flag.Args()...)
+
+	cmd := exec.Command(self, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if e := cmd.Run(); e != nil {
+		log.Printf("generation of %s failed: %v", strings.Join(targets, ","), e)
+		return
+	}
+	log.Printf("generated %s", strings.Join(targets, ","))
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChangedFiles(t *testing.T) {
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+	a, b := sha256.Sum256([]byte("a")), sha256.Sum256([]byte("b"))
+	tests := []struct {
+		name string
+		old  map[string]stamp
+		new  map[string]stamp
+		want []string
+	}{
+		{
+			name: "same",
+			old:  map[string]stamp{"a.graphqls": {t0, 10, a}, "ops/q.graphql": {t0, 5, a}},
+			new:  map[string]stamp{"a.graphqls": {t0, 10, a}, "ops/q.graphql": {t0, 5, a}},
+		},
+		{
+			name: "modified",
+			old:  map[string]stamp{"a.graphqls": {t0, 10, a}, "b.graphqls": {t0, 10, a}},
+			new:  map[string]stamp{"a.graphqls": {t1, 10, a}, "b.graphqls": {t0, 10, a}},
+			want: []string{"a.graphqls"},
+		},
+		{
+			name: "resized in the same time",
+			old:  map[string]stamp{"a.graphqls": {t0, 10, a}},
+			new:  map[string]stamp{"a.graphqls": {t0, 11, a}},
+			want: []string{"a.graphqls"},
+		},
+		{
+			name: "rewritten in the same time and size",
+			old:  map[string]stamp{"a.graphqls": {t0, 10, a}},
+			new:  map[string]stamp{"a.graphqls": {t0, 10, b}},
+			want: []string{"a.graphqls"},
+		},
+		{
+			name: "added and removed",
+			old:  map[string]stamp{"ops/b.graphql": {t0, 10, a}, "ops/c.graphql": {t0, 10, a}},
+			new:  map[string]stamp{"ops/c.graphql": {t0, 10, a}, "ops/a.graphql": {t0, 10, a}},
+			want: []string{"ops/a.graphql", "ops/b.graphql"},
+		},
+		{
+			name: "first poll",
+			new:  map[string]stamp{"b.graphqls": {t0, 10, a}, "a.graphqls": {t0, 10, a}},
+			want: []string{"a.graphqls", "b.graphqls"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := changedFiles(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAffectedTargets(t *testing.T) {
+	defer func(s string) { *schema = s }(*schema)
+	*schema = "a.graphqls,b.graphqls"
+
+	tests := []struct {
+		name    string
+		targets []string
+		changed []string
+		want    []string
+	}{
+		{
+			name:    "schema",
+			targets: []string{"server", "client"},
+			changed: []string{"b.graphqls"},
+			want:    []string{"server", "client"},
+		},
+		{
+			name:    "schema and operations",
+			targets: []string{"server", "client"},
+			changed: []string{"ops/q.graphql", "a.graphqls"},
+			want:    []string{"server", "client"},
+		},
+		{
+			name:    "operations",
+			targets: []string{"server", "client"},
+			changed: []string{"ops/q.graphql"},
+			want:    []string{"client"},
+		},
+		{
+			name:    "operations without client",
+			targets: []string{"server", "enum"},
+			changed: []string{"ops/q.graphql"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			changed := map[string]bool{}
+			for _, p := range tt.changed {
+				changed[p] = true
+			}
+			if got := affectedTargets(tt.targets, changed); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchedFiles(t *testing.T) {
+	dir, e := ioutil.TempDir("", "watch")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	defer func(s, o string) { *schema, *operations = s, o }(*schema, *operations)
+
+	write := func(name, src string) string {
+		p := filepath.Join(dir, name)
+		if e := os.MkdirAll(filepath.Dir(p), 0755); e != nil {
+			t.Fatal(e)
+		}
+		if e := ioutil.WriteFile(p, []byte(src), 0644); e != nil {
+			t.Fatal(e)
+		}
+		return p
+	}
+	s := write("schema.graphqls", "type Query { a: Int }")
+	q := write("ops/q.graphql", "{ a }")
+	write("ops/notes.txt", "not an operation")
+	*schema = s + "," + filepath.Join(dir, "missing.graphqls")
+	*operations = filepath.Join(dir, "ops")
+
+	before := watchedFiles()
+	if _, ok := before[s]; !ok || len(before) != 2 {
+		t.Fatalf("watched files are %v", before)
+	}
+	if _, ok := before[q]; !ok {
+		t.Fatalf("operation %s is not watched", q)
+	}
+
+	info, e := os.Stat(s)
+	if e != nil {
+		t.Fatal(e)
+	}
+	write("schema.graphqls", "type Query { b: Int }")
+	if e := os.Chtimes(s, info.ModTime(), info.ModTime()); e != nil {
+		t.Fatal(e)
+	}
+	if got, want := changedFiles(before, watchedFiles()), []string{s}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rewrite keeping the time and the size: got %q, want %q", got, want)
+	}
+
+	write("schema.graphqls", "type Query { a: Int b: Int }")
+	if e := os.Remove(q); e != nil {
+		t.Fatal(e)
+	}
+	p := write("ops/p.graphql", "{ b }")
+	if got, want := changedFiles(before, watchedFiles()), []string{p, q, s}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,171 @@
+// Package diff compares two schemas and classifies their changes by whether
+// they break existing clients.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// Criticality is how a change affects existing clients.
+type Criticality int
+
+const (
+	// Breaking changes make valid operations invalid or change their results
+	// in a way clients cannot handle.
+	Breaking Criticality = iota
+	// Dangerous changes keep operations valid but may change their
+	// behaviour, e.g. an enum value clients do not know of.
+	Dangerous
+	// Safe changes do not affect existing clients.
+	Safe
+)
+
+func (c Criticality) String() string {
+	switch c {
+	case Breaking:
+		return "BREAKING"
+	case Dangerous:
+		return "DANGEROUS"
+	}
+	return "SAFE"
+}
+
+// Change is a difference between two schemas.
+type Change struct {
+	Criticality Criticality
+	// Path is the coordinate of the changed definition, e.g. Query.truck or
+	// Query.truck(number:).
+	Path    string
+	Message string
+	// Position is the position of the definition in the new schema, or in
+	// the old schema if it is removed.
+	Position gql.Position
+}
+
+func (c *Change) String() string {
+	if c.Position == (gql.Position{}) {
+		return fmt.Sprintf("%s: %s", c.Criticality, c.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", c.Position, c.Criticality, c.Message)
+}
+
+func (c *Change) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Criticality string `json:"criticality"`
+		Path        string `json:"path"`
+		Message     string `json:"message"`
+		Position    string `json:"position"`
+	}{c.Criticality.String(), c.Path, c.Message, c.Position.String()})
+}
+
+// HasBreaking returns whether changes contain a breaking change.
+func HasBreaking(changes []*Change) bool {
+	for _, c := range changes {
+		if c.Criticality == Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+type differ struct {
+	changes []*Change
+}
+
+// Compare returns changes from old to new, sorted by criticality and path.
+func Compare(old, new *gql.TypeSystem) []*Change {
+	d := &differ{}
+	d.schema(old, new)
+	d.types(old, new)
+	d.directives(old, new)
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		if d.changes[i].Criticality != d.changes[j].Criticality {
+			return d.changes[i].Criticality < d.changes[j].Criticality
+		}
+		return d.changes[i].Path < d.changes[j].Path
+	})
+	return d.changes
+}
+
+func (d *differ) add(c Criticality, path string, pos gql.Position, format string, args ...interface{}) {
+	d.changes = append(d.changes, &Change{
+		Criticality: c,
+		Path:        path,
+		Message:     fmt.Sprintf(format, args...),
+		Position:    pos,
+	})
+}
+
+func (d *differ) schema(old, new *gql.TypeSystem) {
+	roots := []struct {
+		name, def string
+		old, new  *gql.TypeRef
+	}{
+		{"query", "Query", old.Schema.Query, new.Schema.Query},
+		{"mutation", "Mutation", old.Schema.Mutation, new.Schema.Mutation},
+		{"subscription", "Subscription", old.Schema.Subscription, new.Schema.Subscription},
+	}
+	for _, r := range roots {
+		o, n := rootName(old, r.old, r.def), rootName(new, r.new, r.def)
+		switch {
+		case o == n:
+		case o == "":
+			d.add(Safe, "schema", gql.Position{}, "%s root type %s was added", r.name, n)
+		case n == "":
+			d.add(Breaking, "schema", gql.Position{}, "%s root type %s was removed", r.name, o)
+		default:
+			d.add(Breaking, "schema", gql.Position{}, "%s root type changed from %s to %s", r.name, o, n)
+		}
+	}
+}
+
+// rootName returns the name of the root operation type ref of ts, which is
+// the type named name by convention if ref is nil, or "" if there is none.
+func rootName(ts *gql.TypeSystem, ref *gql.TypeRef, name string) string {
+	if ref != nil {
+		name = ref.Name
+	}
+	if _, ok := ts.ObjectTypes[name]; !ok {
+		return ""
+	}
+	return name
+}
+
+// isSafeOutputChange returns whether a field of the type old can be changed
+// to new without breaking clients reading it.
+func isSafeOutputChange(old, new *gql.TypeRef) bool {
+	if !old.IsNullable && new.IsNullable {
+		return false
+	}
+	if old.Name == "[]" || new.Name == "[]" {
+		return old.Name == new.Name && isSafeOutputChange(old.InnerType, new.InnerType)
+	}
+	return old.Name == new.Name
+}
+
+// isSafeInputChange returns whether an argument or an input field of the
+// type old can be changed to new without breaking clients giving it.
+func isSafeInputChange(old, new *gql.TypeRef) bool {
+	if old.IsNullable && !new.IsNullable {
+		return false
+	}
+	if old.Name == "[]" || new.Name == "[]" {
+		return old.Name == new.Name && isSafeInputChange(old.InnerType, new.InnerType)
+	}
+	return old.Name == new.Name
+}
+
+func isRequired(v *gql.InputValue) bool {
+	return !v.Type.IsNullable && v.Default == nil
+}
+
+func valueString(v gql.Value) string {
+	if v == nil {
+		return ""
+	}
+	return v.Value()
+}
@@ -0,0 +1,213 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/introspection"
+	"github.com/RettyEng/gqlcodegen/parser"
+)
+
+func parse(t *testing.T, sdl string) *gql.TypeSystem {
+	t.Helper()
+	top, e := parser.NewParser(strings.NewReader(sdl)).Parse()
+	if e != nil {
+		t.Fatal(e)
+	}
+	ts, e := top.Eval()
+	if e != nil {
+		t.Fatal(e)
+	}
+	return ts
+}
+
+func TestCompare(t *testing.T) {
+	const base = `schema { query: Query }
+directive @cache(ttl: Int) on FIELD_DEFINITION | OBJECT
+interface Node { id: ID! }
+type Query { truck(id: ID!, size: Int = 10): Truck trucks: [Truck!]! }
+"A truck"
+type Truck implements Node { id: ID! maker: Maker! capacity: Int }
+union Vehicle = Truck
+enum Maker { HINO ISUZU }
+input Filter { maker: Maker size: Int = 10 }
+`
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []string
+	}{
+		{
+			name: "same",
+			old:  base,
+			new:  base,
+		},
+		{
+			name: "type removed and added",
+			old:  base,
+			new:  strings.Replace(base, "union Vehicle = Truck", "scalar Time", 1),
+			want: []string{
+				"BREAKING Vehicle: union Vehicle was removed",
+				"SAFE Time: scalar Time was added",
+			},
+		},
+		{
+			name: "kind changed",
+			old:  base,
+			new:  strings.Replace(base, "union Vehicle = Truck", "scalar Vehicle", 1),
+			want: []string{"BREAKING Vehicle: Vehicle changed from union to scalar"},
+		},
+		{
+			name: "fields",
+			old:  base,
+			new: strings.Replace(base,
+				"type Truck implements Node { id: ID! maker: Maker! capacity: Int }",
+				"type Truck implements Node { id: ID! maker: Maker capacity: Int! length: Int }", 1),
+			want: []string{
+				"BREAKING Truck.maker: field Truck.maker changed type from Maker! to Maker",
+				"SAFE Truck.capacity: field Truck.capacity changed type from Int to Int!",
+				"SAFE Truck.length: field Truck.length was added",
+			},
+		},
+		{
+			name: "field removed",
+			old:  base,
+			new:  strings.Replace(base, " capacity: Int }", " }", 1),
+			want: []string{"BREAKING Truck.capacity: field Truck.capacity was removed"},
+		},
+		{
+			name: "arguments",
+			old:  base,
+			new:  strings.Replace(base, "truck(id: ID!, size: Int = 10)", "truck(id: String!, size: Int = 20, kind: Int, owner: ID!)", 1),
+			want: []string{
+				"BREAKING Query.truck(id:): argument Query.truck(id:) changed type from ID! to String!",
+				"BREAKING Query.truck(owner:): required argument Query.truck(owner:) was added",
+				"DANGEROUS Query.truck(kind:): optional argument Query.truck(kind:) was added",
+				"DANGEROUS Query.truck(size:): default value of argument Query.truck(size:) changed from \"10\" to \"20\"",
+			},
+		},
+		{
+			name: "input fields",
+			old:  base,
+			new:  strings.Replace(base, "input Filter { maker: Maker size: Int = 10 }", "input Filter { maker: Maker! owner: ID }", 1),
+			want: []string{
+				"BREAKING Filter.maker: input field Filter.maker changed type from Maker to Maker!",
+				"BREAKING Filter.size: input field Filter.size was removed",
+				"DANGEROUS Filter.owner: optional input field Filter.owner was added",
+			},
+		},
+		{
+			name: "enum values",
+			old:  base,
+			new:  strings.Replace(base, "enum Maker { HINO ISUZU }", "enum Maker { HINO VOLVO }", 1),
+			want: []string{
+				"BREAKING Maker.ISUZU: enum value Maker.ISUZU was removed",
+				"DANGEROUS Maker.VOLVO: enum value Maker.VOLVO was added",
+			},
+		},
+		{
+			name: "union members",
+			old:  base,
+			new:  strings.Replace(strings.Replace(base, "union Vehicle = Truck", "union Vehicle = Trailer", 1), "enum Maker", "type Trailer { id: ID! }\nenum Maker", 1),
+			want: []string{
+				"BREAKING Vehicle: member Truck was removed from union Vehicle",
+				"DANGEROUS Vehicle: member Trailer was added to union Vehicle",
+				"SAFE Trailer: object Trailer was added",
+			},
+		},
+		{
+			name: "interfaces",
+			old:  base,
+			new:  strings.Replace(base, "type Truck implements Node {", "type Truck {", 1),
+			want: []string{"BREAKING Truck: Truck no longer implements Node"},
+		},
+		{
+			name: "interface added",
+			old:  strings.Replace(base, "type Truck implements Node {", "type Truck {", 1),
+			new:  base,
+			want: []string{"DANGEROUS Truck: Truck now implements Node"},
+		},
+		{
+			name: "directives",
+			old:  base,
+			new:  strings.Replace(base, "directive @cache(ttl: Int) on FIELD_DEFINITION | OBJECT", "directive @cache(ttl: Int) on FIELD_DEFINITION | INTERFACE\ndirective @auth on OBJECT", 1),
+			want: []string{
+				"BREAKING @cache: location OBJECT was removed from directive @cache",
+				"SAFE @auth: directive @auth was added",
+				"SAFE @cache: location INTERFACE was added to directive @cache",
+			},
+		},
+		{
+			name: "root types",
+			old:  base,
+			new:  strings.Replace(base, "schema { query: Query }", "schema { query: Query mutation: Query }", 1),
+			want: []string{"SAFE schema: mutation root type Query was added"},
+		},
+		{
+			name: "deprecation and description",
+			old:  base,
+			new: strings.Replace(strings.Replace(base,
+				"capacity: Int }", "capacity: Int @deprecated(reason: \"Use load\") }", 1),
+				"\"A truck\"", "\"A lorry\"", 1),
+			want: []string{
+				"SAFE Truck: description of Truck changed",
+				"SAFE Truck.capacity: field Truck.capacity was deprecated: Use load",
+			},
+		},
+		{
+			name: "description in another form",
+			old:  base,
+			new:  strings.Replace(base, "\"A truck\"", "\"\"\"\n  A truck\n\"\"\"", 1),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range Compare(parse(t, tt.old), parse(t, tt.new)) {
+				got = append(got, c.Criticality.String()+" "+c.Path+": "+c.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+			if HasBreaking(Compare(parse(t, tt.old), parse(t, tt.new))) != hasPrefix(tt.want, "BREAKING") {
+				t.Errorf("HasBreaking does not agree with the changes")
+			}
+		})
+	}
+}
+
+// TestCompareIntrospection checks that a schema is the same as its
+// introspection, even though descriptions take another form there.
+func TestCompareIntrospection(t *testing.T) {
+	ts := parse(t, `"""
+  A truck
+"""
+type Query { "The maker" maker: String "  capacity" capacity: Int }
+`)
+	b, e := json.Marshal(introspection.Introspect(ts))
+	if e != nil {
+		t.Fatal(e)
+	}
+	loaded, e := introspection.Load("upstream.json", bytes.NewReader(b))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if changes := Compare(ts, loaded); len(changes) > 0 {
+		t.Errorf("got changes %v", changes)
+	}
+}
+
+func hasPrefix(strs []string, prefix string) bool {
+	for _, s := range strs {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
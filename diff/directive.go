@@ -0,0 +1,46 @@
+package diff
+
+import (
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+func (d *differ) directives(old, new *gql.TypeSystem) {
+	names := map[string]bool{}
+	for n := range old.Directives {
+		names[n] = true
+	}
+	for n := range new.Directives {
+		names[n] = true
+	}
+	for _, n := range gql.SortedKeys(names) {
+		o, nw := old.Directives[n], new.Directives[n]
+		path := "@" + n
+		switch {
+		case nw == nil:
+			d.add(Breaking, path, o.Position, "directive %s was removed", path)
+			continue
+		case o == nil:
+			d.add(Safe, path, nw.Position, "directive %s was added", path)
+			continue
+		}
+		newLocations := map[string]bool{}
+		for _, l := range nw.Location {
+			newLocations[l.String()] = true
+		}
+		oldLocations := map[string]bool{}
+		for _, l := range o.Location {
+			oldLocations[l.String()] = true
+		}
+		for _, l := range gql.SortedKeys(oldLocations) {
+			if !newLocations[l] {
+				d.add(Breaking, path, nw.Position, "location %s was removed from directive %s", l, path)
+			}
+		}
+		for _, l := range gql.SortedKeys(newLocations) {
+			if !oldLocations[l] {
+				d.add(Safe, path, nw.Position, "location %s was added to directive %s", l, path)
+			}
+		}
+		d.args(path, o.Arguments, nw.Arguments)
+	}
+}
@@ -0,0 +1,237 @@
+package diff
+
+import (
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// namedType is a type definition of any kind.
+type namedType struct {
+	kind     string
+	position gql.Position
+	def      gql.Commentable
+}
+
+func namedTypes(ts *gql.TypeSystem) map[string]*namedType {
+	types := map[string]*namedType{}
+	for n, t := range ts.ScalarTypes {
+		types[n] = &namedType{"scalar", t.Position, t}
+	}
+	for n, t := range ts.ObjectTypes {
+		types[n] = &namedType{"object", t.Position, t}
+	}
+	for n, t := range ts.InterfaceTypes {
+		types[n] = &namedType{"interface", t.Position, t}
+	}
+	for n, t := range ts.UnionTypes {
+		types[n] = &namedType{"union", t.Position, t}
+	}
+	for n, t := range ts.EnumTypes {
+		types[n] = &namedType{"enum", t.Position, t}
+	}
+	for n, t := range ts.InputObjectTypes {
+		types[n] = &namedType{"input", t.Position, t}
+	}
+	return types
+}
+
+func (d *differ) types(old, new *gql.TypeSystem) {
+	oldTypes, newTypes := namedTypes(old), namedTypes(new)
+	names := map[string]bool{}
+	for n := range oldTypes {
+		names[n] = true
+	}
+	for n := range newTypes {
+		names[n] = true
+	}
+	for _, n := range gql.SortedKeys(names) {
+		o, nw := oldTypes[n], newTypes[n]
+		switch {
+		case nw == nil:
+			d.add(Breaking, n, o.position, "%s %s was removed", o.kind, n)
+			continue
+		case o == nil:
+			d.add(Safe, n, nw.position, "%s %s was added", nw.kind, n)
+			continue
+		case o.kind != nw.kind:
+			d.add(Breaking, n, nw.position, "%s changed from %s to %s", n, o.kind, nw.kind)
+			continue
+		}
+		if gql.StringValue(o.def.GetDescription()) != gql.StringValue(nw.def.GetDescription()) {
+			d.add(Safe, n, nw.position, "description of %s changed", n)
+		}
+		switch o := o.def.(type) {
+		case *gql.Object:
+			nw := nw.def.(*gql.Object)
+			d.implements(n, o.Implements, nw.Implements, nw.Position)
+			d.fields(n, o.Fields, nw.Fields)
+		case *gql.Interface:
+			d.fields(n, o.Fields, nw.def.(*gql.Interface).Fields)
+		case *gql.Union:
+			d.members(o, nw.def.(*gql.Union))
+		case *gql.Enum:
+			d.enumValues(o, nw.def.(*gql.Enum))
+		case *gql.InputObject:
+			d.inputFields(n, o.InputValue, nw.def.(*gql.InputObject).InputValue)
+		}
+	}
+}
+
+func (d *differ) implements(typ string, old, new []*gql.TypeRef, pos gql.Position) {
+	oldNames, newNames := refNames(old), refNames(new)
+	for _, n := range gql.SortedKeys(oldNames) {
+		if !newNames[n] {
+			d.add(Breaking, typ, pos, "%s no longer implements %s", typ, n)
+		}
+	}
+	for _, n := range gql.SortedKeys(newNames) {
+		if !oldNames[n] {
+			d.add(Dangerous, typ, pos, "%s now implements %s", typ, n)
+		}
+	}
+}
+
+func (d *differ) members(old, new *gql.Union) {
+	oldNames, newNames := refNames(old.Members), refNames(new.Members)
+	for _, n := range gql.SortedKeys(oldNames) {
+		if !newNames[n] {
+			d.add(Breaking, new.Name, new.Position, "member %s was removed from union %s", n, new.Name)
+		}
+	}
+	for _, n := range gql.SortedKeys(newNames) {
+		if !oldNames[n] {
+			d.add(Dangerous, new.Name, new.Position, "member %s was added to union %s", n, new.Name)
+		}
+	}
+}
+
+func refNames(refs []*gql.TypeRef) map[string]bool {
+	names := map[string]bool{}
+	for _, r := range refs {
+		names[r.Name] = true
+	}
+	return names
+}
+
+func (d *differ) enumValues(old, new *gql.Enum) {
+	newValues := map[string]*gql.EnumValue{}
+	for _, v := range new.Values {
+		newValues[v.Name] = v
+	}
+	oldValues := map[string]*gql.EnumValue{}
+	for _, v := range old.Values {
+		oldValues[v.Name] = v
+		path := old.Name + "." + v.Name
+		nv, ok := newValues[v.Name]
+		if !ok {
+			d.add(Breaking, path, v.Position, "enum value %s was removed", path)
+			continue
+		}
+		d.deprecation(path, "enum value", v.Directives, nv.Directives, nv.Position)
+	}
+	for _, v := range new.Values {
+		if _, ok := oldValues[v.Name]; !ok {
+			path := new.Name + "." + v.Name
+			d.add(Dangerous, path, v.Position, "enum value %s was added", path)
+		}
+	}
+}
+
+func (d *differ) fields(typ string, old, new []*gql.ObjectField) {
+	newFields := map[string]*gql.ObjectField{}
+	for _, f := range new {
+		newFields[f.Name] = f
+	}
+	oldFields := map[string]bool{}
+	for _, f := range old {
+		oldFields[f.Name] = true
+		path := typ + "." + f.Name
+		nf, ok := newFields[f.Name]
+		if !ok {
+			d.add(Breaking, path, f.Position, "field %s was removed", path)
+			continue
+		}
+		if !isSafeOutputChange(f.Type, nf.Type) {
+			d.add(Breaking, path, nf.Position, "field %s changed type from %s to %s", path, f.Type, nf.Type)
+		} else if f.Type.String() != nf.Type.String() {
+			d.add(Safe, path, nf.Position, "field %s changed type from %s to %s", path, f.Type, nf.Type)
+		}
+		d.deprecation(path, "field", f.Directives, nf.Directives, nf.Position)
+		d.args(path, f.Args, nf.Args)
+	}
+	for _, f := range new {
+		if !oldFields[f.Name] {
+			path := typ + "." + f.Name
+			d.add(Safe, path, f.Position, "field %s was added", path)
+		}
+	}
+}
+
+func (d *differ) deprecation(path, what string, old, new []*gql.DirectiveRef, pos gql.Position) {
+	wasDeprecated, _ := gql.Deprecation(old)
+	isDeprecated, reason := gql.Deprecation(new)
+	switch {
+	case !wasDeprecated && isDeprecated:
+		if reason == "" {
+			d.add(Safe, path, pos, "%s %s was deprecated", what, path)
+		} else {
+			d.add(Safe, path, pos, "%s %s was deprecated: %s", what, path, reason)
+		}
+	case wasDeprecated && !isDeprecated:
+		d.add(Safe, path, pos, "%s %s is no longer deprecated", what, path)
+	}
+}
+
+// args compares arguments of the field or the directive owner.
+func (d *differ) args(owner string, old, new []*gql.InputValue) {
+	d.inputValues("argument", old, new, func(name string) string {
+		return owner + "(" + name + ":)"
+	})
+}
+
+func (d *differ) inputFields(typ string, old, new []*gql.InputValue) {
+	d.inputValues("input field", old, new, func(name string) string {
+		return typ + "." + name
+	})
+}
+
+// inputValues compares arguments or input fields. what is the kind of the
+// values and path returns the path of the value name.
+func (d *differ) inputValues(
+	what string,
+	old, new []*gql.InputValue,
+	path func(name string) string,
+) {
+	newValues := map[string]*gql.InputValue{}
+	for _, v := range new {
+		newValues[v.Name] = v
+	}
+	oldValues := map[string]bool{}
+	for _, v := range old {
+		oldValues[v.Name] = true
+		p := path(v.Name)
+		nv, ok := newValues[v.Name]
+		if !ok {
+			d.add(Breaking, p, v.Position, "%s %s was removed", what, p)
+			continue
+		}
+		if !isSafeInputChange(v.Type, nv.Type) {
+			d.add(Breaking, p, nv.Position, "%s %s changed type from %s to %s", what, p, v.Type, nv.Type)
+		} else if v.Type.String() != nv.Type.String() {
+			d.add(Safe, p, nv.Position, "%s %s changed type from %s to %s", what, p, v.Type, nv.Type)
+		}
+		if o, n := valueString(v.Default), valueString(nv.Default); o != n {
+			d.add(Dangerous, p, nv.Position, "default value of %s %s changed from %q to %q", what, p, o, n)
+		}
+	}
+	for _, v := range new {
+		if oldValues[v.Name] {
+			continue
+		}
+		p := path(v.Name)
+		if isRequired(v) {
+			d.add(Breaking, p, v.Position, "required %s %s was added", what, p)
+		} else {
+			d.add(Dangerous, p, v.Position, "optional %s %s was added", what, p)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+// Package docs generates static documentation of a schema, a page for each
+// type and an index, in Markdown or HTML.
+package docs
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// format renders the elements of pages. Inline elements are returned as
+// strings and blocks are written to the buffer.
+type format interface {
+	ext() string
+	text(s string) string
+	code(s string) string
+	link(text, file, anchor string) string
+
+	heading(b *bytes.Buffer, level int, anchor, text string)
+	description(b *bytes.Buffer, desc string)
+	paragraph(b *bytes.Buffer, text string)
+	list(b *bytes.Buffer, items []string)
+	page(title, body string) string
+}
+
+// Markdown returns the documentation of ts as Markdown files keyed by their
+// names.
+func Markdown(ts *gql.TypeSystem) map[string]string {
+	return generate(ts, markdown{})
+}
+
+// HTML returns the documentation of ts as HTML files keyed by their names.
+func HTML(ts *gql.TypeSystem) map[string]string {
+	return generate(ts, html{})
+}
+
+type site struct {
+	ts *gql.TypeSystem
+	f  format
+}
+
+func generate(ts *gql.TypeSystem, f format) map[string]string {
+	s := &site{ts: ts, f: f}
+	files := map[string]string{
+		"index" + f.ext(): s.index(),
+	}
+	for _, n := range s.typeNames() {
+		files[n+f.ext()] = s.typePage(n)
+	}
+	return files
+}
+
+// typeNames returns names of all the types in order.
+func (s *site) typeNames() []string {
+	var names []string
+	for n := range s.ts.ScalarTypes {
+		names = append(names, n)
+	}
+	for n := range s.ts.ObjectTypes {
+		names = append(names, n)
+	}
+	for n := range s.ts.InterfaceTypes {
+		names = append(names, n)
+	}
+	for n := range s.ts.UnionTypes {
+		names = append(names, n)
+	}
+	for n := range s.ts.EnumTypes {
+		names = append(names, n)
+	}
+	for n := range s.ts.InputObjectTypes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *site) kind(name string) string {
+	if _, ok := s.ts.ScalarTypes[name]; ok {
+		return "scalar"
+	}
+	if _, ok := s.ts.ObjectTypes[name]; ok {
+		return "object"
+	}
+	if _, ok := s.ts.InterfaceTypes[name]; ok {
+		return "interface"
+	}
+	if _, ok := s.ts.UnionTypes[name]; ok {
+		return "union"
+	}
+	if _, ok := s.ts.EnumTypes[name]; ok {
+		return "enum"
+	}
+	if _, ok := s.ts.InputObjectTypes[name]; ok {
+		return "input"
+	}
+	return ""
+}
+
+/*********************************************************
+Index
+ *********************************************************/
+
+func (s *site) index() string {
+	b := &bytes.Buffer{}
+	s.f.heading(b, 1, "", "Schema")
+	roots := []struct {
+		name string
+		ref  *gql.TypeRef
+	}{
+		{"Query", s.ts.Schema.Query},
+		{"Mutation", s.ts.Schema.Mutation},
+		{"Subscription", s.ts.Schema.Subscription},
+	}
+	var items []string
+	for _, r := range roots {
+		name := r.name
+		if r.ref != nil {
+			name = r.ref.Name
+		}
+		if _, ok := s.ts.ObjectTypes[name]; ok {
+			items = append(items, s.f.text(strings.ToLower(r.name)+": ")+s.typeLink(name))
+		}
+	}
+	s.f.list(b, items)
+
+	kinds := []struct{ kind, title string }{
+		{"object", "Objects"},
+		{"interface", "Interfaces"},
+		{"union", "Unions"},
+		{"enum", "Enums"},
+		{"input", "Input objects"},
+		{"scalar", "Scalars"},
+	}
+	for _, k := range kinds {
+		var items []string
+		for _, n := range s.typeNames() {
+			if s.kind(n) == k.kind {
+				items = append(items, s.typeLink(n))
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+		s.f.heading(b, 2, "", k.title)
+		s.f.list(b, items)
+	}
+
+	if len(s.ts.Directives) > 0 {
+		s.f.heading(b, 2, "", "Directives")
+	}
+	var names []string
+	for n := range s.ts.Directives {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		d := s.ts.Directives[n]
+		s.f.heading(b, 3, n, "@"+n)
+		s.f.description(b, d.Description)
+		var locations []string
+		for _, l := range d.Location {
+			locations = append(locations, s.f.code(l.String()))
+		}
+		s.f.paragraph(b, s.f.text("Locations: ")+strings.Join(locations, ", "))
+		s.arguments(b, d.Arguments)
+	}
+	return s.f.page("Schema", b.String())
+}
+
+/*********************************************************
+Types
+ *********************************************************/
+
+func (s *site) typePage(name string) string {
+	b := &bytes.Buffer{}
+	s.f.heading(b, 1, "", name)
+	s.f.paragraph(b, s.f.link(s.f.text("Schema"), "index", "")+s.f.text(" / "+s.kind(name)))
+
+	switch s.kind(name) {
+	case "scalar":
+		t := s.ts.ScalarTypes[name]
+		s.head(b, t.Description, t.Directives)
+	case "object":
+		t := s.ts.ObjectTypes[name]
+		s.head(b, t.Description, t.Directives)
+		s.typeList(b, "Implements", refNames(t.Implements))
+		s.fields(b, t.Fields)
+		s.typeList(b, "Member of", s.unionsOf(name))
+	case "interface":
+		t := s.ts.InterfaceTypes[name]
+		s.head(b, t.Description, t.Directives)
+		s.fields(b, t.Fields)
+		s.typeList(b, "Implemented by", s.implementations(name))
+	case "union":
+		t := s.ts.UnionTypes[name]
+		s.head(b, t.Description, t.Directives)
+		s.typeList(b, "Members", refNames(t.Members))
+	case "enum":
+		t := s.ts.EnumTypes[name]
+		s.head(b, t.Description, t.Directives)
+		s.f.heading(b, 2, "", "Values")
+		for _, v := range t.Values {
+			s.f.heading(b, 3, v.Name, v.Name)
+			s.head(b, v.Description, v.Directives)
+		}
+	case "input":
+		t := s.ts.InputObjectTypes[name]
+		s.head(b, t.Description, t.Directives)
+		s.f.heading(b, 2, "", "Fields")
+		for _, v := range t.InputValue {
+			s.f.heading(b, 3, v.Name, v.Name)
+			s.f.paragraph(b, s.f.text("Type: ")+s.typeRef(v.Type))
+			s.head(b, v.Description, v.Directives)
+			if v.Default != nil {
+				s.f.paragraph(b, s.f.text("Default: ")+s.f.code(v.Default.Value()))
+			}
+		}
+	}
+	return s.f.page(name, b.String())
+}
+
+// head writes the description, the deprecation and the directives of a
+// definition.
+func (s *site) head(b *bytes.Buffer, desc string, dirs []*gql.DirectiveRef) {
+	s.f.description(b, desc)
+	if deprecated, reason := gql.Deprecation(dirs); deprecated {
+		s.f.paragraph(b, s.f.text("Deprecated: "+reason))
+	}
+	var usages []string
+	for _, d := range dirs {
+		if d.Name != "deprecated" {
+			usages = append(usages, s.directiveUsage(d))
+		}
+	}
+	if len(usages) > 0 {
+		s.f.paragraph(b, s.f.text("Directives: ")+strings.Join(usages, " "))
+	}
+}
+
+func (s *site) directiveUsage(d *gql.DirectiveRef) string {
+	name := s.f.code("@" + d.Name)
+	if _, ok := s.ts.Directives[d.Name]; ok {
+		name = s.f.link(name, "index", d.Name)
+	}
+	if len(d.ArgNames) == 0 {
+		return name
+	}
+	var args []string
+	for _, n := range d.ArgNames {
+		args = append(args, n+": "+d.Args[n].Value())
+	}
+	return name + s.f.code("("+strings.Join(args, ", ")+")")
+}
+
+func (s *site) fields(b *bytes.Buffer, fields []*gql.ObjectField) {
+	s.f.heading(b, 2, "", "Fields")
+	for _, f := range fields {
+		s.f.heading(b, 3, f.Name, f.Name)
+		s.f.paragraph(b, s.f.text("Type: ")+s.typeRef(f.Type))
+		s.head(b, f.Description, f.Directives)
+		s.arguments(b, f.Args)
+	}
+}
+
+func (s *site) arguments(b *bytes.Buffer, args []*gql.InputValue) {
+	if len(args) == 0 {
+		return
+	}
+	var items []string
+	for _, a := range args {
+		item := s.f.code(a.Name) + s.f.text(": ") + s.typeRef(a.Type)
+		if a.Default != nil {
+			item += s.f.text(" = ") + s.f.code(a.Default.Value())
+		}
+		if a.Description != "" {
+			item += s.f.text(" - " + oneLine(gql.StringValue(a.Description)))
+		}
+		for _, d := range a.Directives {
+			item += " " + s.directiveUsage(d)
+		}
+		items = append(items, item)
+	}
+	s.f.paragraph(b, s.f.text("Arguments:"))
+	s.f.list(b, items)
+}
+
+// typeList writes a list of links to the types names under title.
+func (s *site) typeList(b *bytes.Buffer, title string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	var items []string
+	for _, n := range names {
+		items = append(items, s.typeLink(n))
+	}
+	s.f.heading(b, 2, "", title)
+	s.f.list(b, items)
+}
+
+// typeRef renders ref with a link to the named type.
+func (s *site) typeRef(ref *gql.TypeRef) string {
+	str := ""
+	if ref.Name == "[]" {
+		str = s.f.text("[") + s.typeRef(ref.InnerType) + s.f.text("]")
+	} else {
+		str = s.typeLink(ref.Name)
+	}
+	if !ref.IsNullable {
+		str += s.f.text("!")
+	}
+	return str
+}
+
+// typeLink returns a link to the type name, or the name if the schema does
+// not define it.
+func (s *site) typeLink(name string) string {
+	if s.kind(name) == "" {
+		return s.f.code(name)
+	}
+	return s.f.link(s.f.code(name), name, "")
+}
+
+func (s *site) implementations(name string) []string {
+	var names []string
+	for n, o := range s.ts.ObjectTypes {
+		for _, i := range o.Implements {
+			if i.Name == name {
+				names = append(names, n)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *site) unionsOf(name string) []string {
+	var names []string
+	for n, u := range s.ts.UnionTypes {
+		for _, m := range u.Members {
+			if m.Name == name {
+				names = append(names, n)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func refNames(refs []*gql.TypeRef) []string {
+	var names []string
+	for _, r := range refs {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
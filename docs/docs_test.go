@@ -0,0 +1,113 @@
+package docs
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/parser"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the generated ones")
+
+// goldenDir holds pages of the documentation of the example schema.
+const goldenDir = "testdata/example"
+
+// goldenPages are the pages compared with the golden files. They cover the
+// index, objects with arguments and default values, and enums with
+// deprecations.
+var goldenPages = []string{"index", "Garage", "Truck", "Maker"}
+
+func parse(t *testing.T, src string) *gql.TypeSystem {
+	t.Helper()
+	top, e := parser.NewParser(strings.NewReader(src)).Parse()
+	if e != nil {
+		t.Fatal(e)
+	}
+	ts, e := top.Eval()
+	if e != nil {
+		t.Fatal(e)
+	}
+	return ts
+}
+
+// TestGolden compares pages of the example schema with the golden files. Run
+// it with -update to rewrite them after changing the formats.
+func TestGolden(t *testing.T) {
+	src, e := ioutil.ReadFile("../example/schema.graphqls")
+	if e != nil {
+		t.Fatal(e)
+	}
+	ts := parse(t, string(src))
+	for _, pages := range []map[string]string{Markdown(ts), HTML(ts)} {
+		for _, n := range goldenPages {
+			name := n + ".md"
+			if _, ok := pages[name]; !ok {
+				name = n + ".html"
+			}
+			got, ok := pages[name]
+			if !ok {
+				t.Errorf("page %s is not generated", n)
+				continue
+			}
+			p := path.Join(goldenDir, name)
+			if *update {
+				if e := os.MkdirAll(goldenDir, 0755); e != nil {
+					t.Fatal(e)
+				}
+				if e := ioutil.WriteFile(p, []byte(got), 0644); e != nil {
+					t.Fatal(e)
+				}
+			}
+			want, e := ioutil.ReadFile(p)
+			if e != nil {
+				t.Fatal(e)
+			}
+			if got != string(want) {
+				t.Errorf("%s differs from the golden file, run go test -update if the changes are intended", name)
+			}
+		}
+	}
+}
+
+func TestHTMLEscape(t *testing.T) {
+	ts := parse(t, `
+"<b>Trucks</b> & \"trailers\""
+type Query {
+  "Returns <script>alert(1)</script>"
+  truck(
+    "a <i>number</i>"
+    number: String = "<none>"
+  ): String @deprecated(reason: "use <em>trucks</em>")
+}
+
+enum Maker {
+  "<Volvo>"
+  VOLVO
+}
+`)
+	pages := HTML(ts)
+	for name, page := range pages {
+		for _, raw := range []string{"<b>", "<script>", "<i>", "<none>", "<em>", "<Volvo>"} {
+			if strings.Contains(page, raw) {
+				t.Errorf("%s contains %s unescaped", name, raw)
+			}
+		}
+	}
+	for _, escaped := range []string{
+		"&lt;b&gt;Trucks&lt;/b&gt; &amp; &#34;trailers&#34;",
+		"Returns &lt;script&gt;alert(1)&lt;/script&gt;",
+		"a &lt;i&gt;number&lt;/i&gt;",
+	} {
+		if !strings.Contains(pages["Query.html"], escaped) {
+			t.Errorf("Query.html does not contain %s", escaped)
+		}
+	}
+	if !strings.Contains(pages["Maker.html"], "&lt;Volvo&gt;") {
+		t.Errorf("Maker.html does not contain the escaped description of VOLVO")
+	}
+}
@@ -0,0 +1,86 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+type html struct{}
+
+func (html) ext() string {
+	return ".html"
+}
+
+func (html) text(s string) string {
+	return template.HTMLEscapeString(s)
+}
+
+func (html) code(s string) string {
+	return "<code>" + template.HTMLEscapeString(s) + "</code>"
+}
+
+func (html) link(text, file, anchor string) string {
+	target := file + ".html"
+	if anchor != "" {
+		target += "#" + anchor
+	}
+	return `<a href="` + template.HTMLEscapeString(target) + `">` + text + "</a>"
+}
+
+func (h html) heading(b *bytes.Buffer, level int, anchor, text string) {
+	id := ""
+	if anchor != "" {
+		id = ` id="` + template.HTMLEscapeString(anchor) + `"`
+	}
+	fmt.Fprintf(b, "<h%d%s>%s</h%d>\n", level, id, h.text(text), level)
+}
+
+// description writes paragraphs of desc separated by blank lines.
+func (h html) description(b *bytes.Buffer, desc string) {
+	if desc == "" {
+		return
+	}
+	for _, p := range strings.Split(gql.StringValue(desc), "\n\n") {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		h.paragraph(b, strings.Replace(h.text(p), "\n", "<br>\n", -1))
+	}
+}
+
+func (html) paragraph(b *bytes.Buffer, text string) {
+	fmt.Fprintf(b, "<p>%s</p>\n", text)
+}
+
+func (html) list(b *bytes.Buffer, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	b.WriteString("<ul>\n")
+	for _, i := range items {
+		fmt.Fprintf(b, "<li>%s</li>\n", i)
+	}
+	b.WriteString("</ul>\n")
+}
+
+func (h html) page(title, body string) string {
+	return `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>` + h.text(title) + `</title>
+<style>
+body { font-family: sans-serif; max-width: 50em; margin: 2em auto; line-height: 1.5; }
+code { background: #f4f4f4; padding: 0 .2em; }
+h3 { margin-bottom: 0; }
+</style>
+</head>
+<body>
+` + body + `</body>
+</html>
+`
+}
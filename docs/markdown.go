@@ -0,0 +1,69 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+type markdown struct{}
+
+func (markdown) ext() string {
+	return ".md"
+}
+
+func (markdown) text(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`,
+		"[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "|", `\|`,
+	)
+	return r.Replace(s)
+}
+
+func (markdown) code(s string) string {
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
+
+func (markdown) link(text, file, anchor string) string {
+	target := file + ".md"
+	if anchor != "" {
+		target += "#" + strings.ToLower(anchor)
+	}
+	return "[" + text + "](" + target + ")"
+}
+
+func (m markdown) heading(b *bytes.Buffer, level int, anchor, text string) {
+	fmt.Fprintf(b, "%s %s\n\n", strings.Repeat("#", level), m.text(text))
+}
+
+// description writes desc as it is, since descriptions are Markdown by the
+// specification.
+func (markdown) description(b *bytes.Buffer, desc string) {
+	if desc == "" {
+		return
+	}
+	fmt.Fprintf(b, "%s\n\n", gql.StringValue(desc))
+}
+
+func (markdown) paragraph(b *bytes.Buffer, text string) {
+	fmt.Fprintf(b, "%s\n\n", text)
+}
+
+func (markdown) list(b *bytes.Buffer, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	for _, i := range items {
+		fmt.Fprintf(b, "- %s\n", i)
+	}
+	b.WriteString("\n")
+}
+
+func (markdown) page(title, body string) string {
+	return strings.TrimRight(body, "\n") + "\n"
+}
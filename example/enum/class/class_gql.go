@@ -2,17 +2,18 @@
 package class
 
 import (
+	"encoding/json"
 	"errors"
 	"strconv"
 )
 
 /*
-   Description:
-     """
-     Driver class
-     """
-   Directives:
-     @legend()
+	Description:
+	  """
+	  Driver class
+	  """
+	Directives:
+	  @legend()
 */
 type Class int
 
@@ -39,7 +40,7 @@ func (v Class) String() string {
 	return _Class_Name[_Class_Index[v]:_Class_Index[v+1]]
 }
 
-func _ClassFromString(str string) (Class, error) {
+func ClassFromString(str string) (Class, error) {
 	for i := 0; i < len(_Class_Index)-1; i++ {
 		if v := Class(i); str == v.String() {
 			return v, nil
@@ -55,7 +56,7 @@ func (Class) ImplementsGraphQLType(name string) bool {
 func (v *Class) UnmarshalGraphQL(input interface{}) error {
 	switch input := input.(type) {
 	case string:
-		value, err := _ClassFromString(input)
+		value, err := ClassFromString(input)
 		if err != nil {
 			return err
 		}
@@ -69,3 +70,11 @@ func (v *Class) UnmarshalGraphQL(input interface{}) error {
 func (v Class) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + v.String() + `"`), nil
 }
+
+func (v *Class) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	return v.UnmarshalGraphQL(str)
+}
@@ -2,13 +2,14 @@
 package maker
 
 import (
+	"encoding/json"
 	"errors"
 	"strconv"
 )
 
 /*
-   Directives:
-     @important()
+	Directives:
+	  @important()
 */
 type Maker int
 
@@ -44,7 +45,7 @@ func (v Maker) String() string {
 	return _Maker_Name[_Maker_Index[v]:_Maker_Index[v+1]]
 }
 
-func _MakerFromString(str string) (Maker, error) {
+func MakerFromString(str string) (Maker, error) {
 	for i := 0; i < len(_Maker_Index)-1; i++ {
 		if v := Maker(i); str == v.String() {
 			return v, nil
@@ -60,7 +61,7 @@ func (Maker) ImplementsGraphQLType(name string) bool {
 func (v *Maker) UnmarshalGraphQL(input interface{}) error {
 	switch input := input.(type) {
 	case string:
-		value, err := _MakerFromString(input)
+		value, err := MakerFromString(input)
 		if err != nil {
 			return err
 		}
@@ -74,3 +75,11 @@ func (v *Maker) UnmarshalGraphQL(input interface{}) error {
 func (v Maker) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + v.String() + `"`), nil
 }
+
+func (v *Maker) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	return v.UnmarshalGraphQL(str)
+}
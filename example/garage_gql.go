@@ -16,27 +16,56 @@ type GarageResolver interface {
 }
 
 type GarageResolver_Trucks_Arg struct {
-	Size   scalar.Uint32
+	// Default: 20
+	Size scalar.Uint32
+	// Default: null
 	Cursor *scalar.Cursor
 }
 
+// DefaultGarageResolver_Trucks_Arg returns GarageResolver_Trucks_Arg holding default values of the arguments.
+func DefaultGarageResolver_Trucks_Arg() GarageResolver_Trucks_Arg {
+	return GarageResolver_Trucks_Arg{
+		Size: scalar.Uint32(20),
+	}
+}
+
 type GarageResolver_Drivers_Arg struct {
+	// Default: 20
 	Size scalar.Uint32
 
 	/*
 	   Description:
 	     "driver class"
 	*/
+	// Default: [ELITE, KING_OF_ROAD]
 	Class []class.Class
 
 	/*
 	   Directives:
 	     @deprecated()
 	*/
+	// Default: null
 	Cursor *scalar.Cursor
 }
 
+// DefaultGarageResolver_Drivers_Arg returns GarageResolver_Drivers_Arg holding default values of the arguments.
+func DefaultGarageResolver_Drivers_Arg() GarageResolver_Drivers_Arg {
+	return GarageResolver_Drivers_Arg{
+		Size:  scalar.Uint32(20),
+		Class: []class.Class{class.ELITE, class.KING_OF_ROAD},
+	}
+}
+
 type GarageResolver_Trailers_Arg struct {
-	Size   scalar.Uint32
+	// Default: 20
+	Size scalar.Uint32
+	// Default: null
 	Cursor *scalar.Cursor
 }
+
+// DefaultGarageResolver_Trailers_Arg returns GarageResolver_Trailers_Arg holding default values of the arguments.
+func DefaultGarageResolver_Trailers_Arg() GarageResolver_Trailers_Arg {
+	return GarageResolver_Trailers_Arg{
+		Size: scalar.Uint32(20),
+	}
+}
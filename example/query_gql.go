@@ -23,6 +23,7 @@ type QueryResolver interface {
 }
 
 type QueryResolver_Truck_Arg struct {
+	// Default: null
 	Number *scalar.RegistrationNumber
 }
 
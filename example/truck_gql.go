@@ -7,12 +7,12 @@ import (
 )
 
 /*
-   Description:
-     """
-     This is truck
-     """
-   Directives:
-     @special()
+	Description:
+	  """
+	  This is truck
+	  """
+	Directives:
+	  @special()
 */
 type TruckResolver interface {
 	Maker() maker.Maker
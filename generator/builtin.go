@@ -0,0 +1,255 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/RettyEng/gqlcodegen/ast"
+	"github.com/RettyEng/gqlcodegen/docs"
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/parser"
+	"github.com/RettyEng/gqlcodegen/validator"
+)
+
+func init() {
+	Register(&builtin{"enum", generateEnums})
+	Register(&builtin{"resolver", generateResolvers})
+	Register(&builtin{"mock", generateMocks})
+	Register(&builtin{"root", generateRootFile})
+	Register(&operationsBuiltin{builtin{"client", generateClientFile}})
+	Register(&builtin{"docs", generateDocs})
+	Register(&builtin{"scaffold", generateScaffolds})
+}
+
+// builtin is a plugin of a target this package provides.
+type builtin struct {
+	name     string
+	generate func(ctx context.Context, ts *gql.TypeSystem, conf *Config) ([]File, error)
+}
+
+func (b *builtin) Name() string {
+	return b.name
+}
+
+func (b *builtin) Generate(ctx context.Context, ts *gql.TypeSystem, conf Config) ([]File, error) {
+	return b.generate(ctx, ts, &conf)
+}
+
+// operationsBuiltin is a builtin plugin which reads the operations.
+type operationsBuiltin struct {
+	builtin
+}
+
+func (b *operationsBuiltin) ReadsOperations() bool {
+	return true
+}
+
+// task generates a file with its own Generator, or returns nil if there is
+// nothing to generate. It fails with the error it returns or the one the
+// generator panics with. The name prefixes its errors unless it is empty.
+type task struct {
+	name string
+	run  func(g *Generator) (*File, error)
+}
+
+// runTasks runs the tasks with conf.Parallel workers, and returns the files
+// in the order of the tasks. The errors of all the tasks which failed are
+// returned as one.
+func runTasks(ctx context.Context, ts *gql.TypeSystem, conf *Config, tasks []*task) ([]File, error) {
+	n := conf.Parallel
+	if n < 1 {
+		n = 1
+	}
+	files := make([]*File, len(tasks))
+	errs := make([]error, len(tasks))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				if e := ctx.Err(); e != nil {
+					errs[i] = e
+					continue
+				}
+				g := NewGenerator(ts, conf)
+				var e error
+				errs[i] = Run(func() { files[i], e = tasks[i].run(g) })
+				if errs[i] == nil {
+					errs[i] = e
+				}
+			}
+		}()
+	}
+	for i := range tasks {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	var ret []File
+	var msgs []string
+	for i, f := range files {
+		if errs[i] != nil && tasks[i].name == "" {
+			msgs = append(msgs, errs[i].Error())
+		} else if errs[i] != nil {
+			msgs = append(msgs, fmt.Sprintf("%s: %v", tasks[i].name, errs[i]))
+		} else if f != nil {
+			ret = append(ret, *f)
+		}
+	}
+	if len(msgs) > 0 {
+		return nil, errors.New(strings.Join(msgs, "\n"))
+	}
+	return ret, nil
+}
+
+// formatted formats the source g generated as the file p.
+func formatted(g *Generator, p string) *File {
+	g.Format()
+	return &File{Path: p, Content: g.Bytes()}
+}
+
+func generateEnums(ctx context.Context, ts *gql.TypeSystem, conf *Config) ([]File, error) {
+	var tasks []*task
+	for _, e := range sortedEnums(ts) {
+		e := e
+		name := strings.ToLower(e.Name)
+		tasks = append(tasks, &task{e.Name, func(g *Generator) (*File, error) {
+			g.GenerateEnum(e)
+			return formatted(g, path.Join(name, name+conf.FileSuffix+".go")), nil
+		}})
+	}
+	return runTasks(ctx, ts, conf, tasks)
+}
+
+func generateResolvers(ctx context.Context, ts *gql.TypeSystem, conf *Config) ([]File, error) {
+	var tasks []*task
+	for _, o := range sortedObjects(ts) {
+		o := o
+		tasks = append(tasks, &task{o.Name, func(g *Generator) (*File, error) {
+			g.GenerateResolver(o)
+			return formatted(g, strings.ToLower(o.Name)+conf.FileSuffix+".go"), nil
+		}})
+	}
+	return runTasks(ctx, ts, conf, tasks)
+}
+
+func generateMocks(ctx context.Context, ts *gql.TypeSystem, conf *Config) ([]File, error) {
+	var tasks []*task
+	for _, o := range sortedObjects(ts) {
+		o := o
+		tasks = append(tasks, &task{o.Name, func(g *Generator) (*File, error) {
+			g.GenerateMock(o)
+			return formatted(g, strings.ToLower(o.Name)+"_mock"+conf.FileSuffix+"_test.go"), nil
+		}})
+	}
+	return runTasks(ctx, ts, conf, tasks)
+}
+
+func generateRootFile(ctx context.Context, ts *gql.TypeSystem, conf *Config) ([]File, error) {
+	return runTasks(ctx, ts, conf, []*task{{"", func(g *Generator) (*File, error) {
+		g.GenerateRoot(conf.Schema)
+		return formatted(g, "root"+conf.FileSuffix+".go"), nil
+	}}})
+}
+
+// generateClientFile generates the client of the operations in the files
+// *.graphql of conf.Operations, which must be valid against ts.
+func generateClientFile(ctx context.Context, ts *gql.TypeSystem, conf *Config) ([]File, error) {
+	files, e := filepath.Glob(path.Join(conf.Operations, "*.graphql"))
+	if e != nil {
+		return nil, e
+	}
+	doc := &ast.ExecutableDocument{}
+	for _, f := range files {
+		d, e := parser.ParseExecutableFile(f)
+		if e != nil {
+			return nil, e
+		}
+		doc.Definitions = append(doc.Definitions, d.Definitions...)
+	}
+	ops := doc.Eval()
+	if errs := validator.Validate(ts, ops); len(errs) > 0 {
+		msgs := []string{"operations are invalid"}
+		for _, e := range errs {
+			msgs = append(msgs, e.String())
+		}
+		return nil, errors.New(strings.Join(msgs, "\n"))
+	}
+	return runTasks(ctx, ts, conf, []*task{{"", func(g *Generator) (*File, error) {
+		g.GenerateClient(ops)
+		return formatted(g, "client"+conf.FileSuffix+".go"), nil
+	}}})
+}
+
+// generateDocs generates the documentation of the schema in Markdown and
+// HTML into the directory docs.
+func generateDocs(ctx context.Context, ts *gql.TypeSystem, conf *Config) ([]File, error) {
+	var files []File
+	for _, pages := range []map[string]string{docs.Markdown(ts), docs.HTML(ts)} {
+		var names []string
+		for name := range pages {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			files = append(files, File{Path: path.Join("docs", name), Content: []byte(pages[name])})
+		}
+	}
+	return files, nil
+}
+
+// generateScaffolds generates the scaffolds of the resolvers, or the stubs
+// of the methods missing from the scaffolds in the package.
+func generateScaffolds(ctx context.Context, ts *gql.TypeSystem, conf *Config) ([]File, error) {
+	if conf.FileSuffix == "" {
+		return nil, errors.New("scaffold target needs a non-empty -suffix not to write the scaffolds into the resolver files")
+	}
+	var tasks []*task
+	for _, o := range sortedObjects(ts) {
+		o := o
+		name := strings.ToLower(o.Name) + ".go"
+		tasks = append(tasks, &task{o.Name, func(g *Generator) (*File, error) {
+			src, e := ioutil.ReadFile(path.Join(conf.Package.Path, name))
+			if e != nil && !os.IsNotExist(e) {
+				return nil, e
+			}
+			if !g.GenerateScaffold(o, src) {
+				return nil, nil
+			}
+			if src == nil {
+				return formatted(g, name), nil
+			}
+			return &File{Path: name, Content: g.Bytes()}, nil
+		}})
+	}
+	return runTasks(ctx, ts, conf, tasks)
+}
+
+func sortedObjects(ts *gql.TypeSystem) []*gql.Object {
+	var objs []*gql.Object
+	for _, o := range ts.ObjectTypes {
+		objs = append(objs, o)
+	}
+	sort.Slice(objs, func(i, j int) bool { return objs[i].Name < objs[j].Name })
+	return objs
+}
+
+func sortedEnums(ts *gql.TypeSystem) []*gql.Enum {
+	var enums []*gql.Enum
+	for _, e := range ts.EnumTypes {
+		enums = append(enums, e)
+	}
+	sort.Slice(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
+	return enums
+}
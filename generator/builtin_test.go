@@ -0,0 +1,227 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/parser"
+)
+
+// fileTask returns a task generating the file named name.
+func fileTask(name string) *task {
+	return &task{name, func(g *Generator) (*File, error) {
+		return &File{Path: name}, nil
+	}}
+}
+
+func paths(files []File) []string {
+	var ret []string
+	for _, f := range files {
+		ret = append(ret, f.Path)
+	}
+	return ret
+}
+
+func TestRunTasks(t *testing.T) {
+	failing := func(name string, e error) *task {
+		return &task{name, func(g *Generator) (*File, error) { return nil, e }}
+	}
+	panicking := func(name string, format string) *task {
+		return &task{name, func(g *Generator) (*File, error) {
+			fatalf(format, name)
+			return &File{Path: name}, nil
+		}}
+	}
+	tests := []struct {
+		name  string
+		tasks []*task
+		files []string
+		err   string
+	}{
+		{
+			name:  "succeeded",
+			tasks: []*task{fileTask("a"), fileTask("b"), {"c", func(g *Generator) (*File, error) { return nil, nil }}, fileTask("d")},
+			files: []string{"a", "b", "d"},
+		},
+		{
+			name: "failed",
+			tasks: []*task{
+				fileTask("a"),
+				failing("b", errors.New("cannot read")),
+				panicking("c", "%s is broken"),
+				fileTask("d"),
+				failing("", errors.New("unnamed")),
+			},
+			err: "b: cannot read\nc: c is broken\nunnamed",
+		},
+	}
+	for _, tt := range tests {
+		for _, parallel := range []int{-1, 0, 1, 2, 8} {
+			files, e := runTasks(context.Background(), gql.NewTypeSystem(), &Config{Parallel: parallel}, tt.tasks)
+			if got := paths(files); !reflect.DeepEqual(got, tt.files) {
+				t.Errorf("%s with %d workers: got files %q, want %q", tt.name, parallel, got, tt.files)
+			}
+			if got := errString(e); got != tt.err {
+				t.Errorf("%s with %d workers: got error %q, want %q", tt.name, parallel, got, tt.err)
+			}
+		}
+	}
+}
+
+// reversedTasks returns n tasks named a, b, c... which finish in the reverse
+// order. The tasks for which failed returns true fail.
+func reversedTasks(n int, failed func(i int) bool) []*task {
+	done := make([]chan struct{}, n+1)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	close(done[n])
+	var tasks []*task
+	for i := 0; i < n; i++ {
+		i := i
+		name := string(rune('a' + i))
+		tasks = append(tasks, &task{name, func(g *Generator) (*File, error) {
+			<-done[i+1]
+			defer close(done[i])
+			if failed(i) {
+				return nil, errors.New("failed")
+			}
+			return &File{Path: name}, nil
+		}})
+	}
+	return tasks
+}
+
+// TestRunTasksOutOfOrder checks that files and errors are in the order of
+// the tasks even though the tasks finish in the reverse order.
+func TestRunTasksOutOfOrder(t *testing.T) {
+	const n = 6
+	ts := gql.NewTypeSystem()
+	files, e := runTasks(context.Background(), ts, &Config{Parallel: n}, reversedTasks(n, func(int) bool { return false }))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if got, want := paths(files), []string{"a", "b", "c", "d", "e", "f"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got files %q, want %q", got, want)
+	}
+
+	_, e = runTasks(context.Background(), ts, &Config{Parallel: n}, reversedTasks(n, func(i int) bool { return i%2 == 1 }))
+	if got, want := errString(e), "b: failed\nd: failed\nf: failed"; got != want {
+		t.Errorf("got error %q, want %q", got, want)
+	}
+}
+
+func TestRunTasksParallel(t *testing.T) {
+	for _, tt := range []struct{ parallel, max int }{{-1, 1}, {0, 1}, {1, 1}, {3, 3}} {
+		var mu sync.Mutex
+		running, max := 0, 0
+		var tasks []*task
+		for i := 0; i < 12; i++ {
+			tasks = append(tasks, &task{"", func(g *Generator) (*File, error) {
+				mu.Lock()
+				running++
+				if running > max {
+					max = running
+				}
+				mu.Unlock()
+				time.Sleep(time.Millisecond)
+				mu.Lock()
+				running--
+				mu.Unlock()
+				return nil, nil
+			}})
+		}
+		if _, e := runTasks(context.Background(), gql.NewTypeSystem(), &Config{Parallel: tt.parallel}, tasks); e != nil {
+			t.Fatal(e)
+		}
+		if max > tt.max {
+			t.Errorf("%d tasks run at once with %d workers", max, tt.parallel)
+		}
+	}
+}
+
+func TestRunTasksCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ran := map[string]bool{}
+	var tasks []*task
+	for _, name := range []string{"a", "b", "c"} {
+		name := name
+		tasks = append(tasks, &task{name, func(g *Generator) (*File, error) {
+			ran[name] = true
+			if name == "a" {
+				cancel()
+			}
+			return &File{Path: name}, nil
+		}})
+	}
+	files, e := runTasks(ctx, gql.NewTypeSystem(), &Config{Parallel: 1}, tasks)
+	if files != nil {
+		t.Errorf("got files %q", paths(files))
+	}
+	if got, want := errString(e), "b: context canceled\nc: context canceled"; got != want {
+		t.Errorf("got error %q, want %q", got, want)
+	}
+	if !ran["a"] || ran["b"] || ran["c"] {
+		t.Errorf("tasks run after the cancellation: %v", ran)
+	}
+
+	_, e = runTasks(ctx, gql.NewTypeSystem(), &Config{Parallel: 4}, []*task{fileTask("d")})
+	if got, want := errString(e), "d: context canceled"; got != want {
+		t.Errorf("got error %q, want %q", got, want)
+	}
+}
+
+func TestGenerateScaffoldsReadError(t *testing.T) {
+	dir := t.TempDir()
+	// A directory in place of the scaffold cannot be read.
+	if e := os.Mkdir(filepath.Join(dir, "truck.go"), 0755); e != nil {
+		t.Fatal(e)
+	}
+	top, e := parser.NewParser(strings.NewReader("type Query { truck: Truck }\ntype Truck { id: ID }")).Parse()
+	if e != nil {
+		t.Fatal(e)
+	}
+	ts, e := top.Eval()
+	if e != nil {
+		t.Fatal(e)
+	}
+	conf := &Config{Package: &Package{Name: "scaffold", Path: dir}, FileSuffix: "_gql", Parallel: 2}
+	_, e = generateScaffolds(context.Background(), ts, conf)
+	if e == nil || !strings.HasPrefix(e.Error(), "Truck: ") || !strings.Contains(e.Error(), "is a directory") {
+		t.Errorf("got error %v, want the error reading truck.go", e)
+	}
+}
+
+func TestGenerateScaffoldsSuffix(t *testing.T) {
+	conf := &Config{Package: &Package{Name: "scaffold", Path: t.TempDir()}}
+	_, e := generateScaffolds(context.Background(), gql.NewTypeSystem(), conf)
+	if e == nil || !strings.Contains(e.Error(), "non-empty -suffix") {
+		t.Errorf("got error %v, want the error of the empty suffix", e)
+	}
+}
+
+func TestReadsOperations(t *testing.T) {
+	for _, name := range Plugins() {
+		p, _ := Lookup(name)
+		r, ok := p.(OperationsPlugin)
+		if got, want := ok && r.ReadsOperations(), name == "client"; got != want {
+			t.Errorf("%s reads operations: %v, want %v", name, got, want)
+		}
+	}
+}
+
+func errString(e error) string {
+	if e == nil {
+		return ""
+	}
+	return e.Error()
+}
@@ -0,0 +1,468 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/printer"
+)
+
+// GenerateClient generates a client of the operations in doc. For each
+// operation, it generates the document sent to the server, a struct of the
+// variables, structs of the response shaped by the selection set and a
+// function sending the operation with a GraphQLDoer.
+// Operations must be named queries or mutations valid against the schema.
+func (g *Generator) GenerateClient(doc *gql.ExecutableDocument) {
+	c := &clientGenerator{g: g, doc: doc, inputs: map[string]bool{}}
+	// The body is generated first to know the packages to import.
+	head := g.buff
+	g.buff = bytes.NewBuffer(nil)
+	generateClientRuntime(g)
+	for _, op := range doc.Operations {
+		g.Println()
+		c.generateOperation(op)
+	}
+	for i := 0; i < len(c.inputList); i++ {
+		g.Println()
+		c.generateInput(g.TypeSystem().InputObjectTypes[c.inputList[i]])
+	}
+	body := g.buff
+	g.buff = head
+
+	g.Printf(commentOnTop)
+	generateResolverPackageSection(g)
+	g.Println()
+	g.Println("import (")
+	for _, p := range []string{"bytes", "context", "encoding/json", "fmt", "net/http", "strings"} {
+		g.Printf("%q\n", p)
+	}
+	if paths := importPaths(g, c.refs); len(paths) > 0 {
+		sort.Strings(paths)
+		g.Println()
+		for _, p := range paths {
+			g.Printf("%q\n", p)
+		}
+	}
+	g.Println(")")
+	g.Println()
+	g.Printf("%s", body.String())
+}
+
+type clientGenerator struct {
+	g   *Generator
+	doc *gql.ExecutableDocument
+	// refs are types of the schema appearing in the generated code.
+	refs []*gql.TypeRef
+	// inputs are input object types to generate, in inputList in order.
+	inputs    map[string]bool
+	inputList []string
+}
+
+// clientRuntimeNames are the names generated by generateClientRuntime.
+var clientRuntimeNames = map[string]bool{
+	"GraphQLDoer":   true,
+	"GraphQLError":  true,
+	"GraphQLErrors": true,
+}
+
+func generateClientRuntime(g *Generator) {
+	g.Println("// GraphQLDoer sends HTTP requests. *http.Client implements it.")
+	g.Println("type GraphQLDoer interface {")
+	g.Println("Do(req *http.Request) (*http.Response, error)")
+	g.Println("}")
+	g.Println()
+	g.Println("// GraphQLError is an error in a GraphQL response.")
+	g.Println("type GraphQLError struct {")
+	g.Println("Message string `json:\"message\"`")
+	g.Println("Path []interface{} `json:\"path,omitempty\"`")
+	g.Println("}")
+	g.Println()
+	g.Println("func (e *GraphQLError) Error() string {")
+	g.Println("return e.Message")
+	g.Println("}")
+	g.Println()
+	g.Println("// GraphQLErrors are errors in a GraphQL response.")
+	g.Println("type GraphQLErrors []*GraphQLError")
+	g.Println()
+	g.Println("func (e GraphQLErrors) Error() string {")
+	g.Println("var messages []string")
+	g.Println("for _, err := range e {")
+	g.Println("messages = append(messages, err.Message)")
+	g.Println("}")
+	g.Println(`return strings.Join(messages, "; ")`)
+	g.Println("}")
+	g.Println()
+	g.Println("// doGraphQLRequest posts query to endpoint and decodes the data of the")
+	g.Println("// response into data. Errors in the response are returned as GraphQLErrors")
+	g.Println("// after data is decoded.")
+	g.Println("func doGraphQLRequest(")
+	g.Println("ctx context.Context, doer GraphQLDoer, endpoint, query, operationName string,")
+	g.Println("variables interface{}, data interface{},")
+	g.Println(") error {")
+	g.Println("body, err := json.Marshal(map[string]interface{}{")
+	g.Println(`"query": query,`)
+	g.Println(`"operationName": operationName,`)
+	g.Println(`"variables": variables,`)
+	g.Println("})")
+	g.Println("if err != nil {")
+	g.Println("return err")
+	g.Println("}")
+	g.Println("req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))")
+	g.Println("if err != nil {")
+	g.Println("return err")
+	g.Println("}")
+	g.Println(`req.Header.Set("Content-Type", "application/json")`)
+	g.Println("res, err := doer.Do(req.WithContext(ctx))")
+	g.Println("if err != nil {")
+	g.Println("return err")
+	g.Println("}")
+	g.Println("defer res.Body.Close()")
+	g.Println()
+	g.Println("var payload struct {")
+	g.Println("Data json.RawMessage `json:\"data\"`")
+	g.Println("Errors GraphQLErrors `json:\"errors\"`")
+	g.Println("}")
+	g.Println("if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {")
+	g.Printf("return fmt.Errorf(%q, res.Status, err)\n", "%s: %v")
+	g.Println("}")
+	g.Println(`if len(payload.Data) > 0 && string(payload.Data) != "null" {`)
+	g.Println("if err := json.Unmarshal(payload.Data, data); err != nil {")
+	g.Println("return err")
+	g.Println("}")
+	g.Println("}")
+	g.Println("if len(payload.Errors) > 0 {")
+	g.Println("return payload.Errors")
+	g.Println("}")
+	g.Println("if res.StatusCode/100 != 2 {")
+	g.Printf("return fmt.Errorf(%q, res.Status)\n", "unexpected status %s")
+	g.Println("}")
+	g.Println("return nil")
+	g.Println("}")
+}
+
+func (c *clientGenerator) generateOperation(op *gql.Operation) {
+	g := c.g
+	if e := checkClientOperation(op); e != nil {
+		fatal(e)
+	}
+	name := capitalizeFirst(op.Name)
+	root := rootOperationType(g.TypeSystem(), op.Type)
+	if root == nil {
+		fatalf("%s: schema does not support %s", op.Position, op.Type)
+	}
+
+	g.Printf("// %sDocument is the document of the %s %s.\n", name, op.Type, op.Name)
+	g.Printf("const %sDocument = %s\n", name, quoteSource(c.operationDocument(op)))
+	g.Println()
+	if len(op.Variables) > 0 {
+		g.Printf("// %sVariables are variables of the %s %s.\n", name, op.Type, op.Name)
+		g.Printf("type %sVariables struct {\n", name)
+		for _, v := range op.Variables {
+			c.generateInputField(v.Name, v.Type)
+		}
+		g.Println("}")
+		g.Println()
+	}
+	c.generateSelectionStruct(
+		name+"Response",
+		"is the data of the response to the "+string(op.Type)+" "+op.Name,
+		root.Name, op.SelectionSet,
+	)
+	g.Println()
+
+	params := "ctx context.Context, doer GraphQLDoer, endpoint string"
+	variables := "nil"
+	if len(op.Variables) > 0 {
+		params += ", variables " + name + "Variables"
+		variables = "variables"
+	}
+	g.Printf("// %s sends the %s %s to endpoint. The response is returned with\n", name, op.Type, op.Name)
+	g.Println("// GraphQLErrors if it has errors.")
+	g.Printf("func %s(%s) (*%sResponse, error) {\n", name, params, name)
+	g.Printf("data := &%sResponse{}\n", name)
+	g.Printf(
+		"err := doGraphQLRequest(ctx, doer, endpoint, %sDocument, %q, %s, data)\n",
+		name, op.Name, variables,
+	)
+	g.Println("return data, err")
+	g.Println("}")
+}
+
+// checkClientOperation returns an error if the client of op cannot be
+// generated: op is anonymous, a subscription, or named so that its
+// declarations collide with the client runtime.
+func checkClientOperation(op *gql.Operation) error {
+	if op.Name == "" {
+		return fmt.Errorf("%s: operations must be named to generate a client", op.Position)
+	}
+	if op.Type == gql.OperationSubscription {
+		return fmt.Errorf("%s: subscription %s is not supported by the client target", op.Position, op.Name)
+	}
+	if name := capitalizeFirst(op.Name); clientRuntimeNames[name] {
+		return fmt.Errorf("%s: %s of the operation %s collides with the client runtime", op.Position, name, op.Name)
+	}
+	return nil
+}
+
+func rootOperationType(ts *gql.TypeSystem, t gql.OperationType) *gql.Object {
+	refs := map[gql.OperationType]*gql.TypeRef{
+		gql.OperationQuery:        ts.Schema.Query,
+		gql.OperationMutation:     ts.Schema.Mutation,
+		gql.OperationSubscription: ts.Schema.Subscription,
+	}
+	name := capitalizeFirst(string(t))
+	if ref := refs[t]; ref != nil {
+		name = ref.Name
+	}
+	return ts.ObjectTypes[name]
+}
+
+// operationDocument returns the document of op including the fragments it
+// uses.
+func (c *clientGenerator) operationDocument(op *gql.Operation) string {
+	doc := &gql.ExecutableDocument{Operations: []*gql.Operation{op}}
+	used := map[string]bool{}
+	var use func(sels []gql.Selection)
+	use = func(sels []gql.Selection) {
+		for _, s := range sels {
+			switch s := s.(type) {
+			case *gql.Field:
+				use(s.SelectionSet)
+			case *gql.InlineFragment:
+				use(s.SelectionSet)
+			case *gql.FragmentSpread:
+				f := c.doc.Fragment(s.Name)
+				if f == nil {
+					fatalf("%s: unknown fragment %s", s.Position, s.Name)
+				}
+				if used[s.Name] {
+					continue
+				}
+				used[s.Name] = true
+				doc.Fragments = append(doc.Fragments, f)
+				use(f.SelectionSet)
+			}
+		}
+	}
+	use(op.SelectionSet)
+
+	buff := bytes.NewBuffer(nil)
+	if e := printer.Fprint(buff, doc); e != nil {
+		fatal(e)
+	}
+	return buff.String()
+}
+
+/*********************************************************
+Response
+ *********************************************************/
+
+// clientField is a field in a response merged from the selections with the
+// same response key. optional is true if the field is selected only in
+// fragments which may not apply.
+type clientField struct {
+	key      string
+	def      *gql.ObjectField
+	optional bool
+	sels     []gql.Selection
+}
+
+// collectFields merges fields selected by sels on the type parent in order of
+// appearance.
+func (c *clientGenerator) collectFields(
+	parent string, sels []gql.Selection, optional bool, fields []*clientField,
+) []*clientField {
+	for _, s := range sels {
+		switch s := s.(type) {
+		case *gql.Field:
+			fields = c.addField(parent, s, optional, fields)
+		case *gql.InlineFragment:
+			cond := parent
+			if s.TypeCondition != nil {
+				cond = s.TypeCondition.Name
+			}
+			fields = c.collectFields(cond, s.SelectionSet, optional || !c.covers(cond, parent), fields)
+		case *gql.FragmentSpread:
+			f := c.doc.Fragment(s.Name)
+			cond := f.TypeCondition.Name
+			fields = c.collectFields(cond, f.SelectionSet, optional || !c.covers(cond, parent), fields)
+		}
+	}
+	return fields
+}
+
+func (c *clientGenerator) addField(
+	parent string, s *gql.Field, optional bool, fields []*clientField,
+) []*clientField {
+	for _, f := range fields {
+		if f.key == s.ResponseKey() {
+			f.optional = f.optional && optional
+			f.sels = append(f.sels, s.SelectionSet...)
+			return fields
+		}
+	}
+	def := c.field(parent, s.Name)
+	if def == nil {
+		fatalf("%s: cannot query field %s on type %s", s.Position, s.Name, parent)
+	}
+	return append(fields, &clientField{
+		key:      s.ResponseKey(),
+		def:      def,
+		optional: optional,
+		sels:     append([]gql.Selection(nil), s.SelectionSet...),
+	})
+}
+
+func (c *clientGenerator) field(parent, name string) *gql.ObjectField {
+	if name == "__typename" {
+		return &gql.ObjectField{Name: name, Type: &gql.TypeRef{Name: "String"}}
+	}
+	ts := c.g.TypeSystem()
+	var fields []*gql.ObjectField
+	if o, ok := ts.ObjectTypes[parent]; ok {
+		fields = o.Fields
+	} else if i, ok := ts.InterfaceTypes[parent]; ok {
+		fields = i.Fields
+	}
+	for _, f := range fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// covers returns whether every object of the type parent is of the type cond.
+func (c *clientGenerator) covers(cond, parent string) bool {
+	if cond == parent {
+		return true
+	}
+	ts := c.g.TypeSystem()
+	if o, ok := ts.ObjectTypes[parent]; ok {
+		for _, i := range o.Implements {
+			if i.Name == cond {
+				return true
+			}
+		}
+		if u, ok := ts.UnionTypes[cond]; ok {
+			for _, m := range u.Members {
+				if m.Name == parent {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// generateSelectionStruct generates the struct name of fields selected by
+// sels on the type parent, followed by structs of its fields.
+func (c *clientGenerator) generateSelectionStruct(name, doc, parent string, sels []gql.Selection) {
+	g := c.g
+	fields := c.collectFields(parent, sels, false, nil)
+	g.Printf("// %s %s.\n", name, doc)
+	g.Printf("type %s struct {\n", name)
+	for _, f := range fields {
+		typ := f.def.Type
+		if f.optional && !typ.IsNullable {
+			t := *typ
+			t.IsNullable = true
+			typ = &t
+		}
+		g.Printf(
+			"%s %s `json:%q`\n",
+			clientFieldName(f.key), c.typeString(typ, name+"_"+clientFieldName(f.key)), f.key,
+		)
+	}
+	g.Println("}")
+
+	for _, f := range fields {
+		typ := namedTypeOf(f.def.Type)
+		if !c.isComposite(typ) {
+			continue
+		}
+		g.Println()
+		c.generateSelectionStruct(
+			name+"_"+clientFieldName(f.key), "is "+f.key+" of "+name, typ.Name, f.sels,
+		)
+	}
+}
+
+func clientFieldName(key string) string {
+	if key == "__typename" {
+		return "Typename"
+	}
+	return capitalizeFirst(key)
+}
+
+func namedTypeOf(ref *gql.TypeRef) *gql.TypeRef {
+	for ref.Name == "[]" {
+		ref = ref.InnerType
+	}
+	return ref
+}
+
+func (c *clientGenerator) isComposite(ref *gql.TypeRef) bool {
+	ts := c.g.TypeSystem()
+	_, obj := ts.ObjectTypes[ref.Name]
+	_, iface := ts.InterfaceTypes[ref.Name]
+	_, union := ts.UnionTypes[ref.Name]
+	return obj || iface || union
+}
+
+// typeString returns the go type of ref. Composite types are represented by
+// the struct named structName.
+func (c *clientGenerator) typeString(ref *gql.TypeRef, structName string) string {
+	ptr := ""
+	if ref.IsNullable {
+		ptr = "*"
+	}
+	if ref.Name == "[]" {
+		return ptr + "[]" + c.typeString(ref.InnerType, structName)
+	}
+	if c.isComposite(ref) {
+		return ptr + structName
+	}
+	if _, ok := c.g.TypeSystem().InputObjectTypes[ref.Name]; ok {
+		if !c.inputs[ref.Name] {
+			c.inputs[ref.Name] = true
+			c.inputList = append(c.inputList, ref.Name)
+		}
+		return ptr + capitalizeFirst(ref.Name)
+	}
+	if ref.Name == "ID" {
+		return ptr + "string"
+	}
+	c.refs = append(c.refs, ref)
+	return refToString(c.g, ref)
+}
+
+/*********************************************************
+Variables
+ *********************************************************/
+
+// generateInputField generates a field of a variables struct or an input
+// object. Nullable fields are omitted when they are nil.
+func (c *clientGenerator) generateInputField(name string, ref *gql.TypeRef) {
+	tag := name
+	if ref.IsNullable {
+		tag += ",omitempty"
+	}
+	c.g.Printf("%s %s `json:%s`\n", capitalizeFirst(name), c.typeString(ref, ""), strconv.Quote(tag))
+}
+
+func (c *clientGenerator) generateInput(def *gql.InputObject) {
+	g := c.g
+	if clientRuntimeNames[capitalizeFirst(def.Name)] {
+		fatalf("%s: input %s collides with the client runtime", def.Position, def.Name)
+	}
+	generateComment(g, def)
+	g.Printf("type %s struct {\n", capitalizeFirst(def.Name))
+	for _, v := range def.InputValue {
+		c.generateInputField(v.Name, v.Type)
+	}
+	g.Println("}")
+}
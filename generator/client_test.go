@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/parser"
+)
+
+// TestGenerateClient generates the client of testdata/client and runs the
+// tests in testdata/client/client_test.go against it, which serve responses
+// with httptest.
+func TestGenerateClient(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test on the generated client")
+	}
+	goCmd, e := exec.LookPath("go")
+	if e != nil {
+		t.Skip(e)
+	}
+	f, e := os.Open("testdata/client/schema.graphqls")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer f.Close()
+	ts := parser.NewFileParser("schema.graphqls", f).ParseAndEvalSchema()
+	doc, e := parser.ParseExecutableFile("testdata/client/ops.graphql")
+	if e != nil {
+		t.Fatal(e)
+	}
+	g := NewGenerator(ts, &Config{Package: &Package{Name: "client"}})
+	g.GenerateClient(doc.Eval())
+	g.Format()
+
+	test, e := ioutil.ReadFile("testdata/client/client_test.go")
+	if e != nil {
+		t.Fatal(e)
+	}
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"go.mod":         []byte("module client\n\ngo 1.16\n"),
+		"client_gql.go":  g.buff.Bytes(),
+		"client_test.go": test,
+	}
+	for name, src := range files {
+		if e := ioutil.WriteFile(filepath.Join(dir, name), src, 0644); e != nil {
+			t.Fatal(e)
+		}
+	}
+	cmd := exec.Command(goCmd, "test", "-count=1", ".")
+	cmd.Dir = dir
+	if out, e := cmd.CombinedOutput(); e != nil {
+		t.Errorf("%v\n%s\n%s", e, out, g.buff.String())
+	}
+}
+
+func TestCheckClientOperation(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{src: "query Q { a }"},
+		{src: "mutation M { a }"},
+		{src: "{ a }", want: "ops.graphql:1:1: operations must be named to generate a client"},
+		{src: "subscription S { a }", want: "ops.graphql:1:1: subscription S is not supported by the client target"},
+		{src: "query graphQLError { a }", want: "ops.graphql:1:1: GraphQLError of the operation graphQLError collides with the client runtime"},
+		{src: "query GraphQLErrorsResponse { a }"},
+	}
+	for _, tt := range tests {
+		doc, e := parser.NewFileParser("ops.graphql", strings.NewReader(tt.src)).ParseExecutable()
+		if e != nil {
+			t.Fatal(e)
+		}
+		got := ""
+		if e := checkClientOperation(doc.Eval().Operations[0]); e != nil {
+			got = e.Error()
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
@@ -21,16 +21,16 @@ func generateComment(
 	if len(c.GetDirectives()) != 0 {
 		g.Printf("   Directives:\n")
 		for _, d := range c.GetDirectives() {
-			g.Printf("     @%s(%s)\n", d.Name, argsStr(d.Args))
+			g.Printf("     @%s(%s)\n", d.Name, argsStr(d))
 		}
 	}
 	g.Println(" */")
 }
 
-func argsStr(args map[string]gql.Value) string {
+func argsStr(d *gql.DirectiveRef) string {
 	var str []string
-	for name, value := range args {
-		str = append(str, fmt.Sprintf("%s: %s", name, value.Value()))
+	for _, name := range d.ArgNames {
+		str = append(str, fmt.Sprintf("%s: %s", name, d.Args[name].Value()))
 	}
 	return strings.Join(str, ", ")
 }
@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+func defaultFuncName(f *gql.ObjectField, t *gql.Object) string {
+	return "Default" + argStructName(f, t)
+}
+
+func hasDefaultValue(args []*gql.InputValue) bool {
+	for _, a := range args {
+		if a.Default != nil && !isNull(a.Default) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNull(v gql.Value) bool {
+	return v.Value() == "null"
+}
+
+func generateDefaultComment(g *Generator, a *gql.InputValue) {
+	if a.Default != nil {
+		g.Printf("// Default: %s\n", strings.Replace(a.Default.Value(), "\n", "\n// ", -1))
+	}
+}
+
+// generateDefaultFunc generates a function returning the arg struct of f
+// holding default values of the arguments.
+func generateDefaultFunc(g *Generator, t *gql.Object, f *gql.ObjectField) {
+	name := argStructName(f, t)
+	g.Printf(
+		"// %s returns %s holding default values of the arguments.\n",
+		defaultFuncName(f, t), name,
+	)
+	g.Printf("func %s() %s {\n", defaultFuncName(f, t), name)
+	g.Printf("return %s{\n", name)
+	for _, a := range f.Args {
+		if a.Default == nil || isNull(a.Default) {
+			continue
+		}
+		g.Printf("%s: %s,\n", capitalizeFirst(a.Name), valueToGo(g, a.Type, a.Default))
+	}
+	g.Println("}")
+	g.Println("}")
+}
+
+// valueToGo returns a go expression of the value v whose type is ref.
+func valueToGo(g *Generator, ref *gql.TypeRef, v gql.Value) string {
+	if isNull(v) {
+		return "nil"
+	}
+	typ := refToString(g, ref)
+	if ref.Name == "[]" {
+		children := []gql.Value{v}
+		if l, ok := v.(*gql.List); ok {
+			children = l.Child
+		}
+		var elems []string
+		for _, c := range children {
+			elems = append(elems, valueToGo(g, ref.InnerType, c))
+		}
+		lit := strings.TrimPrefix(typ, "*") + "{" + strings.Join(elems, ", ") + "}"
+		if ref.IsNullable {
+			return pointerTo(strings.TrimPrefix(typ, "*"), lit)
+		}
+		return lit
+	}
+
+	lit := scalarLiteral(g, ref, v.Value())
+	if ref.IsNullable {
+		return pointerTo(strings.TrimPrefix(typ, "*"), lit)
+	}
+	return lit
+}
+
+func scalarLiteral(g *Generator, ref *gql.TypeRef, v string) string {
+	n := ref.Name
+	base := strings.TrimPrefix(refToString(g, ref), "*")
+	if _, ok := g.TypeSystem().EnumTypes[n]; ok {
+		return strings.ToLower(n) + "." + capitalizeFirst(v)
+	}
+	if strings.HasPrefix(v, `"`) {
+		v = strconv.Quote(gql.StringValue(v))
+	}
+	switch n {
+	case "Int", "Boolean", "String":
+		return v
+	case "Float":
+		return base + "(" + v + ")"
+	}
+	if _, ok := g.TypeSystem().ScalarTypes[n]; ok {
+		return base + "(" + v + ")"
+	}
+	fatalf("unsupported default value %s of type %s", v, n)
+	return ""
+}
+
+func pointerTo(typ, value string) string {
+	return "func() *" + typ + " { v := " + value + "; return &v }()"
+}
@@ -24,12 +24,15 @@ func generateEnum(g *Generator, def *gql.Enum) {
 	generateUnmarshalGraphQL(g, def)
 	g.Println()
 	generateMarshalJson(g, def)
+	g.Println()
+	generateUnmarshalJson(g, def)
 }
 
 func generateEnumPackageSection(g *Generator, def *gql.Enum) {
 	g.Printf("package %s\n", strings.ToLower(def.Name))
 	g.Println()
 	g.Println("import (")
+	g.Println(`"encoding/json"`)
 	g.Println(`"errors"`)
 	g.Println(`"strconv"`)
 	g.Println(")")
@@ -124,3 +127,13 @@ func generateMarshalJson(g *Generator, e *gql.Enum) {
 	g.Println("return []byte(`\"`+v.String()+`\"`), nil")
 	g.Println("}")
 }
+
+func generateUnmarshalJson(g *Generator, e *gql.Enum) {
+	g.Printf("func (v *%s) UnmarshalJSON(b []byte) error {\n", capitalizeFirst(e.Name))
+	g.Println("var str string")
+	g.Println("if err := json.Unmarshal(b, &str); err != nil {")
+	g.Println("return err")
+	g.Println("}")
+	g.Println("return v.UnmarshalGraphQL(str)")
+	g.Println("}")
+}
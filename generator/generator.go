@@ -0,0 +1,134 @@
+// Package generator generates go code from schemas. Each target is a Plugin
+// registered to it.
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// Package is the go package files are generated into.
+type Package struct {
+	Name string
+	// Path is the directory of the package.
+	Path string
+}
+
+// Config is the configuration of a generation, which is shared by the
+// targets.
+type Config struct {
+	EnumPackagePrefix string
+	ScalarPackage     string
+	Package           *Package
+	// FileSuffix is appended to the base names of generated files.
+	FileSuffix string
+	// Schema is the source of the schema in SDL.
+	Schema string
+	// Operations is the directory of the operations of the client target.
+	Operations string
+	// Parallel is the number of files a target may generate in parallel.
+	Parallel int
+}
+
+// Version is the version of the generator. It is a part of the inputs of
+// cached generation, so it must change whenever generated codes change.
+const Version = "0.2.0"
+
+// Generator generates a file into its buffer. Generators must not be shared
+// by goroutines, but ones of the same Config can run in parallel.
+type Generator struct {
+	ts     *gql.TypeSystem
+	config *Config
+	buff   *bytes.Buffer
+}
+
+func NewGenerator(ts *gql.TypeSystem, conf *Config) *Generator {
+	return &Generator{
+		ts:     ts,
+		config: conf,
+		buff:   bytes.NewBuffer(nil),
+	}
+}
+
+// generationError is an error generators panic with, which Run recovers.
+type generationError struct {
+	error
+}
+
+func fatal(e error) {
+	panic(generationError{e})
+}
+
+func fatalf(format string, args ...interface{}) {
+	fatal(fmt.Errorf(format, args...))
+}
+
+// Run calls f, which uses generators, and returns the error generation
+// failed with.
+func Run(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(generationError)
+			if !ok {
+				panic(r)
+			}
+			err = e.error
+		}
+	}()
+	f()
+	return nil
+}
+
+func (g *Generator) Config() *Config {
+	return g.config
+}
+
+func (g *Generator) TypeSystem() *gql.TypeSystem {
+	return g.ts
+}
+
+// GenerateEnum generates the go type of def and its methods, in the package
+// of def.
+func (g *Generator) GenerateEnum(def *gql.Enum) {
+	generateEnum(g, def)
+}
+
+// GenerateResolver generates the resolver interface of def and the structs
+// of the arguments of its fields.
+func (g *Generator) GenerateResolver(def *gql.Object) {
+	generateType(g, def)
+}
+
+func (g *Generator) Printf(fmtStr string, args ...interface{}) {
+	_, e := fmt.Fprintf(g.buff, fmtStr, args...)
+	if e != nil {
+		fatal(e)
+	}
+}
+
+func (g *Generator) Println(args ...interface{}) {
+	_, e := fmt.Fprintln(g.buff, args...)
+	if e != nil {
+		fatal(e)
+	}
+}
+
+func (g *Generator) Format() {
+	src, e := format.Source(g.buff.Bytes())
+	if e != nil {
+		fatal(e)
+	}
+	g.buff = bytes.NewBuffer(src)
+}
+
+func (g *Generator) ClearBuff() {
+	g.buff = bytes.NewBuffer(nil)
+}
+
+// Bytes returns the content of the buffer.
+func (g *Generator) Bytes() []byte {
+	return g.buff.Bytes()
+}
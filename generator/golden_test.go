@@ -0,0 +1,309 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	gqlparser "github.com/RettyEng/gqlcodegen/parser"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the generated ones")
+
+// goldenDir holds a directory for each case of the golden tests:
+//
+//	schema.graphqls  the schema
+//	ops/*.graphql    operations of the client target
+//	scalar.go        the scalar package, if the schema has custom scalars
+//	targets          the comma separated targets to run, if not all of them
+//	out/             the files expected to be generated
+const goldenDir = "testdata/golden"
+
+// stubDir holds stubs of the packages the generated files import besides
+// the standard library, by their import paths, so that they typecheck
+// without the modules.
+const stubDir = "testdata/stub"
+
+// TestGolden runs the targets over the schema of each case, compares the
+// files generated with the golden files, and typechecks them. Run it with
+// -update to rewrite the golden files after changing the generators.
+func TestGolden(t *testing.T) {
+	dirs, e := filepath.Glob(path.Join(goldenDir, "*"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	std := importer.ForCompiler(token.NewFileSet(), "source", nil)
+	for _, dir := range dirs {
+		dir := dir
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			files := generateGolden(t, dir)
+			out := path.Join(dir, "out")
+			if *update {
+				writeGolden(t, out, files)
+			}
+			compareGolden(t, out, files)
+			typecheckGolden(t, dir, files, std)
+		})
+	}
+}
+
+// goldenPackagePrefix is the import path of the generated package of a case.
+func goldenPackagePrefix(dir string) string {
+	return "gqlcodegen.test/" + filepath.Base(dir)
+}
+
+// generateGolden generates the files of the targets for the case in dir,
+// by their paths relative to the generated package.
+func generateGolden(t *testing.T, dir string) map[string][]byte {
+	sdl, e := ioutil.ReadFile(path.Join(dir, "schema.graphqls"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	schema, e := gqlparser.NewFileParser("schema.graphqls", bytes.NewReader(sdl)).Parse()
+	if e != nil {
+		t.Fatal(e)
+	}
+	ts, e := schema.Eval()
+	if e != nil {
+		t.Fatal(e)
+	}
+	prefix := goldenPackagePrefix(dir)
+	conf := Config{
+		EnumPackagePrefix: prefix + "/enum",
+		ScalarPackage:     prefix + "/scalar",
+		Package:           &Package{Name: filepath.Base(dir), Path: dir},
+		FileSuffix:        "_gql",
+		Schema:            string(sdl),
+		Operations:        path.Join(dir, "ops"),
+		Parallel:          2,
+	}
+
+	files := map[string][]byte{}
+	for _, name := range goldenTargets(t, dir) {
+		p, ok := Lookup(name)
+		if !ok {
+			t.Fatalf("unknown target %s", name)
+		}
+		generated, e := p.Generate(context.Background(), ts, conf)
+		if e != nil {
+			t.Errorf("%s: %v", name, e)
+			continue
+		}
+		for _, f := range generated {
+			// The enum target generates into the directory of the enum
+			// packages.
+			if name == "enum" {
+				f.Path = path.Join("enum", f.Path)
+			}
+			files[f.Path] = f.Content
+		}
+	}
+	return files
+}
+
+// goldenTargets returns the targets of the case in dir, which are all the
+// registered ones unless the case lists them.
+func goldenTargets(t *testing.T, dir string) []string {
+	b, e := ioutil.ReadFile(path.Join(dir, "targets"))
+	if os.IsNotExist(e) {
+		return Plugins()
+	}
+	if e != nil {
+		t.Fatal(e)
+	}
+	return strings.Split(strings.TrimSpace(string(b)), ",")
+}
+
+func writeGolden(t *testing.T, out string, files map[string][]byte) {
+	if e := os.RemoveAll(out); e != nil {
+		t.Fatal(e)
+	}
+	for name, content := range files {
+		p := path.Join(out, name)
+		if e := os.MkdirAll(path.Dir(p), 0755); e != nil {
+			t.Fatal(e)
+		}
+		if e := ioutil.WriteFile(p, content, 0644); e != nil {
+			t.Fatal(e)
+		}
+	}
+}
+
+func compareGolden(t *testing.T, out string, files map[string][]byte) {
+	golden := map[string][]byte{}
+	e := filepath.Walk(out, func(p string, info os.FileInfo, e error) error {
+		if e != nil || info.IsDir() {
+			return e
+		}
+		rel, e := filepath.Rel(out, p)
+		if e != nil {
+			return e
+		}
+		golden[filepath.ToSlash(rel)], e = ioutil.ReadFile(p)
+		return e
+	})
+	if e != nil && !os.IsNotExist(e) {
+		t.Fatal(e)
+	}
+
+	for _, name := range sortedNames(files) {
+		want, ok := golden[name]
+		if !ok {
+			t.Errorf("%s is generated but has no golden file", name)
+			continue
+		}
+		if line, ok := firstDifference(want, files[name]); !ok {
+			t.Errorf("%s differs from the golden file at line %d", name, line)
+		}
+	}
+	for _, name := range sortedNames(golden) {
+		if _, ok := files[name]; !ok {
+			t.Errorf("%s has a golden file but is not generated", name)
+		}
+	}
+	if t.Failed() && !*update {
+		t.Log("run go test -update if the changes are intended")
+	}
+}
+
+// firstDifference returns the first line where a and b differ, or false if
+// they are the same.
+func firstDifference(a, b []byte) (int, bool) {
+	if bytes.Equal(a, b) {
+		return 0, true
+	}
+	la, lb := bytes.Split(a, []byte("\n")), bytes.Split(b, []byte("\n"))
+	for i := 0; i < len(la) && i < len(lb); i++ {
+		if !bytes.Equal(la[i], lb[i]) {
+			return i + 1, false
+		}
+	}
+	if len(la) < len(lb) {
+		return len(la) + 1, false
+	}
+	return len(lb) + 1, false
+}
+
+func sortedNames(files map[string][]byte) []string {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// typecheckGolden typechecks the generated package and the enum packages
+// with go/types. The scalar package of the case and the stubs are
+// typechecked as their dependencies.
+func typecheckGolden(t *testing.T, dir string, files map[string][]byte, std types.Importer) {
+	prefix := goldenPackagePrefix(dir)
+	imp := &goldenImporter{
+		fset:     token.NewFileSet(),
+		std:      std,
+		sources:  map[string]map[string][]byte{},
+		packages: map[string]*types.Package{},
+	}
+	for name, content := range files {
+		if path.Ext(name) != ".go" {
+			continue
+		}
+		pkg := prefix
+		if d := path.Dir(name); d != "." {
+			pkg = path.Join(prefix, d)
+		}
+		imp.add(pkg, name, content)
+	}
+	if scalar, e := ioutil.ReadFile(path.Join(dir, "scalar.go")); e == nil {
+		imp.add(prefix+"/scalar", "scalar.go", scalar)
+	}
+	e := filepath.Walk(stubDir, func(p string, info os.FileInfo, e error) error {
+		if e != nil || info.IsDir() {
+			return e
+		}
+		src, e := ioutil.ReadFile(p)
+		if e != nil {
+			return e
+		}
+		pkg, e := filepath.Rel(stubDir, filepath.Dir(p))
+		if e != nil {
+			return e
+		}
+		imp.add(filepath.ToSlash(pkg), p, src)
+		return nil
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	var pkgs []string
+	for p := range imp.sources {
+		if strings.HasPrefix(p, prefix) && p != prefix+"/scalar" {
+			pkgs = append(pkgs, p)
+		}
+	}
+	sort.Strings(pkgs)
+	for _, p := range pkgs {
+		if _, e := imp.Import(p); e != nil {
+			t.Errorf("%s does not typecheck: %v", p, e)
+		}
+	}
+}
+
+// goldenImporter imports packages from the sources added to it, and the
+// standard library otherwise.
+type goldenImporter struct {
+	fset     *token.FileSet
+	std      types.Importer
+	sources  map[string]map[string][]byte
+	packages map[string]*types.Package
+}
+
+func (imp *goldenImporter) add(pkg, name string, src []byte) {
+	if imp.sources[pkg] == nil {
+		imp.sources[pkg] = map[string][]byte{}
+	}
+	imp.sources[pkg][name] = src
+}
+
+func (imp *goldenImporter) Import(p string) (*types.Package, error) {
+	if pkg, ok := imp.packages[p]; ok {
+		return pkg, nil
+	}
+	sources, ok := imp.sources[p]
+	if !ok {
+		return imp.std.Import(p)
+	}
+	var files []*ast.File
+	for _, name := range sortedNames(sources) {
+		f, e := parser.ParseFile(imp.fset, name, sources[name], 0)
+		if e != nil {
+			return nil, e
+		}
+		files = append(files, f)
+	}
+	var errs []string
+	conf := &types.Config{
+		Importer: imp,
+		Error:    func(e error) { errs = append(errs, e.Error()) },
+	}
+	pkg, _ := conf.Check(p, imp.fset, files, nil)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	imp.packages[p] = pkg
+	return pkg, nil
+}
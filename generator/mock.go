@@ -0,0 +1,179 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// MockTypeName returns the name of the struct generated by the mock target
+// for def.
+func MockTypeName(def *gql.Object) string {
+	return convertResolverName(def.Name) + "Mock"
+}
+
+// GenerateMock generates a mock implementation of the resolver interface of
+// def. Each method calls the function field of the same name and records its
+// arguments.
+func (g *Generator) GenerateMock(def *gql.Object) {
+	if e := mockNameCollision(def); e != nil {
+		fatal(e)
+	}
+	g.Printf(commentOnTop)
+	generateResolverPackageSection(g)
+	g.Println()
+	generateMockImportSection(g, def)
+	g.Println()
+	g.Printf("var _ %s = &%s{}\n", convertResolverName(def.Name), MockTypeName(def))
+	g.Println()
+	generateMockStruct(g, def)
+	for _, f := range def.Fields {
+		g.Println()
+		generateMockCallStruct(g, def, f)
+		g.Println()
+		generateMockMethod(g, def, f)
+		g.Println()
+		generateMockCallsMethod(g, def, f)
+	}
+}
+
+// mockNameCollision returns an error if members of the mock of def have the
+// same name, as the method XCalls of a field x has with the method of a field
+// xCalls, or the field XFunc of x has with the method of a field xFunc.
+func mockNameCollision(def *gql.Object) error {
+	fields := map[string]string{}
+	for _, f := range def.Fields {
+		fields[capitalizeFirst(f.Name)] = f.Name
+	}
+	for _, f := range def.Fields {
+		name := capitalizeFirst(f.Name)
+		for _, member := range []string{name + "Func", name + "Calls"} {
+			if other, ok := fields[member]; ok {
+				return fmt.Errorf(
+					"cannot generate the mock of %s: %s of the field %s collides with the method of the field %s",
+					def.Name, member, f.Name, other,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func generateMockImportSection(g *Generator, def *gql.Object) {
+	refs, needContext := scaffoldRefs(def.Fields)
+	imported := []string{"sync"}
+	if needContext {
+		imported = append([]string{"context"}, imported...)
+	}
+	imported = append(imported, importPaths(g, refs)...)
+
+	g.Println("import(")
+	for _, p := range imported {
+		g.Printf("%q\n", p)
+		if p == "sync" {
+			g.Println()
+		}
+	}
+	g.Println(")")
+}
+
+func generateMockStruct(g *Generator, def *gql.Object) {
+	g.Printf(
+		"// %s is a mock implementation of %s.\n",
+		MockTypeName(def), convertResolverName(def.Name),
+	)
+	g.Printf("type %s struct {\n", MockTypeName(def))
+	for _, f := range def.Fields {
+		g.Printf(
+			"%sFunc func(%s) %s\n",
+			capitalizeFirst(f.Name),
+			strings.Join(fieldParams(f, def), ","),
+			fieldResult(g, f),
+		)
+	}
+	g.Println()
+	g.Println("mu sync.Mutex")
+	g.Println("calls struct {")
+	for _, f := range def.Fields {
+		g.Printf("%s []%s\n", capitalizeFirst(f.Name), mockCallStructName(def, f))
+	}
+	g.Println("}")
+	g.Println("}")
+}
+
+func mockCallStructName(def *gql.Object, f *gql.ObjectField) string {
+	return MockTypeName(def) + "_" + capitalizeFirst(f.Name) + "_Call"
+}
+
+// mockParams returns names and types of parameters of the mocked method of f.
+func mockParams(f *gql.ObjectField, def *gql.Object) ([]string, []string) {
+	var names []string
+	types := fieldParams(f, def)
+	for _, t := range types {
+		if t == "context.Context" {
+			names = append(names, "ctx")
+		} else {
+			names = append(names, "arg")
+		}
+	}
+	return names, types
+}
+
+func generateMockCallStruct(g *Generator, def *gql.Object, f *gql.ObjectField) {
+	names, types := mockParams(f, def)
+	g.Printf(
+		"// %s holds arguments of a call to %s.\n",
+		mockCallStructName(def, f), capitalizeFirst(f.Name),
+	)
+	g.Printf("type %s struct {\n", mockCallStructName(def, f))
+	for i, n := range names {
+		g.Printf("%s %s\n", capitalizeFirst(n), types[i])
+	}
+	g.Println("}")
+}
+
+func generateMockMethod(g *Generator, def *gql.Object, f *gql.ObjectField) {
+	names, types := mockParams(f, def)
+	var params, fields []string
+	for i, n := range names {
+		params = append(params, n+" "+types[i])
+		fields = append(fields, capitalizeFirst(n)+": "+n)
+	}
+	name := capitalizeFirst(f.Name)
+	g.Printf(
+		"func (m *%s) %s(%s) %s {\n",
+		MockTypeName(def), name, strings.Join(params, ", "), fieldResult(g, f),
+	)
+	g.Printf("if m.%sFunc == nil {\n", name)
+	g.Printf(
+		`panic("%s.%sFunc is nil but %s.%s was called")`,
+		MockTypeName(def), name, convertResolverName(def.Name), name,
+	)
+	g.Println()
+	g.Println("}")
+	g.Println("m.mu.Lock()")
+	g.Printf(
+		"m.calls.%s = append(m.calls.%s, %s{%s})\n",
+		name, name, mockCallStructName(def, f), strings.Join(fields, ", "),
+	)
+	g.Println("m.mu.Unlock()")
+	g.Printf("return m.%sFunc(%s)\n", name, strings.Join(names, ", "))
+	g.Println("}")
+}
+
+func generateMockCallsMethod(g *Generator, def *gql.Object, f *gql.ObjectField) {
+	name := capitalizeFirst(f.Name)
+	g.Printf("// %sCalls returns arguments of calls to %s in order.\n", name, name)
+	g.Printf(
+		"func (m *%s) %sCalls() []%s {\n",
+		MockTypeName(def), name, mockCallStructName(def, f),
+	)
+	g.Println("m.mu.Lock()")
+	g.Println("defer m.mu.Unlock()")
+	g.Printf(
+		"return append([]%s(nil), m.calls.%s...)\n",
+		mockCallStructName(def, f), name,
+	)
+	g.Println("}")
+}
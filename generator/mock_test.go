@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+func TestMockNameCollision(t *testing.T) {
+	tests := []struct {
+		fields []string
+		want   string
+	}{
+		{
+			fields: []string{"trucks", "drivers", "truckCalls"},
+		},
+		{
+			fields: []string{"trucks", "trucksCalls"},
+			want:   "cannot generate the mock of Garage: TrucksCalls of the field trucks collides with the method of the field trucksCalls",
+		},
+		{
+			fields: []string{"trucksFunc", "trucks"},
+			want:   "cannot generate the mock of Garage: TrucksFunc of the field trucks collides with the method of the field trucksFunc",
+		},
+	}
+	for _, tt := range tests {
+		def := &gql.Object{Name: "Garage"}
+		for _, name := range tt.fields {
+			def.Fields = append(def.Fields, &gql.ObjectField{Name: name})
+		}
+		got := ""
+		if e := mockNameCollision(def); e != nil {
+			got = e.Error()
+		}
+		if got != tt.want {
+			t.Errorf("fields %q: got %q, want %q", tt.fields, got, tt.want)
+		}
+	}
+}
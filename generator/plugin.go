@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// File is a file generated by a plugin.
+type File struct {
+	// Path is the path of the file relative to the directory of the package,
+	// separated by slashes.
+	Path    string
+	Content []byte
+}
+
+// Plugin generates files of a target from a schema. The target -target
+// names is the plugin of the same name registered with Register.
+//
+// Plugins return the files instead of writing them, so that the command
+// skips files whose contents are unchanged and caches the generation.
+type Plugin interface {
+	Name() string
+	Generate(ctx context.Context, ts *gql.TypeSystem, conf Config) ([]File, error)
+}
+
+// OperationsPlugin is a Plugin which reads the operations under
+// Config.Operations besides the schema. The command regenerates the target
+// of the plugin when the operations change, and caches it with them.
+type OperationsPlugin interface {
+	Plugin
+	ReadsOperations() bool
+}
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   = map[string]Plugin{}
+)
+
+// Register makes p available as the target of its name. Third-party plugins
+// usually call it in init of their packages, which a custom main of the
+// command imports. It panics if a plugin of the same name is registered.
+func Register(p Plugin) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	if _, ok := plugins[p.Name()]; ok {
+		panic(fmt.Sprintf("generator: plugin %s is registered twice", p.Name()))
+	}
+	plugins[p.Name()] = p
+}
+
+// Lookup returns the plugin registered as name.
+func Lookup(name string) (Plugin, bool) {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	p, ok := plugins[name]
+	return p, ok
+}
+
+// Plugins returns the names of the registered plugins in sorted order.
+func Plugins() []string {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	var names []string
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+const graphqlPackage = "github.com/graph-gophers/graphql-go"
+
+// generatorDirectives are the declarations of the directives the generators
+// read. graph-gophers/graphql-go rejects directives which are not declared.
+var generatorDirectives = []struct{ name, declaration string }{
+	{"withContext", "directive @withContext on FIELD_DEFINITION"},
+	{"returnWithError", "directive @returnWithError on FIELD_DEFINITION"},
+	{"goScalarType", "directive @goScalarType(name: String!) on SCALAR"},
+}
+
+// GenerateRoot generates the schema source sdl as a constant, the root
+// resolver interface composed of the resolvers of the root operation types
+// and a function binding them for graph-gophers/graphql-go.
+// The declarations of the generator directives which sdl does not declare
+// are appended to the constant.
+func (g *Generator) GenerateRoot(sdl string) {
+	g.Printf(commentOnTop)
+	generateResolverPackageSection(g)
+	g.Println()
+	g.Printf("import %q\n", graphqlPackage)
+	g.Println()
+	g.Println("// Schema is the source of the schema.")
+	g.Printf("const Schema = %s\n", quoteSource(declareGeneratorDirectives(g.TypeSystem(), sdl)))
+	g.Println()
+	generateRootResolver(g)
+	g.Println()
+	g.Println("// NewSchema parses Schema and binds root to it.")
+	g.Println("func NewSchema(root RootResolver, opts ...graphql.SchemaOpt) (*graphql.Schema, error) {")
+	g.Println("return graphql.ParseSchema(Schema, root, opts...)")
+	g.Println("}")
+}
+
+func generateRootResolver(g *Generator) {
+	g.Println("// RootResolver resolves root operation types of the schema.")
+	g.Println("type RootResolver interface {")
+	for _, t := range RootOperationTypes(g.TypeSystem()) {
+		g.Println(convertResolverName(t.Name))
+	}
+	g.Println("}")
+}
+
+// RootOperationTypes returns object types of query, mutation and
+// subscription in this order. Operations which are not defined are omitted.
+// Types named Query, Mutation and Subscription are used when the schema
+// definition does not specify them.
+func RootOperationTypes(ts *gql.TypeSystem) []*gql.Object {
+	refs := []*gql.TypeRef{ts.Schema.Query, ts.Schema.Mutation, ts.Schema.Subscription}
+	defaults := []string{"Query", "Mutation", "Subscription"}
+	var ret []*gql.Object
+	for i, ref := range refs {
+		name := defaults[i]
+		if ref != nil {
+			name = ref.Name
+		}
+		if obj, ok := ts.ObjectTypes[name]; ok {
+			ret = append(ret, obj)
+		}
+	}
+	return ret
+}
+
+func declareGeneratorDirectives(ts *gql.TypeSystem, sdl string) string {
+	var decls []string
+	for _, d := range generatorDirectives {
+		if _, ok := ts.Directives[d.name]; !ok {
+			decls = append(decls, d.declaration)
+		}
+	}
+	if len(decls) == 0 {
+		return sdl
+	}
+	if !strings.HasSuffix(sdl, "\n") {
+		sdl += "\n"
+	}
+	return sdl + "\n" + strings.Join(decls, "\n") + "\n"
+}
+
+func quoteSource(src string) string {
+	if strings.Contains(src, "`") || strings.Contains(src, "\r") {
+		return strconv.Quote(src)
+	}
+	return "`" + src + "`"
+}
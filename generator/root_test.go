@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/parser"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// rootSchema is a part of the example schema which graph-gophers/graphql-go
+// supports, with the directives the generators read.
+const rootSchema = `schema {
+    query: Query
+}
+
+"It is used as ID"
+scalar Uint32 @goScalarType(name: "ID32")
+scalar Cursor
+
+type Query {
+    truck(number: String = null): Truck
+
+    "Returns garage"
+    garage(id: Uint32!): Garage @returnWithError
+}
+
+type Garage {
+    id: Uint32!
+    trucks(size: Uint32! = 20, cursor: Cursor = null): [Truck!]!
+    drivers(class: [Class!]! = [ELITE, KING_OF_ROAD]): [Driver!]!
+}
+
+type Truck {
+    maker: Maker!
+    capacity: Int!
+}
+
+type Driver {
+    name: String!
+    middleName: String @withContext()
+    class: Class!
+}
+
+enum Maker {
+    SCANIA
+    ISUZU @deprecated(reason: "not an euro truck")
+}
+
+enum Class {
+    ROOKIE
+    ELITE
+    KING_OF_ROAD
+}
+`
+
+func TestGenerateRoot(t *testing.T) {
+	tests := []struct {
+		name string
+		sdl  string
+	}{
+		{
+			name: "generator directives",
+			sdl:  rootSchema,
+		},
+		{
+			name: "declared generator directive",
+			sdl:  rootSchema + "directive @withContext on FIELD_DEFINITION | OBJECT",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ts := parser.NewParser(strings.NewReader(tt.sdl)).ParseAndEvalSchema()
+			g := NewGenerator(ts, &Config{Package: &Package{Name: "example"}})
+			g.GenerateRoot(tt.sdl)
+			schema := schemaConstant(t, g.buff.String())
+			if !strings.HasPrefix(schema, tt.sdl) {
+				t.Errorf("Schema does not start with the source:\n%s", schema)
+			}
+			if _, e := graphql.ParseSchema(schema, nil); e != nil {
+				t.Errorf("graphql.ParseSchema: %v\n%s", e, schema)
+			}
+		})
+	}
+}
+
+// schemaConstant returns the value of the constant Schema in src.
+func schemaConstant(t *testing.T, src string) string {
+	t.Helper()
+	f, e := goparser.ParseFile(token.NewFileSet(), "root_gql.go", src, 0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if vs.Names[0].Name == "Schema" {
+				s, e := strconv.Unquote(vs.Values[0].(*ast.BasicLit).Value)
+				if e != nil {
+					t.Fatal(e)
+				}
+				return s
+			}
+		}
+	}
+	t.Fatalf("Schema is not declared in\n%s", src)
+	return ""
+}
@@ -0,0 +1,190 @@
+package generator
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// ScaffoldTypeName returns the name of the struct generated by the scaffold
+// target for def.
+func ScaffoldTypeName(def *gql.Object) string {
+	return lowerFirst(convertResolverName(def.Name))
+}
+
+// GenerateScaffold generates a struct implementing the resolver interface of
+// def whose methods only panic.
+// src is the current content of the destination file, or nil if it does not
+// exist yet. When src is given, stubs of the methods which are not
+// implemented anywhere in the package are appended to it and the rest of src
+// is kept as it is.
+// It returns false when there is nothing to add to src.
+func (g *Generator) GenerateScaffold(def *gql.Object, src []byte) bool {
+	if src == nil {
+		generateScaffold(g, def)
+		return true
+	}
+	return appendScaffold(g, def, src)
+}
+
+func generateScaffold(g *Generator, def *gql.Object) {
+	generateResolverPackageSection(g)
+	g.Println()
+	refs, needContext := scaffoldRefs(def.Fields)
+	generateImports(g, refs, needContext)
+	g.Println()
+	g.Printf("type %s struct{}\n", ScaffoldTypeName(def))
+	for _, f := range def.Fields {
+		g.Println()
+		generateScaffoldMethod(g, def, f)
+	}
+}
+
+func generateScaffoldMethod(g *Generator, def *gql.Object, f *gql.ObjectField) {
+	g.Printf(
+		"func (r *%s) %s(%s) %s {\n",
+		ScaffoldTypeName(def),
+		capitalizeFirst(f.Name),
+		strings.Join(fieldParams(f, def), ","),
+		fieldResult(g, f),
+	)
+	g.Println(`panic("not implemented")`)
+	g.Println("}")
+}
+
+func scaffoldRefs(fields []*gql.ObjectField) ([]*gql.TypeRef, bool) {
+	var refs []*gql.TypeRef
+	needContext := false
+	for _, f := range fields {
+		refs = append(refs, f.Type)
+		needContext = needContext || needsContext(f)
+	}
+	return refs, needContext
+}
+
+func appendScaffold(g *Generator, def *gql.Object, src []byte) bool {
+	implemented := implementedMethods(g.Config().Package.Path, ScaffoldTypeName(def))
+	var missing []*gql.ObjectField
+	for _, f := range def.Fields {
+		if _, ok := implemented[capitalizeFirst(f.Name)]; !ok {
+			missing = append(missing, f)
+		}
+	}
+	if len(missing) == 0 {
+		return false
+	}
+
+	stubs := NewGenerator(g.TypeSystem(), g.Config())
+	for _, f := range missing {
+		stubs.Println()
+		generateScaffoldMethod(stubs, def, f)
+	}
+	stubs.Format()
+
+	refs, needContext := scaffoldRefs(missing)
+	paths := importPaths(g, refs)
+	if needContext {
+		paths = append(paths, "context")
+	}
+	src = insertImports(src, paths)
+	g.buff.Write(bytes.TrimRight(src, "\n"))
+	g.Println()
+	g.buff.Write(stubs.buff.Bytes())
+	return true
+}
+
+// implementedMethods returns names of methods of typeName declared in go
+// files in dir.
+func implementedMethods(dir, typeName string) map[string]struct{} {
+	files, e := filepath.Glob(filepath.Join(dir, "*.go"))
+	if e != nil {
+		fatal(e)
+	}
+	methods := map[string]struct{}{}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, e := parser.ParseFile(fset, file, nil, 0)
+		if e != nil {
+			fatalf("error occured while parsing %s: %v", file, e)
+		}
+		for _, d := range f.Decls {
+			fn, ok := d.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+			if receiverName(fn.Recv.List[0].Type) == typeName {
+				methods[fn.Name.Name] = struct{}{}
+			}
+		}
+	}
+	return methods
+}
+
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// insertImports adds import declarations of paths which src does not import
+// yet. The rest of src is left untouched.
+func insertImports(src []byte, paths []string) []byte {
+	fset := token.NewFileSet()
+	f, e := parser.ParseFile(fset, "", src, parser.ImportsOnly)
+	if e != nil {
+		fatal(e)
+	}
+	imported := map[string]struct{}{}
+	for _, i := range f.Imports {
+		imported[strings.Trim(i.Path.Value, `"`)] = struct{}{}
+	}
+	var lines []string
+	for _, p := range paths {
+		if _, ok := imported[p]; ok {
+			continue
+		}
+		imported[p] = struct{}{}
+		lines = append(lines, `"`+p+`"`)
+	}
+	if len(lines) == 0 {
+		return src
+	}
+
+	var offset int
+	var text string
+	if decl := firstImportDecl(f); decl != nil && decl.Lparen.IsValid() {
+		offset = fset.Position(decl.Lparen).Offset + 1
+		text = "\n\t" + strings.Join(lines, "\n\t")
+	} else {
+		offset = fset.Position(f.Name.End()).Offset
+		if decl != nil {
+			offset = fset.Position(decl.End()).Offset
+		}
+		text = "\n\nimport (\n\t" + strings.Join(lines, "\n\t") + "\n)"
+	}
+	ret := make([]byte, 0, len(src)+len(text))
+	ret = append(ret, src[:offset]...)
+	ret = append(ret, text...)
+	return append(ret, src[offset:]...)
+}
+
+func firstImportDecl(f *ast.File) *ast.GenDecl {
+	for _, d := range f.Decls {
+		if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			return gd
+		}
+	}
+	return nil
+}
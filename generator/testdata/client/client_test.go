@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// serve starts a server responding with status and body, which stores the
+// last request into req.
+func serve(t *testing.T, status int, body string, req *request) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("got %s request with Content-Type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		if e := json.NewDecoder(r.Body).Decode(req); e != nil {
+			t.Error(e)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestSearch(t *testing.T) {
+	var req request
+	s := serve(t, http.StatusOK, `{"data": {"search": [
+		{"__typename": "Truck", "maker": "SCANIA", "name": "T1"},
+		{"__typename": "Trailer", "length": 12}
+	]}}`, &req)
+
+	maker := "SCANIA"
+	res, e := Search(context.Background(), s.Client(), s.URL, SearchVariables{
+		Text:   "t",
+		Filter: &Filter{Maker: &maker},
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if req.Query != SearchDocument || req.OperationName != "Search" {
+		t.Errorf("got request %+v", req)
+	}
+	wantVars := map[string]interface{}{"text": "t", "filter": map[string]interface{}{"maker": "SCANIA"}}
+	if !reflect.DeepEqual(req.Variables, wantVars) {
+		t.Errorf("got variables %v, want %v", req.Variables, wantVars)
+	}
+	name, length := "T1", 12
+	want := &SearchResponse{Search: []SearchResponse_Search{
+		{Typename: "Truck", Maker: &maker, Name: &name},
+		{Typename: "Trailer", Length: &length},
+	}}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("got %+v, want %+v", res, want)
+	}
+}
+
+func TestVehicle(t *testing.T) {
+	var req request
+	s := serve(t, http.StatusOK, `{"data": {"vehicle": {"id": "1", "name": "T1"}}}`, &req)
+
+	res, e := Vehicle(context.Background(), s.Client(), s.URL, VehicleVariables{Id: "1"})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if res.Vehicle == nil || res.Vehicle.Id != "1" || *res.Vehicle.Name != "T1" || res.Vehicle.Maker != nil {
+		t.Errorf("got %+v", res.Vehicle)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	var req request
+	s := serve(t, http.StatusOK, `{
+		"data": {"renamed": {"id": "1", "name": null}},
+		"errors": [{"message": "name is taken", "path": ["renamed", "name"]}, {"message": "retry later"}]
+	}`, &req)
+
+	res, e := Rename(context.Background(), s.Client(), s.URL, RenameVariables{Id: "1", Name: "T1"})
+	errs, ok := e.(GraphQLErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("got error %#v, want GraphQLErrors", e)
+	}
+	if e.Error() != "name is taken; retry later" {
+		t.Errorf("got error %q", e.Error())
+	}
+	if !reflect.DeepEqual(errs[0].Path, []interface{}{"renamed", "name"}) {
+		t.Errorf("got path %v", errs[0].Path)
+	}
+	if res.Renamed.Id != "1" || res.Renamed.Name != nil {
+		t.Errorf("data is not decoded with errors: %+v", res.Renamed)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{}`, "unexpected status 502 Bad Gateway"},
+		{`bad gateway`, "502 Bad Gateway: "},
+	}
+	for _, tt := range tests {
+		var req request
+		s := serve(t, http.StatusBadGateway, tt.body, &req)
+		_, e := Vehicle(context.Background(), s.Client(), s.URL, VehicleVariables{Id: "1"})
+		if e == nil || !strings.HasPrefix(e.Error(), tt.want) {
+			t.Errorf("body %q: got error %v, want %s", tt.body, e, tt.want)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package feed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GraphQLDoer sends HTTP requests. *http.Client implements it.
+type GraphQLDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// GraphQLError is an error in a GraphQL response.
+type GraphQLError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+func (e *GraphQLError) Error() string {
+	return e.Message
+}
+
+// GraphQLErrors are errors in a GraphQL response.
+type GraphQLErrors []*GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	var messages []string
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// doGraphQLRequest posts query to endpoint and decodes the data of the
+// response into data. Errors in the response are returned as GraphQLErrors
+// after data is decoded.
+func doGraphQLRequest(
+	ctx context.Context, doer GraphQLDoer, endpoint, query, operationName string,
+	variables interface{}, data interface{},
+) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":         query,
+		"operationName": operationName,
+		"variables":     variables,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := doer.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var payload struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("%s: %v", res.Status, err)
+	}
+	if len(payload.Data) > 0 && string(payload.Data) != "null" {
+		if err := json.Unmarshal(payload.Data, data); err != nil {
+			return err
+		}
+	}
+	if len(payload.Errors) > 0 {
+		return payload.Errors
+	}
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
+
+// FeedDocument is the document of the query Feed.
+const FeedDocument = `query Feed($first: Int) {
+  feed(first: $first) {
+    __typename
+    ... on Post {
+      ...PostFields
+    }
+    ... on Ad {
+      url
+      sponsor
+    }
+  }
+}
+
+fragment PostFields on Post {
+  id
+  title
+  author {
+    ... {
+      name
+    }
+  }
+}
+`
+
+// FeedVariables are variables of the query Feed.
+type FeedVariables struct {
+	First *int `json:"first,omitempty"`
+}
+
+// FeedResponse is the data of the response to the query Feed.
+type FeedResponse struct {
+	Feed []FeedResponse_Feed `json:"feed"`
+}
+
+// FeedResponse_Feed is feed of FeedResponse.
+type FeedResponse_Feed struct {
+	Typename string                    `json:"__typename"`
+	Id       *string                   `json:"id"`
+	Title    *string                   `json:"title"`
+	Author   *FeedResponse_Feed_Author `json:"author"`
+	Url      *string                   `json:"url"`
+	Sponsor  *string                   `json:"sponsor"`
+}
+
+// FeedResponse_Feed_Author is author of FeedResponse_Feed.
+type FeedResponse_Feed_Author struct {
+	Name string `json:"name"`
+}
+
+// Feed sends the query Feed to endpoint. The response is returned with
+// GraphQLErrors if it has errors.
+func Feed(ctx context.Context, doer GraphQLDoer, endpoint string, variables FeedVariables) (*FeedResponse, error) {
+	data := &FeedResponse{}
+	err := doGraphQLRequest(ctx, doer, endpoint, FeedDocument, "Feed", variables, data)
+	return data, err
+}
+
+// NodeDocument is the document of the query Node.
+const NodeDocument = `query Node($id: ID!) {
+  node(id: $id) {
+    id
+    ... on User {
+      name
+    }
+    ... on Post {
+      title
+    }
+  }
+}
+`
+
+// NodeVariables are variables of the query Node.
+type NodeVariables struct {
+	Id string `json:"id"`
+}
+
+// NodeResponse is the data of the response to the query Node.
+type NodeResponse struct {
+	Node *NodeResponse_Node `json:"node"`
+}
+
+// NodeResponse_Node is node of NodeResponse.
+type NodeResponse_Node struct {
+	Id    string  `json:"id"`
+	Name  *string `json:"name"`
+	Title *string `json:"title"`
+}
+
+// Node sends the query Node to endpoint. The response is returned with
+// GraphQLErrors if it has errors.
+func Node(ctx context.Context, doer GraphQLDoer, endpoint string, variables NodeVariables) (*NodeResponse, error) {
+	data := &NodeResponse{}
+	err := doGraphQLRequest(ctx, doer, endpoint, NodeDocument, "Node", variables, data)
+	return data, err
+}
@@ -0,0 +1,84 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package minimal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GraphQLDoer sends HTTP requests. *http.Client implements it.
+type GraphQLDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// GraphQLError is an error in a GraphQL response.
+type GraphQLError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+func (e *GraphQLError) Error() string {
+	return e.Message
+}
+
+// GraphQLErrors are errors in a GraphQL response.
+type GraphQLErrors []*GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	var messages []string
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// doGraphQLRequest posts query to endpoint and decodes the data of the
+// response into data. Errors in the response are returned as GraphQLErrors
+// after data is decoded.
+func doGraphQLRequest(
+	ctx context.Context, doer GraphQLDoer, endpoint, query, operationName string,
+	variables interface{}, data interface{},
+) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":         query,
+		"operationName": operationName,
+		"variables":     variables,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := doer.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var payload struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("%s: %v", res.Status, err)
+	}
+	if len(payload.Data) > 0 && string(payload.Data) != "null" {
+		if err := json.Unmarshal(payload.Data, data); err != nil {
+			return err
+		}
+	}
+	if len(payload.Errors) > 0 {
+		return payload.Errors
+	}
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package minimal
+
+import (
+	"context"
+)
+
+type queryResolver struct{}
+
+func (r *queryResolver) Hello(context.Context, QueryResolver_Hello_Arg) string {
+	panic("not implemented")
+}
+
+func (r *queryResolver) Count() *int {
+	panic("not implemented")
+}
+
+func (r *queryResolver) Ratio() float32 {
+	panic("not implemented")
+}
+
+func (r *queryResolver) Enabled() *bool {
+	panic("not implemented")
+}
+
+func (r *queryResolver) Names() []string {
+	panic("not implemented")
+}
@@ -0,0 +1,30 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package minimal
+
+import (
+	"context"
+)
+
+type QueryResolver interface {
+	Hello(context.Context, QueryResolver_Hello_Arg) string
+
+	// Return value of Count is nullable
+	Count() *int
+	Ratio() float32
+
+	// Return value of Enabled is nullable
+	Enabled() *bool
+	Names() []string
+}
+
+type QueryResolver_Hello_Arg struct {
+	// Default: "world"
+	Name *string
+}
+
+// DefaultQueryResolver_Hello_Arg returns QueryResolver_Hello_Arg holding default values of the arguments.
+func DefaultQueryResolver_Hello_Arg() QueryResolver_Hello_Arg {
+	return QueryResolver_Hello_Arg{
+		Name: func() *string { v := "world"; return &v }(),
+	}
+}
@@ -0,0 +1,134 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package minimal
+
+import (
+	"context"
+	"sync"
+)
+
+var _ QueryResolver = &QueryResolverMock{}
+
+// QueryResolverMock is a mock implementation of QueryResolver.
+type QueryResolverMock struct {
+	HelloFunc   func(context.Context, QueryResolver_Hello_Arg) string
+	CountFunc   func() *int
+	RatioFunc   func() float32
+	EnabledFunc func() *bool
+	NamesFunc   func() []string
+
+	mu    sync.Mutex
+	calls struct {
+		Hello   []QueryResolverMock_Hello_Call
+		Count   []QueryResolverMock_Count_Call
+		Ratio   []QueryResolverMock_Ratio_Call
+		Enabled []QueryResolverMock_Enabled_Call
+		Names   []QueryResolverMock_Names_Call
+	}
+}
+
+// QueryResolverMock_Hello_Call holds arguments of a call to Hello.
+type QueryResolverMock_Hello_Call struct {
+	Ctx context.Context
+	Arg QueryResolver_Hello_Arg
+}
+
+func (m *QueryResolverMock) Hello(ctx context.Context, arg QueryResolver_Hello_Arg) string {
+	if m.HelloFunc == nil {
+		panic("QueryResolverMock.HelloFunc is nil but QueryResolver.Hello was called")
+	}
+	m.mu.Lock()
+	m.calls.Hello = append(m.calls.Hello, QueryResolverMock_Hello_Call{Ctx: ctx, Arg: arg})
+	m.mu.Unlock()
+	return m.HelloFunc(ctx, arg)
+}
+
+// HelloCalls returns arguments of calls to Hello in order.
+func (m *QueryResolverMock) HelloCalls() []QueryResolverMock_Hello_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]QueryResolverMock_Hello_Call(nil), m.calls.Hello...)
+}
+
+// QueryResolverMock_Count_Call holds arguments of a call to Count.
+type QueryResolverMock_Count_Call struct {
+}
+
+func (m *QueryResolverMock) Count() *int {
+	if m.CountFunc == nil {
+		panic("QueryResolverMock.CountFunc is nil but QueryResolver.Count was called")
+	}
+	m.mu.Lock()
+	m.calls.Count = append(m.calls.Count, QueryResolverMock_Count_Call{})
+	m.mu.Unlock()
+	return m.CountFunc()
+}
+
+// CountCalls returns arguments of calls to Count in order.
+func (m *QueryResolverMock) CountCalls() []QueryResolverMock_Count_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]QueryResolverMock_Count_Call(nil), m.calls.Count...)
+}
+
+// QueryResolverMock_Ratio_Call holds arguments of a call to Ratio.
+type QueryResolverMock_Ratio_Call struct {
+}
+
+func (m *QueryResolverMock) Ratio() float32 {
+	if m.RatioFunc == nil {
+		panic("QueryResolverMock.RatioFunc is nil but QueryResolver.Ratio was called")
+	}
+	m.mu.Lock()
+	m.calls.Ratio = append(m.calls.Ratio, QueryResolverMock_Ratio_Call{})
+	m.mu.Unlock()
+	return m.RatioFunc()
+}
+
+// RatioCalls returns arguments of calls to Ratio in order.
+func (m *QueryResolverMock) RatioCalls() []QueryResolverMock_Ratio_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]QueryResolverMock_Ratio_Call(nil), m.calls.Ratio...)
+}
+
+// QueryResolverMock_Enabled_Call holds arguments of a call to Enabled.
+type QueryResolverMock_Enabled_Call struct {
+}
+
+func (m *QueryResolverMock) Enabled() *bool {
+	if m.EnabledFunc == nil {
+		panic("QueryResolverMock.EnabledFunc is nil but QueryResolver.Enabled was called")
+	}
+	m.mu.Lock()
+	m.calls.Enabled = append(m.calls.Enabled, QueryResolverMock_Enabled_Call{})
+	m.mu.Unlock()
+	return m.EnabledFunc()
+}
+
+// EnabledCalls returns arguments of calls to Enabled in order.
+func (m *QueryResolverMock) EnabledCalls() []QueryResolverMock_Enabled_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]QueryResolverMock_Enabled_Call(nil), m.calls.Enabled...)
+}
+
+// QueryResolverMock_Names_Call holds arguments of a call to Names.
+type QueryResolverMock_Names_Call struct {
+}
+
+func (m *QueryResolverMock) Names() []string {
+	if m.NamesFunc == nil {
+		panic("QueryResolverMock.NamesFunc is nil but QueryResolver.Names was called")
+	}
+	m.mu.Lock()
+	m.calls.Names = append(m.calls.Names, QueryResolverMock_Names_Call{})
+	m.mu.Unlock()
+	return m.NamesFunc()
+}
+
+// NamesCalls returns arguments of calls to Names in order.
+func (m *QueryResolverMock) NamesCalls() []QueryResolverMock_Names_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]QueryResolverMock_Names_Call(nil), m.calls.Names...)
+}
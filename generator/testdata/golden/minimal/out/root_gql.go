@@ -0,0 +1,28 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package minimal
+
+import "github.com/graph-gophers/graphql-go"
+
+// Schema is the source of the schema.
+const Schema = `type Query {
+    hello(name: String = "world"): String!
+    count: Int
+    ratio: Float!
+    enabled: Boolean
+    names: [String!]!
+}
+
+directive @withContext on FIELD_DEFINITION
+directive @returnWithError on FIELD_DEFINITION
+directive @goScalarType(name: String!) on SCALAR
+`
+
+// RootResolver resolves root operation types of the schema.
+type RootResolver interface {
+	QueryResolver
+}
+
+// NewSchema parses Schema and binds root to it.
+func NewSchema(root RootResolver, opts ...graphql.SchemaOpt) (*graphql.Schema, error) {
+	return graphql.ParseSchema(Schema, root, opts...)
+}
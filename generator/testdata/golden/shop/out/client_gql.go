@@ -0,0 +1,182 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gqlcodegen.test/shop/enum/status"
+	"gqlcodegen.test/shop/scalar"
+)
+
+// GraphQLDoer sends HTTP requests. *http.Client implements it.
+type GraphQLDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// GraphQLError is an error in a GraphQL response.
+type GraphQLError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+func (e *GraphQLError) Error() string {
+	return e.Message
+}
+
+// GraphQLErrors are errors in a GraphQL response.
+type GraphQLErrors []*GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	var messages []string
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// doGraphQLRequest posts query to endpoint and decodes the data of the
+// response into data. Errors in the response are returned as GraphQLErrors
+// after data is decoded.
+func doGraphQLRequest(
+	ctx context.Context, doer GraphQLDoer, endpoint, query, operationName string,
+	variables interface{}, data interface{},
+) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":         query,
+		"operationName": operationName,
+		"variables":     variables,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := doer.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var payload struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("%s: %v", res.Status, err)
+	}
+	if len(payload.Data) > 0 && string(payload.Data) != "null" {
+		if err := json.Unmarshal(payload.Data, data); err != nil {
+			return err
+		}
+	}
+	if len(payload.Errors) > 0 {
+		return payload.Errors
+	}
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
+
+// OrderProductDocument is the document of the mutation OrderProduct.
+const OrderProductDocument = `mutation OrderProduct($productId: String!, $quantity: Int) {
+  orderProduct(productId: $productId, quantity: $quantity) {
+    id
+    status
+    createdAt
+    items {
+      quantity
+      product {
+        id
+        name
+        price
+      }
+    }
+  }
+}
+`
+
+// OrderProductVariables are variables of the mutation OrderProduct.
+type OrderProductVariables struct {
+	ProductId string `json:"productId"`
+	Quantity  *int   `json:"quantity,omitempty"`
+}
+
+// OrderProductResponse is the data of the response to the mutation OrderProduct.
+type OrderProductResponse struct {
+	OrderProduct OrderProductResponse_OrderProduct `json:"orderProduct"`
+}
+
+// OrderProductResponse_OrderProduct is orderProduct of OrderProductResponse.
+type OrderProductResponse_OrderProduct struct {
+	Id        string                                    `json:"id"`
+	Status    *status.Status                            `json:"status"`
+	CreatedAt scalar.Timestamp                          `json:"createdAt"`
+	Items     []OrderProductResponse_OrderProduct_Items `json:"items"`
+}
+
+// OrderProductResponse_OrderProduct_Items is items of OrderProductResponse_OrderProduct.
+type OrderProductResponse_OrderProduct_Items struct {
+	Quantity int                                             `json:"quantity"`
+	Product  OrderProductResponse_OrderProduct_Items_Product `json:"product"`
+}
+
+// OrderProductResponse_OrderProduct_Items_Product is product of OrderProductResponse_OrderProduct_Items.
+type OrderProductResponse_OrderProduct_Items_Product struct {
+	Id    string  `json:"id"`
+	Name  string  `json:"name"`
+	Price float32 `json:"price"`
+}
+
+// OrderProduct sends the mutation OrderProduct to endpoint. The response is returned with
+// GraphQLErrors if it has errors.
+func OrderProduct(ctx context.Context, doer GraphQLDoer, endpoint string, variables OrderProductVariables) (*OrderProductResponse, error) {
+	data := &OrderProductResponse{}
+	err := doGraphQLRequest(ctx, doer, endpoint, OrderProductDocument, "OrderProduct", variables, data)
+	return data, err
+}
+
+// ListProductsDocument is the document of the query ListProducts.
+const ListProductsDocument = `query ListProducts($first: Int) {
+  products(first: $first) {
+    id
+    name
+    tags
+    status
+  }
+}
+`
+
+// ListProductsVariables are variables of the query ListProducts.
+type ListProductsVariables struct {
+	First *int `json:"first,omitempty"`
+}
+
+// ListProductsResponse is the data of the response to the query ListProducts.
+type ListProductsResponse struct {
+	Products []*ListProductsResponse_Products `json:"products"`
+}
+
+// ListProductsResponse_Products is products of ListProductsResponse.
+type ListProductsResponse_Products struct {
+	Id     string        `json:"id"`
+	Name   string        `json:"name"`
+	Tags   *[]string     `json:"tags"`
+	Status status.Status `json:"status"`
+}
+
+// ListProducts sends the query ListProducts to endpoint. The response is returned with
+// GraphQLErrors if it has errors.
+func ListProducts(ctx context.Context, doer GraphQLDoer, endpoint string, variables ListProductsVariables) (*ListProductsResponse, error) {
+	data := &ListProductsResponse{}
+	err := doGraphQLRequest(ctx, doer, endpoint, ListProductsDocument, "ListProducts", variables, data)
+	return data, err
+}
@@ -0,0 +1,65 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+type Status int
+
+const (
+	AVAILABLE Status = iota
+	SOLD_OUT
+)
+
+const _Status_Name = "AVAILABLESOLD_OUT"
+
+var _Status_Index = []int{0, 9, 17}
+
+func (v Status) String() string {
+	if v < 0 || v >= Status(len(_Status_Index)-1) {
+		return "Status(" + strconv.FormatInt(int64(v), 10) + ")"
+	}
+	return _Status_Name[_Status_Index[v]:_Status_Index[v+1]]
+}
+
+func StatusFromString(str string) (Status, error) {
+	for i := 0; i < len(_Status_Index)-1; i++ {
+		if v := Status(i); str == v.String() {
+			return v, nil
+		}
+	}
+	return -1, errors.New(str + " is not found")
+}
+
+func (Status) ImplementsGraphQLType(name string) bool {
+	return name == "Status"
+}
+
+func (v *Status) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case string:
+		value, err := StatusFromString(input)
+		if err != nil {
+			return err
+		}
+		*v = value
+		return nil
+	default:
+		return errors.New("wrong type")
+	}
+}
+
+func (v Status) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + v.String() + `"`), nil
+}
+
+func (v *Status) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	return v.UnmarshalGraphQL(str)
+}
@@ -0,0 +1,15 @@
+package shop
+
+import (
+	"context"
+)
+
+type mutationResolver struct{}
+
+func (r *mutationResolver) OrderProduct(context.Context, MutationResolver_OrderProduct_Arg) OrderResolver {
+	panic("not implemented")
+}
+
+func (r *mutationResolver) CancelOrder(context.Context, MutationResolver_CancelOrder_Arg) bool {
+	panic("not implemented")
+}
@@ -0,0 +1,32 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"context"
+)
+
+type MutationResolver interface {
+	OrderProduct(context.Context, MutationResolver_OrderProduct_Arg) OrderResolver
+	CancelOrder(context.Context, MutationResolver_CancelOrder_Arg) bool
+}
+
+type MutationResolver_OrderProduct_Arg struct {
+	ProductId string
+	// Default: 1
+	Quantity *int
+	// Default: ""
+	Note *string
+}
+
+// DefaultMutationResolver_OrderProduct_Arg returns MutationResolver_OrderProduct_Arg holding default values of the arguments.
+func DefaultMutationResolver_OrderProduct_Arg() MutationResolver_OrderProduct_Arg {
+	return MutationResolver_OrderProduct_Arg{
+		Quantity: func() *int { v := 1; return &v }(),
+		Note:     func() *string { v := ""; return &v }(),
+	}
+}
+
+type MutationResolver_CancelOrder_Arg struct {
+	Id     string
+	Reason *string
+}
@@ -0,0 +1,67 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"context"
+	"sync"
+)
+
+var _ MutationResolver = &MutationResolverMock{}
+
+// MutationResolverMock is a mock implementation of MutationResolver.
+type MutationResolverMock struct {
+	OrderProductFunc func(context.Context, MutationResolver_OrderProduct_Arg) OrderResolver
+	CancelOrderFunc  func(context.Context, MutationResolver_CancelOrder_Arg) bool
+
+	mu    sync.Mutex
+	calls struct {
+		OrderProduct []MutationResolverMock_OrderProduct_Call
+		CancelOrder  []MutationResolverMock_CancelOrder_Call
+	}
+}
+
+// MutationResolverMock_OrderProduct_Call holds arguments of a call to OrderProduct.
+type MutationResolverMock_OrderProduct_Call struct {
+	Ctx context.Context
+	Arg MutationResolver_OrderProduct_Arg
+}
+
+func (m *MutationResolverMock) OrderProduct(ctx context.Context, arg MutationResolver_OrderProduct_Arg) OrderResolver {
+	if m.OrderProductFunc == nil {
+		panic("MutationResolverMock.OrderProductFunc is nil but MutationResolver.OrderProduct was called")
+	}
+	m.mu.Lock()
+	m.calls.OrderProduct = append(m.calls.OrderProduct, MutationResolverMock_OrderProduct_Call{Ctx: ctx, Arg: arg})
+	m.mu.Unlock()
+	return m.OrderProductFunc(ctx, arg)
+}
+
+// OrderProductCalls returns arguments of calls to OrderProduct in order.
+func (m *MutationResolverMock) OrderProductCalls() []MutationResolverMock_OrderProduct_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MutationResolverMock_OrderProduct_Call(nil), m.calls.OrderProduct...)
+}
+
+// MutationResolverMock_CancelOrder_Call holds arguments of a call to CancelOrder.
+type MutationResolverMock_CancelOrder_Call struct {
+	Ctx context.Context
+	Arg MutationResolver_CancelOrder_Arg
+}
+
+func (m *MutationResolverMock) CancelOrder(ctx context.Context, arg MutationResolver_CancelOrder_Arg) bool {
+	if m.CancelOrderFunc == nil {
+		panic("MutationResolverMock.CancelOrderFunc is nil but MutationResolver.CancelOrder was called")
+	}
+	m.mu.Lock()
+	m.calls.CancelOrder = append(m.calls.CancelOrder, MutationResolverMock_CancelOrder_Call{Ctx: ctx, Arg: arg})
+	m.mu.Unlock()
+	return m.CancelOrderFunc(ctx, arg)
+}
+
+// CancelOrderCalls returns arguments of calls to CancelOrder in order.
+func (m *MutationResolverMock) CancelOrderCalls() []MutationResolverMock_CancelOrder_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MutationResolverMock_CancelOrder_Call(nil), m.calls.CancelOrder...)
+}
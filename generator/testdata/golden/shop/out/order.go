@@ -0,0 +1,28 @@
+package shop
+
+import (
+	"gqlcodegen.test/shop/enum/status"
+	"gqlcodegen.test/shop/scalar"
+)
+
+type orderResolver struct{}
+
+func (r *orderResolver) Id() string {
+	panic("not implemented")
+}
+
+func (r *orderResolver) Items() []OrderItemResolver {
+	panic("not implemented")
+}
+
+func (r *orderResolver) Status() *status.Status {
+	panic("not implemented")
+}
+
+func (r *orderResolver) CreatedAt() scalar.Timestamp {
+	panic("not implemented")
+}
+
+func (r *orderResolver) CanceledAt() *scalar.Timestamp {
+	panic("not implemented")
+}
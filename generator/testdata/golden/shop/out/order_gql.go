@@ -0,0 +1,19 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"gqlcodegen.test/shop/enum/status"
+	"gqlcodegen.test/shop/scalar"
+)
+
+type OrderResolver interface {
+	Id() string
+	Items() []OrderItemResolver
+
+	// Return value of Status is nullable
+	Status() *status.Status
+	CreatedAt() scalar.Timestamp
+
+	// Return value of CanceledAt is nullable
+	CanceledAt() *scalar.Timestamp
+}
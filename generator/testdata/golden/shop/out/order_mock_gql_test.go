@@ -0,0 +1,134 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"sync"
+
+	"gqlcodegen.test/shop/enum/status"
+	"gqlcodegen.test/shop/scalar"
+)
+
+var _ OrderResolver = &OrderResolverMock{}
+
+// OrderResolverMock is a mock implementation of OrderResolver.
+type OrderResolverMock struct {
+	IdFunc         func() string
+	ItemsFunc      func() []OrderItemResolver
+	StatusFunc     func() *status.Status
+	CreatedAtFunc  func() scalar.Timestamp
+	CanceledAtFunc func() *scalar.Timestamp
+
+	mu    sync.Mutex
+	calls struct {
+		Id         []OrderResolverMock_Id_Call
+		Items      []OrderResolverMock_Items_Call
+		Status     []OrderResolverMock_Status_Call
+		CreatedAt  []OrderResolverMock_CreatedAt_Call
+		CanceledAt []OrderResolverMock_CanceledAt_Call
+	}
+}
+
+// OrderResolverMock_Id_Call holds arguments of a call to Id.
+type OrderResolverMock_Id_Call struct {
+}
+
+func (m *OrderResolverMock) Id() string {
+	if m.IdFunc == nil {
+		panic("OrderResolverMock.IdFunc is nil but OrderResolver.Id was called")
+	}
+	m.mu.Lock()
+	m.calls.Id = append(m.calls.Id, OrderResolverMock_Id_Call{})
+	m.mu.Unlock()
+	return m.IdFunc()
+}
+
+// IdCalls returns arguments of calls to Id in order.
+func (m *OrderResolverMock) IdCalls() []OrderResolverMock_Id_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]OrderResolverMock_Id_Call(nil), m.calls.Id...)
+}
+
+// OrderResolverMock_Items_Call holds arguments of a call to Items.
+type OrderResolverMock_Items_Call struct {
+}
+
+func (m *OrderResolverMock) Items() []OrderItemResolver {
+	if m.ItemsFunc == nil {
+		panic("OrderResolverMock.ItemsFunc is nil but OrderResolver.Items was called")
+	}
+	m.mu.Lock()
+	m.calls.Items = append(m.calls.Items, OrderResolverMock_Items_Call{})
+	m.mu.Unlock()
+	return m.ItemsFunc()
+}
+
+// ItemsCalls returns arguments of calls to Items in order.
+func (m *OrderResolverMock) ItemsCalls() []OrderResolverMock_Items_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]OrderResolverMock_Items_Call(nil), m.calls.Items...)
+}
+
+// OrderResolverMock_Status_Call holds arguments of a call to Status.
+type OrderResolverMock_Status_Call struct {
+}
+
+func (m *OrderResolverMock) Status() *status.Status {
+	if m.StatusFunc == nil {
+		panic("OrderResolverMock.StatusFunc is nil but OrderResolver.Status was called")
+	}
+	m.mu.Lock()
+	m.calls.Status = append(m.calls.Status, OrderResolverMock_Status_Call{})
+	m.mu.Unlock()
+	return m.StatusFunc()
+}
+
+// StatusCalls returns arguments of calls to Status in order.
+func (m *OrderResolverMock) StatusCalls() []OrderResolverMock_Status_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]OrderResolverMock_Status_Call(nil), m.calls.Status...)
+}
+
+// OrderResolverMock_CreatedAt_Call holds arguments of a call to CreatedAt.
+type OrderResolverMock_CreatedAt_Call struct {
+}
+
+func (m *OrderResolverMock) CreatedAt() scalar.Timestamp {
+	if m.CreatedAtFunc == nil {
+		panic("OrderResolverMock.CreatedAtFunc is nil but OrderResolver.CreatedAt was called")
+	}
+	m.mu.Lock()
+	m.calls.CreatedAt = append(m.calls.CreatedAt, OrderResolverMock_CreatedAt_Call{})
+	m.mu.Unlock()
+	return m.CreatedAtFunc()
+}
+
+// CreatedAtCalls returns arguments of calls to CreatedAt in order.
+func (m *OrderResolverMock) CreatedAtCalls() []OrderResolverMock_CreatedAt_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]OrderResolverMock_CreatedAt_Call(nil), m.calls.CreatedAt...)
+}
+
+// OrderResolverMock_CanceledAt_Call holds arguments of a call to CanceledAt.
+type OrderResolverMock_CanceledAt_Call struct {
+}
+
+func (m *OrderResolverMock) CanceledAt() *scalar.Timestamp {
+	if m.CanceledAtFunc == nil {
+		panic("OrderResolverMock.CanceledAtFunc is nil but OrderResolver.CanceledAt was called")
+	}
+	m.mu.Lock()
+	m.calls.CanceledAt = append(m.calls.CanceledAt, OrderResolverMock_CanceledAt_Call{})
+	m.mu.Unlock()
+	return m.CanceledAtFunc()
+}
+
+// CanceledAtCalls returns arguments of calls to CanceledAt in order.
+func (m *OrderResolverMock) CanceledAtCalls() []OrderResolverMock_CanceledAt_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]OrderResolverMock_CanceledAt_Call(nil), m.calls.CanceledAt...)
+}
@@ -0,0 +1,62 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"sync"
+)
+
+var _ OrderItemResolver = &OrderItemResolverMock{}
+
+// OrderItemResolverMock is a mock implementation of OrderItemResolver.
+type OrderItemResolverMock struct {
+	ProductFunc  func() ProductResolver
+	QuantityFunc func() int
+
+	mu    sync.Mutex
+	calls struct {
+		Product  []OrderItemResolverMock_Product_Call
+		Quantity []OrderItemResolverMock_Quantity_Call
+	}
+}
+
+// OrderItemResolverMock_Product_Call holds arguments of a call to Product.
+type OrderItemResolverMock_Product_Call struct {
+}
+
+func (m *OrderItemResolverMock) Product() ProductResolver {
+	if m.ProductFunc == nil {
+		panic("OrderItemResolverMock.ProductFunc is nil but OrderItemResolver.Product was called")
+	}
+	m.mu.Lock()
+	m.calls.Product = append(m.calls.Product, OrderItemResolverMock_Product_Call{})
+	m.mu.Unlock()
+	return m.ProductFunc()
+}
+
+// ProductCalls returns arguments of calls to Product in order.
+func (m *OrderItemResolverMock) ProductCalls() []OrderItemResolverMock_Product_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]OrderItemResolverMock_Product_Call(nil), m.calls.Product...)
+}
+
+// OrderItemResolverMock_Quantity_Call holds arguments of a call to Quantity.
+type OrderItemResolverMock_Quantity_Call struct {
+}
+
+func (m *OrderItemResolverMock) Quantity() int {
+	if m.QuantityFunc == nil {
+		panic("OrderItemResolverMock.QuantityFunc is nil but OrderItemResolver.Quantity was called")
+	}
+	m.mu.Lock()
+	m.calls.Quantity = append(m.calls.Quantity, OrderItemResolverMock_Quantity_Call{})
+	m.mu.Unlock()
+	return m.QuantityFunc()
+}
+
+// QuantityCalls returns arguments of calls to Quantity in order.
+func (m *OrderItemResolverMock) QuantityCalls() []OrderItemResolverMock_Quantity_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]OrderItemResolverMock_Quantity_Call(nil), m.calls.Quantity...)
+}
@@ -0,0 +1,27 @@
+package shop
+
+import (
+	"gqlcodegen.test/shop/enum/status"
+)
+
+type productResolver struct{}
+
+func (r *productResolver) Id() string {
+	panic("not implemented")
+}
+
+func (r *productResolver) Name() string {
+	panic("not implemented")
+}
+
+func (r *productResolver) Price() float32 {
+	panic("not implemented")
+}
+
+func (r *productResolver) Tags() *[]string {
+	panic("not implemented")
+}
+
+func (r *productResolver) Status() status.Status {
+	panic("not implemented")
+}
@@ -0,0 +1,16 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"gqlcodegen.test/shop/enum/status"
+)
+
+type ProductResolver interface {
+	Id() string
+	Name() string
+	Price() float32
+
+	// Return value of Tags is nullable
+	Tags() *[]string
+	Status() status.Status
+}
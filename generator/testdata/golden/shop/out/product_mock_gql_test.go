@@ -0,0 +1,133 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"sync"
+
+	"gqlcodegen.test/shop/enum/status"
+)
+
+var _ ProductResolver = &ProductResolverMock{}
+
+// ProductResolverMock is a mock implementation of ProductResolver.
+type ProductResolverMock struct {
+	IdFunc     func() string
+	NameFunc   func() string
+	PriceFunc  func() float32
+	TagsFunc   func() *[]string
+	StatusFunc func() status.Status
+
+	mu    sync.Mutex
+	calls struct {
+		Id     []ProductResolverMock_Id_Call
+		Name   []ProductResolverMock_Name_Call
+		Price  []ProductResolverMock_Price_Call
+		Tags   []ProductResolverMock_Tags_Call
+		Status []ProductResolverMock_Status_Call
+	}
+}
+
+// ProductResolverMock_Id_Call holds arguments of a call to Id.
+type ProductResolverMock_Id_Call struct {
+}
+
+func (m *ProductResolverMock) Id() string {
+	if m.IdFunc == nil {
+		panic("ProductResolverMock.IdFunc is nil but ProductResolver.Id was called")
+	}
+	m.mu.Lock()
+	m.calls.Id = append(m.calls.Id, ProductResolverMock_Id_Call{})
+	m.mu.Unlock()
+	return m.IdFunc()
+}
+
+// IdCalls returns arguments of calls to Id in order.
+func (m *ProductResolverMock) IdCalls() []ProductResolverMock_Id_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ProductResolverMock_Id_Call(nil), m.calls.Id...)
+}
+
+// ProductResolverMock_Name_Call holds arguments of a call to Name.
+type ProductResolverMock_Name_Call struct {
+}
+
+func (m *ProductResolverMock) Name() string {
+	if m.NameFunc == nil {
+		panic("ProductResolverMock.NameFunc is nil but ProductResolver.Name was called")
+	}
+	m.mu.Lock()
+	m.calls.Name = append(m.calls.Name, ProductResolverMock_Name_Call{})
+	m.mu.Unlock()
+	return m.NameFunc()
+}
+
+// NameCalls returns arguments of calls to Name in order.
+func (m *ProductResolverMock) NameCalls() []ProductResolverMock_Name_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ProductResolverMock_Name_Call(nil), m.calls.Name...)
+}
+
+// ProductResolverMock_Price_Call holds arguments of a call to Price.
+type ProductResolverMock_Price_Call struct {
+}
+
+func (m *ProductResolverMock) Price() float32 {
+	if m.PriceFunc == nil {
+		panic("ProductResolverMock.PriceFunc is nil but ProductResolver.Price was called")
+	}
+	m.mu.Lock()
+	m.calls.Price = append(m.calls.Price, ProductResolverMock_Price_Call{})
+	m.mu.Unlock()
+	return m.PriceFunc()
+}
+
+// PriceCalls returns arguments of calls to Price in order.
+func (m *ProductResolverMock) PriceCalls() []ProductResolverMock_Price_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ProductResolverMock_Price_Call(nil), m.calls.Price...)
+}
+
+// ProductResolverMock_Tags_Call holds arguments of a call to Tags.
+type ProductResolverMock_Tags_Call struct {
+}
+
+func (m *ProductResolverMock) Tags() *[]string {
+	if m.TagsFunc == nil {
+		panic("ProductResolverMock.TagsFunc is nil but ProductResolver.Tags was called")
+	}
+	m.mu.Lock()
+	m.calls.Tags = append(m.calls.Tags, ProductResolverMock_Tags_Call{})
+	m.mu.Unlock()
+	return m.TagsFunc()
+}
+
+// TagsCalls returns arguments of calls to Tags in order.
+func (m *ProductResolverMock) TagsCalls() []ProductResolverMock_Tags_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ProductResolverMock_Tags_Call(nil), m.calls.Tags...)
+}
+
+// ProductResolverMock_Status_Call holds arguments of a call to Status.
+type ProductResolverMock_Status_Call struct {
+}
+
+func (m *ProductResolverMock) Status() status.Status {
+	if m.StatusFunc == nil {
+		panic("ProductResolverMock.StatusFunc is nil but ProductResolver.Status was called")
+	}
+	m.mu.Lock()
+	m.calls.Status = append(m.calls.Status, ProductResolverMock_Status_Call{})
+	m.mu.Unlock()
+	return m.StatusFunc()
+}
+
+// StatusCalls returns arguments of calls to Status in order.
+func (m *ProductResolverMock) StatusCalls() []ProductResolverMock_Status_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ProductResolverMock_Status_Call(nil), m.calls.Status...)
+}
@@ -0,0 +1,19 @@
+package shop
+
+import (
+	"context"
+)
+
+type queryResolver struct{}
+
+func (r *queryResolver) Product(context.Context, QueryResolver_Product_Arg) ProductResolver {
+	panic("not implemented")
+}
+
+func (r *queryResolver) Products(context.Context, QueryResolver_Products_Arg) []ProductResolver {
+	panic("not implemented")
+}
+
+func (r *queryResolver) Orders(context.Context, QueryResolver_Orders_Arg) *[]*[]OrderResolver {
+	panic("not implemented")
+}
@@ -0,0 +1,47 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"context"
+
+	"gqlcodegen.test/shop/enum/status"
+)
+
+type QueryResolver interface {
+
+	// Return value of Product is nullable
+	Product(context.Context, QueryResolver_Product_Arg) ProductResolver
+	Products(context.Context, QueryResolver_Products_Arg) []ProductResolver
+
+	// Return value of Orders is nullable
+	Orders(context.Context, QueryResolver_Orders_Arg) *[]*[]OrderResolver
+}
+
+type QueryResolver_Product_Arg struct {
+	Id string
+}
+
+type QueryResolver_Products_Arg struct {
+	// Default: 10
+	First *int
+	After *string
+}
+
+// DefaultQueryResolver_Products_Arg returns QueryResolver_Products_Arg holding default values of the arguments.
+func DefaultQueryResolver_Products_Arg() QueryResolver_Products_Arg {
+	return QueryResolver_Products_Arg{
+		First: func() *int { v := 10; return &v }(),
+	}
+}
+
+type QueryResolver_Orders_Arg struct {
+	// Default: [AVAILABLE]
+	Status *[]status.Status
+}
+
+// DefaultQueryResolver_Orders_Arg returns QueryResolver_Orders_Arg holding default values of the arguments.
+func DefaultQueryResolver_Orders_Arg() QueryResolver_Orders_Arg {
+	return QueryResolver_Orders_Arg{
+		Status: func() *[]status.Status { v := []status.Status{status.AVAILABLE}; return &v }(),
+	}
+}
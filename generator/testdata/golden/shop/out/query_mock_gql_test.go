@@ -0,0 +1,92 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"context"
+	"sync"
+)
+
+var _ QueryResolver = &QueryResolverMock{}
+
+// QueryResolverMock is a mock implementation of QueryResolver.
+type QueryResolverMock struct {
+	ProductFunc  func(context.Context, QueryResolver_Product_Arg) ProductResolver
+	ProductsFunc func(context.Context, QueryResolver_Products_Arg) []ProductResolver
+	OrdersFunc   func(context.Context, QueryResolver_Orders_Arg) *[]*[]OrderResolver
+
+	mu    sync.Mutex
+	calls struct {
+		Product  []QueryResolverMock_Product_Call
+		Products []QueryResolverMock_Products_Call
+		Orders   []QueryResolverMock_Orders_Call
+	}
+}
+
+// QueryResolverMock_Product_Call holds arguments of a call to Product.
+type QueryResolverMock_Product_Call struct {
+	Ctx context.Context
+	Arg QueryResolver_Product_Arg
+}
+
+func (m *QueryResolverMock) Product(ctx context.Context, arg QueryResolver_Product_Arg) ProductResolver {
+	if m.ProductFunc == nil {
+		panic("QueryResolverMock.ProductFunc is nil but QueryResolver.Product was called")
+	}
+	m.mu.Lock()
+	m.calls.Product = append(m.calls.Product, QueryResolverMock_Product_Call{Ctx: ctx, Arg: arg})
+	m.mu.Unlock()
+	return m.ProductFunc(ctx, arg)
+}
+
+// ProductCalls returns arguments of calls to Product in order.
+func (m *QueryResolverMock) ProductCalls() []QueryResolverMock_Product_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]QueryResolverMock_Product_Call(nil), m.calls.Product...)
+}
+
+// QueryResolverMock_Products_Call holds arguments of a call to Products.
+type QueryResolverMock_Products_Call struct {
+	Ctx context.Context
+	Arg QueryResolver_Products_Arg
+}
+
+func (m *QueryResolverMock) Products(ctx context.Context, arg QueryResolver_Products_Arg) []ProductResolver {
+	if m.ProductsFunc == nil {
+		panic("QueryResolverMock.ProductsFunc is nil but QueryResolver.Products was called")
+	}
+	m.mu.Lock()
+	m.calls.Products = append(m.calls.Products, QueryResolverMock_Products_Call{Ctx: ctx, Arg: arg})
+	m.mu.Unlock()
+	return m.ProductsFunc(ctx, arg)
+}
+
+// ProductsCalls returns arguments of calls to Products in order.
+func (m *QueryResolverMock) ProductsCalls() []QueryResolverMock_Products_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]QueryResolverMock_Products_Call(nil), m.calls.Products...)
+}
+
+// QueryResolverMock_Orders_Call holds arguments of a call to Orders.
+type QueryResolverMock_Orders_Call struct {
+	Ctx context.Context
+	Arg QueryResolver_Orders_Arg
+}
+
+func (m *QueryResolverMock) Orders(ctx context.Context, arg QueryResolver_Orders_Arg) *[]*[]OrderResolver {
+	if m.OrdersFunc == nil {
+		panic("QueryResolverMock.OrdersFunc is nil but QueryResolver.Orders was called")
+	}
+	m.mu.Lock()
+	m.calls.Orders = append(m.calls.Orders, QueryResolverMock_Orders_Call{Ctx: ctx, Arg: arg})
+	m.mu.Unlock()
+	return m.OrdersFunc(ctx, arg)
+}
+
+// OrdersCalls returns arguments of calls to Orders in order.
+func (m *QueryResolverMock) OrdersCalls() []QueryResolverMock_Orders_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]QueryResolverMock_Orders_Call(nil), m.calls.Orders...)
+}
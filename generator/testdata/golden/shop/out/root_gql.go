@@ -0,0 +1,71 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import "github.com/graph-gophers/graphql-go"
+
+// Schema is the source of the schema.
+const Schema = `schema {
+    query: Query
+    mutation: Mutation
+    subscription: Subscription
+}
+
+scalar Time @goScalarType(name: "Timestamp")
+
+type Query {
+    product(id: String!): Product
+    products(first: Int = 10, after: String): [Product]!
+    orders(status: [Status!] = [AVAILABLE]): [[Order!]]
+}
+
+type Mutation {
+    orderProduct(productId: String!, quantity: Int = 1, note: String = ""): Order!
+    cancelOrder(id: String!, reason: String): Boolean!
+}
+
+type Subscription {
+    orderUpdated(id: String!): Order!
+}
+
+type Product {
+    id: String!
+    name: String!
+    price: Float!
+    tags: [String!]
+    status: Status!
+}
+
+type Order {
+    id: String!
+    items: [OrderItem!]!
+    status: Status
+    createdAt: Time!
+    canceledAt: Time
+}
+
+type OrderItem {
+    product: Product!
+    quantity: Int!
+}
+
+enum Status {
+    AVAILABLE
+    SOLD_OUT
+}
+
+directive @withContext on FIELD_DEFINITION
+directive @returnWithError on FIELD_DEFINITION
+directive @goScalarType(name: String!) on SCALAR
+`
+
+// RootResolver resolves root operation types of the schema.
+type RootResolver interface {
+	QueryResolver
+	MutationResolver
+	SubscriptionResolver
+}
+
+// NewSchema parses Schema and binds root to it.
+func NewSchema(root RootResolver, opts ...graphql.SchemaOpt) (*graphql.Schema, error) {
+	return graphql.ParseSchema(Schema, root, opts...)
+}
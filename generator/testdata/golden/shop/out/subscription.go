@@ -0,0 +1,11 @@
+package shop
+
+import (
+	"context"
+)
+
+type subscriptionResolver struct{}
+
+func (r *subscriptionResolver) OrderUpdated(context.Context, SubscriptionResolver_OrderUpdated_Arg) OrderResolver {
+	panic("not implemented")
+}
@@ -0,0 +1,14 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"context"
+)
+
+type SubscriptionResolver interface {
+	OrderUpdated(context.Context, SubscriptionResolver_OrderUpdated_Arg) OrderResolver
+}
+
+type SubscriptionResolver_OrderUpdated_Arg struct {
+	Id string
+}
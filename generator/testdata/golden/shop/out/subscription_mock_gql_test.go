@@ -0,0 +1,42 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package shop
+
+import (
+	"context"
+	"sync"
+)
+
+var _ SubscriptionResolver = &SubscriptionResolverMock{}
+
+// SubscriptionResolverMock is a mock implementation of SubscriptionResolver.
+type SubscriptionResolverMock struct {
+	OrderUpdatedFunc func(context.Context, SubscriptionResolver_OrderUpdated_Arg) OrderResolver
+
+	mu    sync.Mutex
+	calls struct {
+		OrderUpdated []SubscriptionResolverMock_OrderUpdated_Call
+	}
+}
+
+// SubscriptionResolverMock_OrderUpdated_Call holds arguments of a call to OrderUpdated.
+type SubscriptionResolverMock_OrderUpdated_Call struct {
+	Ctx context.Context
+	Arg SubscriptionResolver_OrderUpdated_Arg
+}
+
+func (m *SubscriptionResolverMock) OrderUpdated(ctx context.Context, arg SubscriptionResolver_OrderUpdated_Arg) OrderResolver {
+	if m.OrderUpdatedFunc == nil {
+		panic("SubscriptionResolverMock.OrderUpdatedFunc is nil but SubscriptionResolver.OrderUpdated was called")
+	}
+	m.mu.Lock()
+	m.calls.OrderUpdated = append(m.calls.OrderUpdated, SubscriptionResolverMock_OrderUpdated_Call{Ctx: ctx, Arg: arg})
+	m.mu.Unlock()
+	return m.OrderUpdatedFunc(ctx, arg)
+}
+
+// OrderUpdatedCalls returns arguments of calls to OrderUpdated in order.
+func (m *SubscriptionResolverMock) OrderUpdatedCalls() []SubscriptionResolverMock_OrderUpdated_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]SubscriptionResolverMock_OrderUpdated_Call(nil), m.calls.OrderUpdated...)
+}
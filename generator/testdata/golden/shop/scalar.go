@@ -0,0 +1,5 @@
+package scalar
+
+import "time"
+
+type Timestamp time.Time
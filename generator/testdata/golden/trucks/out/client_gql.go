@@ -0,0 +1,186 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package trucks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gqlcodegen.test/trucks/enum/class"
+	"gqlcodegen.test/trucks/enum/maker"
+	"gqlcodegen.test/trucks/scalar"
+)
+
+// GraphQLDoer sends HTTP requests. *http.Client implements it.
+type GraphQLDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// GraphQLError is an error in a GraphQL response.
+type GraphQLError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+func (e *GraphQLError) Error() string {
+	return e.Message
+}
+
+// GraphQLErrors are errors in a GraphQL response.
+type GraphQLErrors []*GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	var messages []string
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// doGraphQLRequest posts query to endpoint and decodes the data of the
+// response into data. Errors in the response are returned as GraphQLErrors
+// after data is decoded.
+func doGraphQLRequest(
+	ctx context.Context, doer GraphQLDoer, endpoint, query, operationName string,
+	variables interface{}, data interface{},
+) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":         query,
+		"operationName": operationName,
+		"variables":     variables,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := doer.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var payload struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("%s: %v", res.Status, err)
+	}
+	if len(payload.Data) > 0 && string(payload.Data) != "null" {
+		if err := json.Unmarshal(payload.Data, data); err != nil {
+			return err
+		}
+	}
+	if len(payload.Errors) > 0 {
+		return payload.Errors
+	}
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
+
+// GetGarageDocument is the document of the query GetGarage.
+const GetGarageDocument = `query GetGarage($id: Uint32!, $size: Uint32 = 10, $cls: [Class!]!) {
+  garage(id: $id) {
+    id
+    trucks(size: $size) {
+      ...TruckFields
+    }
+    drivers(class: $cls) {
+      name
+      licenceNumber
+      class
+    }
+  }
+}
+
+fragment TruckFields on Truck {
+  maker
+  number
+  capacity
+}
+`
+
+// GetGarageVariables are variables of the query GetGarage.
+type GetGarageVariables struct {
+	Id   scalar.Uint32  `json:"id"`
+	Size *scalar.Uint32 `json:"size,omitempty"`
+	Cls  []class.Class  `json:"cls"`
+}
+
+// GetGarageResponse is the data of the response to the query GetGarage.
+type GetGarageResponse struct {
+	Garage *GetGarageResponse_Garage `json:"garage"`
+}
+
+// GetGarageResponse_Garage is garage of GetGarageResponse.
+type GetGarageResponse_Garage struct {
+	Id      scalar.Uint32                      `json:"id"`
+	Trucks  []GetGarageResponse_Garage_Trucks  `json:"trucks"`
+	Drivers []GetGarageResponse_Garage_Drivers `json:"drivers"`
+}
+
+// GetGarageResponse_Garage_Trucks is trucks of GetGarageResponse_Garage.
+type GetGarageResponse_Garage_Trucks struct {
+	Maker    maker.Maker               `json:"maker"`
+	Number   scalar.RegistrationNumber `json:"number"`
+	Capacity int                       `json:"capacity"`
+}
+
+// GetGarageResponse_Garage_Drivers is drivers of GetGarageResponse_Garage.
+type GetGarageResponse_Garage_Drivers struct {
+	Name          string      `json:"name"`
+	LicenceNumber *string     `json:"licenceNumber"`
+	Class         class.Class `json:"class"`
+}
+
+// GetGarage sends the query GetGarage to endpoint. The response is returned with
+// GraphQLErrors if it has errors.
+func GetGarage(ctx context.Context, doer GraphQLDoer, endpoint string, variables GetGarageVariables) (*GetGarageResponse, error) {
+	data := &GetGarageResponse{}
+	err := doGraphQLRequest(ctx, doer, endpoint, GetGarageDocument, "GetGarage", variables, data)
+	return data, err
+}
+
+// GetTruckDocument is the document of the query GetTruck.
+const GetTruckDocument = `query GetTruck($number: RegistrationNumber) {
+  truck(number: $number) {
+    maker
+    capacity
+    enginePower
+  }
+}
+`
+
+// GetTruckVariables are variables of the query GetTruck.
+type GetTruckVariables struct {
+	Number *scalar.RegistrationNumber `json:"number,omitempty"`
+}
+
+// GetTruckResponse is the data of the response to the query GetTruck.
+type GetTruckResponse struct {
+	Truck *GetTruckResponse_Truck `json:"truck"`
+}
+
+// GetTruckResponse_Truck is truck of GetTruckResponse.
+type GetTruckResponse_Truck struct {
+	Maker       maker.Maker `json:"maker"`
+	Capacity    int         `json:"capacity"`
+	EnginePower *int        `json:"enginePower"`
+}
+
+// GetTruck sends the query GetTruck to endpoint. The response is returned with
+// GraphQLErrors if it has errors.
+func GetTruck(ctx context.Context, doer GraphQLDoer, endpoint string, variables GetTruckVariables) (*GetTruckResponse, error) {
+	data := &GetTruckResponse{}
+	err := doGraphQLRequest(ctx, doer, endpoint, GetTruckDocument, "GetTruck", variables, data)
+	return data, err
+}
@@ -0,0 +1,31 @@
+package trucks
+
+import (
+	"gqlcodegen.test/trucks/enum/class"
+)
+
+type driverResolver struct{}
+
+func (r *driverResolver) LicenceNumber() *string {
+	panic("not implemented")
+}
+
+func (r *driverResolver) Name() string {
+	panic("not implemented")
+}
+
+func (r *driverResolver) MiddleName() *string {
+	panic("not implemented")
+}
+
+func (r *driverResolver) IsOnDuty() bool {
+	panic("not implemented")
+}
+
+func (r *driverResolver) Class() class.Class {
+	panic("not implemented")
+}
+
+func (r *driverResolver) Rating() *float32 {
+	panic("not implemented")
+}
@@ -0,0 +1,21 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package trucks
+
+import (
+	"gqlcodegen.test/trucks/enum/class"
+)
+
+type DriverResolver interface {
+
+	// Return value of LicenceNumber is nullable
+	LicenceNumber() *string
+	Name() string
+
+	// Return value of MiddleName is nullable
+	MiddleName() *string
+	IsOnDuty() bool
+	Class() class.Class
+
+	// Return value of Rating is nullable
+	Rating() *float32
+}
@@ -0,0 +1,156 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package trucks
+
+import (
+	"sync"
+
+	"gqlcodegen.test/trucks/enum/class"
+)
+
+var _ DriverResolver = &DriverResolverMock{}
+
+// DriverResolverMock is a mock implementation of DriverResolver.
+type DriverResolverMock struct {
+	LicenceNumberFunc func() *string
+	NameFunc          func() string
+	MiddleNameFunc    func() *string
+	IsOnDutyFunc      func() bool
+	ClassFunc         func() class.Class
+	RatingFunc        func() *float32
+
+	mu    sync.Mutex
+	calls struct {
+		LicenceNumber []DriverResolverMock_LicenceNumber_Call
+		Name          []DriverResolverMock_Name_Call
+		MiddleName    []DriverResolverMock_MiddleName_Call
+		IsOnDuty      []DriverResolverMock_IsOnDuty_Call
+		Class         []DriverResolverMock_Class_Call
+		Rating        []DriverResolverMock_Rating_Call
+	}
+}
+
+// DriverResolverMock_LicenceNumber_Call holds arguments of a call to LicenceNumber.
+type DriverResolverMock_LicenceNumber_Call struct {
+}
+
+func (m *DriverResolverMock) LicenceNumber() *string {
+	if m.LicenceNumberFunc == nil {
+		panic("DriverResolverMock.LicenceNumberFunc is nil but DriverResolver.LicenceNumber was called")
+	}
+	m.mu.Lock()
+	m.calls.LicenceNumber = append(m.calls.LicenceNumber, DriverResolverMock_LicenceNumber_Call{})
+	m.mu.Unlock()
+	return m.LicenceNumberFunc()
+}
+
+// LicenceNumberCalls returns arguments of calls to LicenceNumber in order.
+func (m *DriverResolverMock) LicenceNumberCalls() []DriverResolverMock_LicenceNumber_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]DriverResolverMock_LicenceNumber_Call(nil), m.calls.LicenceNumber...)
+}
+
+// DriverResolverMock_Name_Call holds arguments of a call to Name.
+type DriverResolverMock_Name_Call struct {
+}
+
+func (m *DriverResolverMock) Name() string {
+	if m.NameFunc == nil {
+		panic("DriverResolverMock.NameFunc is nil but DriverResolver.Name was called")
+	}
+	m.mu.Lock()
+	m.calls.Name = append(m.calls.Name, DriverResolverMock_Name_Call{})
+	m.mu.Unlock()
+	return m.NameFunc()
+}
+
+// NameCalls returns arguments of calls to Name in order.
+func (m *DriverResolverMock) NameCalls() []DriverResolverMock_Name_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]DriverResolverMock_Name_Call(nil), m.calls.Name...)
+}
+
+// DriverResolverMock_MiddleName_Call holds arguments of a call to MiddleName.
+type DriverResolverMock_MiddleName_Call struct {
+}
+
+func (m *DriverResolverMock) MiddleName() *string {
+	if m.MiddleNameFunc == nil {
+		panic("DriverResolverMock.MiddleNameFunc is nil but DriverResolver.MiddleName was called")
+	}
+	m.mu.Lock()
+	m.calls.MiddleName = append(m.calls.MiddleName, DriverResolverMock_MiddleName_Call{})
+	m.mu.Unlock()
+	return m.MiddleNameFunc()
+}
+
+// MiddleNameCalls returns arguments of calls to MiddleName in order.
+func (m *DriverResolverMock) MiddleNameCalls() []DriverResolverMock_MiddleName_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]DriverResolverMock_MiddleName_Call(nil), m.calls.MiddleName...)
+}
+
+// DriverResolverMock_IsOnDuty_Call holds arguments of a call to IsOnDuty.
+type DriverResolverMock_IsOnDuty_Call struct {
+}
+
+func (m *DriverResolverMock) IsOnDuty() bool {
+	if m.IsOnDutyFunc == nil {
+		panic("DriverResolverMock.IsOnDutyFunc is nil but DriverResolver.IsOnDuty was called")
+	}
+	m.mu.Lock()
+	m.calls.IsOnDuty = append(m.calls.IsOnDuty, DriverResolverMock_IsOnDuty_Call{})
+	m.mu.Unlock()
+	return m.IsOnDutyFunc()
+}
+
+// IsOnDutyCalls returns arguments of calls to IsOnDuty in order.
+func (m *DriverResolverMock) IsOnDutyCalls() []DriverResolverMock_IsOnDuty_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]DriverResolverMock_IsOnDuty_Call(nil), m.calls.IsOnDuty...)
+}
+
+// DriverResolverMock_Class_Call holds arguments of a call to Class.
+type DriverResolverMock_Class_Call struct {
+}
+
+func (m *DriverResolverMock) Class() class.Class {
+	if m.ClassFunc == nil {
+		panic("DriverResolverMock.ClassFunc is nil but DriverResolver.Class was called")
+	}
+	m.mu.Lock()
+	m.calls.Class = append(m.calls.Class, DriverResolverMock_Class_Call{})
+	m.mu.Unlock()
+	return m.ClassFunc()
+}
+
+// ClassCalls returns arguments of calls to Class in order.
+func (m *DriverResolverMock) ClassCalls() []DriverResolverMock_Class_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]DriverResolverMock_Class_Call(nil), m.calls.Class...)
+}
+
+// DriverResolverMock_Rating_Call holds arguments of a call to Rating.
+type DriverResolverMock_Rating_Call struct {
+}
+
+func (m *DriverResolverMock) Rating() *float32 {
+	if m.RatingFunc == nil {
+		panic("DriverResolverMock.RatingFunc is nil but DriverResolver.Rating was called")
+	}
+	m.mu.Lock()
+	m.calls.Rating = append(m.calls.Rating, DriverResolverMock_Rating_Call{})
+	m.mu.Unlock()
+	return m.RatingFunc()
+}
+
+// RatingCalls returns arguments of calls to Rating in order.
+func (m *DriverResolverMock) RatingCalls() []DriverResolverMock_Rating_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]DriverResolverMock_Rating_Call(nil), m.calls.Rating...)
+}
@@ -0,0 +1,77 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package maker
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+type Maker int
+
+const (
+
+	/*
+	   Description:
+	     "Scania is awesome"
+	*/
+	SCANIA Maker = iota
+	DAF
+	VOLVO
+
+	/*
+	   Directives:
+	     @deprecated(reason: "not an euro truck")
+	*/
+	ISUZU
+)
+
+const _Maker_Name = "SCANIADAFVOLVOISUZU"
+
+var _Maker_Index = []int{0, 6, 9, 14, 19}
+
+func (v Maker) String() string {
+	if v < 0 || v >= Maker(len(_Maker_Index)-1) {
+		return "Maker(" + strconv.FormatInt(int64(v), 10) + ")"
+	}
+	return _Maker_Name[_Maker_Index[v]:_Maker_Index[v+1]]
+}
+
+func MakerFromString(str string) (Maker, error) {
+	for i := 0; i < len(_Maker_Index)-1; i++ {
+		if v := Maker(i); str == v.String() {
+			return v, nil
+		}
+	}
+	return -1, errors.New(str + " is not found")
+}
+
+func (Maker) ImplementsGraphQLType(name string) bool {
+	return name == "Maker"
+}
+
+func (v *Maker) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case string:
+		value, err := MakerFromString(input)
+		if err != nil {
+			return err
+		}
+		*v = value
+		return nil
+	default:
+		return errors.New("wrong type")
+	}
+}
+
+func (v Maker) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + v.String() + `"`), nil
+}
+
+func (v *Maker) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	return v.UnmarshalGraphQL(str)
+}
@@ -0,0 +1,21 @@
+package trucks
+
+import (
+	"context"
+
+	"gqlcodegen.test/trucks/scalar"
+)
+
+type garageResolver struct{}
+
+func (r *garageResolver) Id() scalar.Uint32 {
+	panic("not implemented")
+}
+
+func (r *garageResolver) Trucks(context.Context, GarageResolver_Trucks_Arg) []TruckResolver {
+	panic("not implemented")
+}
+
+func (r *garageResolver) Drivers(context.Context, GarageResolver_Drivers_Arg) []DriverResolver {
+	panic("not implemented")
+}
@@ -0,0 +1,56 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package trucks
+
+import (
+	"context"
+
+	"gqlcodegen.test/trucks/enum/class"
+	"gqlcodegen.test/trucks/scalar"
+)
+
+type GarageResolver interface {
+	Id() scalar.Uint32
+	Trucks(context.Context, GarageResolver_Trucks_Arg) []TruckResolver
+	Drivers(context.Context, GarageResolver_Drivers_Arg) []DriverResolver
+}
+
+type GarageResolver_Trucks_Arg struct {
+	// Default: 20
+	Size scalar.Uint32
+	// Default: null
+	Cursor *scalar.Cursor
+}
+
+// DefaultGarageResolver_Trucks_Arg returns GarageResolver_Trucks_Arg holding default values of the arguments.
+func DefaultGarageResolver_Trucks_Arg() GarageResolver_Trucks_Arg {
+	return GarageResolver_Trucks_Arg{
+		Size: scalar.Uint32(20),
+	}
+}
+
+type GarageResolver_Drivers_Arg struct {
+	// Default: 20
+	Size scalar.Uint32
+
+	/*
+	   Description:
+	     "driver class"
+	*/
+	// Default: [ELITE, KING_OF_ROAD]
+	Class []class.Class
+
+	/*
+	   Directives:
+	     @deprecated()
+	*/
+	// Default: null
+	Cursor *scalar.Cursor
+}
+
+// DefaultGarageResolver_Drivers_Arg returns GarageResolver_Drivers_Arg holding default values of the arguments.
+func DefaultGarageResolver_Drivers_Arg() GarageResolver_Drivers_Arg {
+	return GarageResolver_Drivers_Arg{
+		Size:  scalar.Uint32(20),
+		Class: []class.Class{class.ELITE, class.KING_OF_ROAD},
+	}
+}
@@ -0,0 +1,92 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package trucks
+
+import (
+	"context"
+	"sync"
+
+	"gqlcodegen.test/trucks/scalar"
+)
+
+var _ GarageResolver = &GarageResolverMock{}
+
+// GarageResolverMock is a mock implementation of GarageResolver.
+type GarageResolverMock struct {
+	IdFunc      func() scalar.Uint32
+	TrucksFunc  func(context.Context, GarageResolver_Trucks_Arg) []TruckResolver
+	DriversFunc func(context.Context, GarageResolver_Drivers_Arg) []DriverResolver
+
+	mu    sync.Mutex
+	calls struct {
+		Id      []GarageResolverMock_Id_Call
+		Trucks  []GarageResolverMock_Trucks_Call
+		Drivers []GarageResolverMock_Drivers_Call
+	}
+}
+
+// GarageResolverMock_Id_Call holds arguments of a call to Id.
+type GarageResolverMock_Id_Call struct {
+}
+
+func (m *GarageResolverMock) Id() scalar.Uint32 {
+	if m.IdFunc == nil {
+		panic("GarageResolverMock.IdFunc is nil but GarageResolver.Id was called")
+	}
+	m.mu.Lock()
+	m.calls.Id = append(m.calls.Id, GarageResolverMock_Id_Call{})
+	m.mu.Unlock()
+	return m.IdFunc()
+}
+
+// IdCalls returns arguments of calls to Id in order.
+func (m *GarageResolverMock) IdCalls() []GarageResolverMock_Id_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]GarageResolverMock_Id_Call(nil), m.calls.Id...)
+}
+
+// GarageResolverMock_Trucks_Call holds arguments of a call to Trucks.
+type GarageResolverMock_Trucks_Call struct {
+	Ctx context.Context
+	Arg GarageResolver_Trucks_Arg
+}
+
+func (m *GarageResolverMock) Trucks(ctx context.Context, arg GarageResolver_Trucks_Arg) []TruckResolver {
+	if m.TrucksFunc == nil {
+		panic("GarageResolverMock.TrucksFunc is nil but GarageResolver.Trucks was called")
+	}
+	m.mu.Lock()
+	m.calls.Trucks = append(m.calls.Trucks, GarageResolverMock_Trucks_Call{Ctx: ctx, Arg: arg})
+	m.mu.Unlock()
+	return m.TrucksFunc(ctx, arg)
+}
+
+// TrucksCalls returns arguments of calls to Trucks in order.
+func (m *GarageResolverMock) TrucksCalls() []GarageResolverMock_Trucks_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]GarageResolverMock_Trucks_Call(nil), m.calls.Trucks...)
+}
+
+// GarageResolverMock_Drivers_Call holds arguments of a call to Drivers.
+type GarageResolverMock_Drivers_Call struct {
+	Ctx context.Context
+	Arg GarageResolver_Drivers_Arg
+}
+
+func (m *GarageResolverMock) Drivers(ctx context.Context, arg GarageResolver_Drivers_Arg) []DriverResolver {
+	if m.DriversFunc == nil {
+		panic("GarageResolverMock.DriversFunc is nil but GarageResolver.Drivers was called")
+	}
+	m.mu.Lock()
+	m.calls.Drivers = append(m.calls.Drivers, GarageResolverMock_Drivers_Call{Ctx: ctx, Arg: arg})
+	m.mu.Unlock()
+	return m.DriversFunc(ctx, arg)
+}
+
+// DriversCalls returns arguments of calls to Drivers in order.
+func (m *GarageResolverMock) DriversCalls() []GarageResolverMock_Drivers_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]GarageResolverMock_Drivers_Call(nil), m.calls.Drivers...)
+}
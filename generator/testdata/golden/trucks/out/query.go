@@ -0,0 +1,15 @@
+package trucks
+
+import (
+	"context"
+)
+
+type queryResolver struct{}
+
+func (r *queryResolver) Truck(context.Context, QueryResolver_Truck_Arg) TruckResolver {
+	panic("not implemented")
+}
+
+func (r *queryResolver) Garage(context.Context, QueryResolver_Garage_Arg) GarageResolver {
+	panic("not implemented")
+}
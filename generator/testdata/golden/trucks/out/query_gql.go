@@ -0,0 +1,30 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package trucks
+
+import (
+	"context"
+
+	"gqlcodegen.test/trucks/scalar"
+)
+
+type QueryResolver interface {
+
+	// Return value of Truck is nullable
+	Truck(context.Context, QueryResolver_Truck_Arg) TruckResolver
+
+	/*
+	   Description:
+	     "Returns garage"
+	*/
+	// Return value of Garage is nullable
+	Garage(context.Context, QueryResolver_Garage_Arg) GarageResolver
+}
+
+type QueryResolver_Truck_Arg struct {
+	// Default: null
+	Number *scalar.RegistrationNumber
+}
+
+type QueryResolver_Garage_Arg struct {
+	Id scalar.Uint32
+}
@@ -0,0 +1,67 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package trucks
+
+import (
+	"context"
+	"sync"
+)
+
+var _ QueryResolver = &QueryResolverMock{}
+
+// QueryResolverMock is a mock implementation of QueryResolver.
+type QueryResolverMock struct {
+	TruckFunc  func(context.Context, QueryResolver_Truck_Arg) TruckResolver
+	GarageFunc func(context.Context, QueryResolver_Garage_Arg) GarageResolver
+
+	mu    sync.Mutex
+	calls struct {
+		Truck  []QueryResolverMock_Truck_Call
+		Garage []QueryResolverMock_Garage_Call
+	}
+}
+
+// QueryResolverMock_Truck_Call holds arguments of a call to Truck.
+type QueryResolverMock_Truck_Call struct {
+	Ctx context.Context
+	Arg QueryResolver_Truck_Arg
+}
+
+func (m *QueryResolverMock) Truck(ctx context.Context, arg QueryResolver_Truck_Arg) TruckResolver {
+	if m.TruckFunc == nil {
+		panic("QueryResolverMock.TruckFunc is nil but QueryResolver.Truck was called")
+	}
+	m.mu.Lock()
+	m.calls.Truck = append(m.calls.Truck, QueryResolverMock_Truck_Call{Ctx: ctx, Arg: arg})
+	m.mu.Unlock()
+	return m.TruckFunc(ctx, arg)
+}
+
+// TruckCalls returns arguments of calls to Truck in order.
+func (m *QueryResolverMock) TruckCalls() []QueryResolverMock_Truck_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]QueryResolverMock_Truck_Call(nil), m.calls.Truck...)
+}
+
+// QueryResolverMock_Garage_Call holds arguments of a call to Garage.
+type QueryResolverMock_Garage_Call struct {
+	Ctx context.Context
+	Arg QueryResolver_Garage_Arg
+}
+
+func (m *QueryResolverMock) Garage(ctx context.Context, arg QueryResolver_Garage_Arg) GarageResolver {
+	if m.GarageFunc == nil {
+		panic("QueryResolverMock.GarageFunc is nil but QueryResolver.Garage was called")
+	}
+	m.mu.Lock()
+	m.calls.Garage = append(m.calls.Garage, QueryResolverMock_Garage_Call{Ctx: ctx, Arg: arg})
+	m.mu.Unlock()
+	return m.GarageFunc(ctx, arg)
+}
+
+// GarageCalls returns arguments of calls to Garage in order.
+func (m *QueryResolverMock) GarageCalls() []QueryResolverMock_Garage_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]QueryResolverMock_Garage_Call(nil), m.calls.Garage...)
+}
@@ -0,0 +1,97 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package trucks
+
+import "github.com/graph-gophers/graphql-go"
+
+// Schema is the source of the schema.
+const Schema = `schema {
+    query: Query
+}
+
+"It is used as ID"
+scalar Uint32
+scalar RegistrationNumber @deprecated(reason: "Personal information")
+scalar Cursor
+
+type Query {
+    truck(number: RegistrationNumber = null): Truck
+
+    "Returns garage"
+    garage(id: Uint32!): Garage
+}
+
+type Garage {
+    id: Uint32!
+    trucks(size: Uint32! = 20, cursor: Cursor = null): [Truck!]!
+    drivers(
+      size: Uint32! = 20,
+
+      "driver class"
+      class: [Class!]! = [ELITE, KING_OF_ROAD],
+
+      cursor: Cursor = null @deprecated
+    ): [Driver!]!
+}
+
+"""
+This is truck
+"""
+type Truck implements Vehicle {
+    maker: Maker!
+    "Number"
+    number: RegistrationNumber!
+    capacity: Int!
+}
+
+extend type Truck {
+    enginePower: Int
+}
+
+type Driver {
+    licenceNumber: String # Some drivers do not have licence
+    name: String!
+    middleName: String
+    isOnDuty: Boolean!
+    class: Class!
+    rating: Float
+}
+
+interface Vehicle {
+    number: RegistrationNumber!
+}
+
+enum Maker {
+    "Scania is awesome"
+    SCANIA
+    DAF
+    VOLVO
+    ISUZU @deprecated(reason: "not an euro truck")
+}
+
+"""
+Driver class
+"""
+enum Class {
+    ROOKIE
+    ELITE
+    KING_OF_ROAD
+}
+
+extend enum Class {
+    LEGEND
+}
+
+directive @withContext on FIELD_DEFINITION
+directive @returnWithError on FIELD_DEFINITION
+directive @goScalarType(name: String!) on SCALAR
+`
+
+// RootResolver resolves root operation types of the schema.
+type RootResolver interface {
+	QueryResolver
+}
+
+// NewSchema parses Schema and binds root to it.
+func NewSchema(root RootResolver, opts ...graphql.SchemaOpt) (*graphql.Schema, error) {
+	return graphql.ParseSchema(Schema, root, opts...)
+}
@@ -0,0 +1,24 @@
+package trucks
+
+import (
+	"gqlcodegen.test/trucks/enum/maker"
+	"gqlcodegen.test/trucks/scalar"
+)
+
+type truckResolver struct{}
+
+func (r *truckResolver) Maker() maker.Maker {
+	panic("not implemented")
+}
+
+func (r *truckResolver) Number() scalar.RegistrationNumber {
+	panic("not implemented")
+}
+
+func (r *truckResolver) Capacity() int {
+	panic("not implemented")
+}
+
+func (r *truckResolver) EnginePower() *int {
+	panic("not implemented")
+}
@@ -0,0 +1,27 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package trucks
+
+import (
+	"gqlcodegen.test/trucks/enum/maker"
+	"gqlcodegen.test/trucks/scalar"
+)
+
+/*
+	Description:
+	  """
+	  This is truck
+	  """
+*/
+type TruckResolver interface {
+	Maker() maker.Maker
+
+	/*
+	   Description:
+	     "Number"
+	*/
+	Number() scalar.RegistrationNumber
+	Capacity() int
+
+	// Return value of EnginePower is nullable
+	EnginePower() *int
+}
@@ -0,0 +1,111 @@
+// DO NOT EDIT. this file is generated by gqlcodegen.
+package trucks
+
+import (
+	"sync"
+
+	"gqlcodegen.test/trucks/enum/maker"
+	"gqlcodegen.test/trucks/scalar"
+)
+
+var _ TruckResolver = &TruckResolverMock{}
+
+// TruckResolverMock is a mock implementation of TruckResolver.
+type TruckResolverMock struct {
+	MakerFunc       func() maker.Maker
+	NumberFunc      func() scalar.RegistrationNumber
+	CapacityFunc    func() int
+	EnginePowerFunc func() *int
+
+	mu    sync.Mutex
+	calls struct {
+		Maker       []TruckResolverMock_Maker_Call
+		Number      []TruckResolverMock_Number_Call
+		Capacity    []TruckResolverMock_Capacity_Call
+		EnginePower []TruckResolverMock_EnginePower_Call
+	}
+}
+
+// TruckResolverMock_Maker_Call holds arguments of a call to Maker.
+type TruckResolverMock_Maker_Call struct {
+}
+
+func (m *TruckResolverMock) Maker() maker.Maker {
+	if m.MakerFunc == nil {
+		panic("TruckResolverMock.MakerFunc is nil but TruckResolver.Maker was called")
+	}
+	m.mu.Lock()
+	m.calls.Maker = append(m.calls.Maker, TruckResolverMock_Maker_Call{})
+	m.mu.Unlock()
+	return m.MakerFunc()
+}
+
+// MakerCalls returns arguments of calls to Maker in order.
+func (m *TruckResolverMock) MakerCalls() []TruckResolverMock_Maker_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]TruckResolverMock_Maker_Call(nil), m.calls.Maker...)
+}
+
+// TruckResolverMock_Number_Call holds arguments of a call to Number.
+type TruckResolverMock_Number_Call struct {
+}
+
+func (m *TruckResolverMock) Number() scalar.RegistrationNumber {
+	if m.NumberFunc == nil {
+		panic("TruckResolverMock.NumberFunc is nil but TruckResolver.Number was called")
+	}
+	m.mu.Lock()
+	m.calls.Number = append(m.calls.Number, TruckResolverMock_Number_Call{})
+	m.mu.Unlock()
+	return m.NumberFunc()
+}
+
+// NumberCalls returns arguments of calls to Number in order.
+func (m *TruckResolverMock) NumberCalls() []TruckResolverMock_Number_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]TruckResolverMock_Number_Call(nil), m.calls.Number...)
+}
+
+// TruckResolverMock_Capacity_Call holds arguments of a call to Capacity.
+type TruckResolverMock_Capacity_Call struct {
+}
+
+func (m *TruckResolverMock) Capacity() int {
+	if m.CapacityFunc == nil {
+		panic("TruckResolverMock.CapacityFunc is nil but TruckResolver.Capacity was called")
+	}
+	m.mu.Lock()
+	m.calls.Capacity = append(m.calls.Capacity, TruckResolverMock_Capacity_Call{})
+	m.mu.Unlock()
+	return m.CapacityFunc()
+}
+
+// CapacityCalls returns arguments of calls to Capacity in order.
+func (m *TruckResolverMock) CapacityCalls() []TruckResolverMock_Capacity_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]TruckResolverMock_Capacity_Call(nil), m.calls.Capacity...)
+}
+
+// TruckResolverMock_EnginePower_Call holds arguments of a call to EnginePower.
+type TruckResolverMock_EnginePower_Call struct {
+}
+
+func (m *TruckResolverMock) EnginePower() *int {
+	if m.EnginePowerFunc == nil {
+		panic("TruckResolverMock.EnginePowerFunc is nil but TruckResolver.EnginePower was called")
+	}
+	m.mu.Lock()
+	m.calls.EnginePower = append(m.calls.EnginePower, TruckResolverMock_EnginePower_Call{})
+	m.mu.Unlock()
+	return m.EnginePowerFunc()
+}
+
+// EnginePowerCalls returns arguments of calls to EnginePower in order.
+func (m *TruckResolverMock) EnginePowerCalls() []TruckResolverMock_EnginePower_Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]TruckResolverMock_EnginePower_Call(nil), m.calls.EnginePower...)
+}
@@ -0,0 +1,5 @@
+package scalar
+
+type Uint32 uint32
+type RegistrationNumber string
+type Cursor string
@@ -0,0 +1,11 @@
+// Package graphql stubs the API of github.com/graph-gophers/graphql-go which
+// the root target uses.
+package graphql
+
+type Schema struct{}
+
+type SchemaOpt func(*Schema)
+
+func ParseSchema(schemaString string, resolver interface{}, opts ...SchemaOpt) (*Schema, error) {
+	return &Schema{}, nil
+}
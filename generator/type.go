@@ -1,8 +1,6 @@
 package generator
 
 import (
-	"fmt"
-	"log"
 	"path"
 	"strings"
 
@@ -27,6 +25,10 @@ func generateArgStructs(g *Generator, def *gql.Object) {
 			generateArgStruct(g, def, f)
 			g.Println()
 		}
+		if hasDefaultValue(f.Args) {
+			generateDefaultFunc(g, def, f)
+			g.Println()
+		}
 	}
 }
 
@@ -34,6 +36,7 @@ func generateArgStruct(g *Generator, t *gql.Object, f *gql.ObjectField) {
 	g.Printf("type %s struct {\n", argStructName(f, t))
 	for _, a := range f.Args {
 		generateComment(g, a)
+		generateDefaultComment(g, a)
 		g.Printf("%s %s\n", capitalizeFirst(a.Name), refToString(g, a.Type))
 	}
 	g.Println("}")
@@ -68,25 +71,29 @@ func generateField(g *Generator, f *gql.ObjectField, t *gql.Object) {
 		generateComment(g, f)
 	}
 
-	g.Printf("%s(", name)
-	argsStr := []string{}
-	if hasDirective(f, "withContext") || len(f.Args) > 0 {
-		argsStr = append(argsStr, "context.Context")
+	g.Printf("%s(%s) %s\n", name, strings.Join(fieldParams(f, t), ","), fieldResult(g, f))
+}
+
+func needsContext(f *gql.ObjectField) bool {
+	return hasDirective(f, "withContext") || len(f.Args) > 0
+}
+
+func fieldParams(f *gql.ObjectField, t *gql.Object) []string {
+	params := []string{}
+	if needsContext(f) {
+		params = append(params, "context.Context")
 	}
 	if len(f.Args) > 0 {
-		argsStr = append(argsStr, argStructName(f, t))
+		params = append(params, argStructName(f, t))
 	}
-	g.Printf(strings.Join(argsStr, ","))
-	g.Printf(") ")
-
-	hasReturnWithError := hasDirective(f, "returnWithError")
-
-	fmt := "%s\n"
-	if hasReturnWithError {
-		fmt = "(%s, error)\n"
+	return params
+}
 
+func fieldResult(g *Generator, f *gql.ObjectField) string {
+	if hasDirective(f, "returnWithError") {
+		return "(" + refToString(g, f.Type) + ", error)"
 	}
-	g.Printf(fmt, refToString(g, f.Type))
+	return refToString(g, f.Type)
 }
 
 func argStructName(f *gql.ObjectField, t *gql.Object) string {
@@ -99,17 +106,17 @@ func convertResolverName(name string) string {
 
 func refToString(g *Generator, ref *gql.TypeRef) string {
 	n := ref.Name
-	if _, ok := g.Config().TypeSystem.ObjectTypes[n]; ok {
+	if _, ok := g.TypeSystem().ObjectTypes[n]; ok {
 		return convertResolverName(ref.Name)
 	}
-	if _, ok := g.Config().TypeSystem.EnumTypes[n]; ok {
+	if _, ok := g.TypeSystem().EnumTypes[n]; ok {
 		n = strings.ToLower(n) + "." + capitalizeFirst(n)
 		if ref.IsNullable {
 			n = "*" + n
 		}
 		return n
 	}
-	if scalar, ok := g.Config().TypeSystem.ScalarTypes[n]; ok {
+	if scalar, ok := g.TypeSystem().ScalarTypes[n]; ok {
 		for _, d := range scalar.Directives {
 			if d.Name == "goScalarType" {
 				n = strings.Trim(d.Args["name"].Value(), `"`)
@@ -135,7 +142,7 @@ func refToString(g *Generator, ref *gql.TypeRef) string {
 	case "[]":
 		n = "[]" + refToString(g, ref.InnerType)
 	default:
-		log.Fatalf("unknown type %s", n)
+		fatalf("unknown type %s", n)
 	}
 	if ref.IsNullable {
 		n = "*" + n
@@ -159,14 +166,7 @@ func findTypeName(ref []*gql.TypeRef) []string {
 	return ret
 }
 
-func typeNameMap(def *gql.Object) map[string]struct{} {
-	var refs []*gql.TypeRef
-	for _, f := range def.Fields {
-		refs = append(refs, f.Type)
-		for _, a := range f.Args {
-			refs = append(refs, a.Type)
-		}
-	}
+func typeNameMap(refs []*gql.TypeRef) map[string]struct{} {
 	types := map[string]struct{}{}
 	for _, t := range findTypeName(refs) {
 		types[t] = struct{}{}
@@ -175,45 +175,55 @@ func typeNameMap(def *gql.Object) map[string]struct{} {
 }
 
 func generateImportSection(g *Generator, def *gql.Object) {
-	typesMap := typeNameMap(def)
+	var refs []*gql.TypeRef
 	needContext := false
 	for _, f := range def.Fields {
-		if len(f.Args) > 0 || hasDirective(f, "withContext") {
-			needContext = true
-			break
+		refs = append(refs, f.Type)
+		for _, a := range f.Args {
+			refs = append(refs, a.Type)
 		}
+		needContext = needContext || needsContext(f)
 	}
-	var imported []string
+	generateImports(g, refs, needContext)
+}
+
+func generateImports(g *Generator, refs []*gql.TypeRef, needContext bool) {
+	imported := importPaths(g, refs)
 	if needContext {
-		imported = append(imported, "\"context\"")
+		imported = append([]string{"context"}, imported...)
+	}
+
+	if len(imported) > 0 {
+		g.Println("import(")
+		for _, p := range imported {
+			g.Printf("%q\n", p)
+			if p == "context" {
+				g.Println()
+			}
+		}
+		g.Println(")")
 	}
-	for k := range g.Config().TypeSystem.EnumTypes {
+}
+
+func importPaths(g *Generator, refs []*gql.TypeRef) []string {
+	typesMap := typeNameMap(refs)
+	var imported []string
+	for k := range g.TypeSystem().EnumTypes {
 		if _, ok := typesMap[k]; ok {
 			imported = append(
 				imported,
-				fmt.Sprintf("\"%s\"", path.Join(g.Config().EnumPackagePrefix, strings.ToLower(k))),
+				path.Join(g.Config().EnumPackagePrefix, strings.ToLower(k)),
 			)
 		}
 	}
-	for k := range g.Config().TypeSystem.ScalarTypes {
+	for k := range g.TypeSystem().ScalarTypes {
 		if _, ok := typesMap[k]; ok {
 			imported = append(
 				imported,
-				fmt.Sprintf("\"%s\"", strings.Trim(g.Config().ScalarPackage, "/")),
+				strings.Trim(g.Config().ScalarPackage, "/"),
 			)
 			break
 		}
 	}
-
-	if len(imported) > 0 {
-		g.Println("import(")
-		for _, p := range imported {
-			g.Println(p)
-			if p == "\"context\"" {
-				g.Println()
-			}
-		}
-		g.Println(")")
-	}
-
+	return imported
 }
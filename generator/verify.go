@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// Problem is an inconsistency between the schema and an implementation of a
+// resolver.
+type Problem struct {
+	Position gql.Position
+	Message  string
+}
+
+func (p *Problem) String() string {
+	return p.Position.String() + ": " + p.Message
+}
+
+// Verify checks that the types in pkg implementing resolvers have the methods
+// required by the schema.
+// bindings maps names of resolver interfaces to names of types implementing
+// them. Resolvers not in bindings are bound to the type named as the
+// scaffold target does, and are skipped if there is no such type.
+func (g *Generator) Verify(pkg *types.Package, bindings map[string]string) []*Problem {
+	var names []string
+	for n := range g.TypeSystem().ObjectTypes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var problems []*Problem
+	for _, n := range names {
+		def := g.TypeSystem().ObjectTypes[n]
+		impl, bound := bindings[convertResolverName(def.Name)]
+		if !bound {
+			impl = ScaffoldTypeName(def)
+		}
+		obj, ok := pkg.Scope().Lookup(impl).(*types.TypeName)
+		if !ok {
+			if bound {
+				problems = append(problems, &Problem{
+					Position: def.Position,
+					Message: fmt.Sprintf(
+						"type %s bound to %s is not found in %s",
+						impl, convertResolverName(def.Name), pkg.Path(),
+					),
+				})
+			}
+			continue
+		}
+		problems = append(problems, verifyType(g, pkg, def, obj)...)
+	}
+	return problems
+}
+
+func verifyType(g *Generator, pkg *types.Package, def *gql.Object, obj *types.TypeName) []*Problem {
+	methods := types.NewMethodSet(types.NewPointer(obj.Type()))
+	qualifier := func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		return p.Name()
+	}
+
+	var problems []*Problem
+	for _, f := range def.Fields {
+		name := capitalizeFirst(f.Name)
+		want := name + "(" + strings.Join(fieldParams(f, def), ", ") + ") " + fieldResult(g, f)
+		sel := methods.Lookup(pkg, name)
+		if sel == nil {
+			problems = append(problems, &Problem{
+				Position: f.Position,
+				Message: fmt.Sprintf(
+					"%s.%s: method %s of %s is missing, want %s",
+					def.Name, f.Name, name, obj.Name(), want,
+				),
+			})
+			continue
+		}
+		sig := sel.Obj().Type().(*types.Signature)
+		if got := name + signatureString(sig, qualifier); got != want {
+			problems = append(problems, &Problem{
+				Position: f.Position,
+				Message: fmt.Sprintf(
+					"%s.%s: method %s of %s is %s, want %s",
+					def.Name, f.Name, name, obj.Name(), got, want,
+				),
+			})
+		}
+	}
+	return problems
+}
+
+// signatureString formats sig in the same form as methods of generated
+// resolver interfaces.
+func signatureString(sig *types.Signature, q types.Qualifier) string {
+	var params []string
+	for i := 0; i < sig.Params().Len(); i++ {
+		t := sig.Params().At(i).Type()
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			params = append(params, "..."+types.TypeString(t.(*types.Slice).Elem(), q))
+			continue
+		}
+		params = append(params, types.TypeString(t, q))
+	}
+	var results []string
+	for i := 0; i < sig.Results().Len(); i++ {
+		results = append(results, types.TypeString(sig.Results().At(i).Type(), q))
+	}
+	ret := "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+		return ret
+	case 1:
+		return ret + " " + results[0]
+	}
+	return ret + " (" + strings.Join(results, ", ") + ")"
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+	"testing"
+
+	gqlparser "github.com/RettyEng/gqlcodegen/parser"
+)
+
+const verifySchema = `type Query {
+  hello(name: String = "world"): String!
+  count: Int
+}
+
+type Mutation {
+  reset: Boolean!
+}
+`
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		bindings map[string]string
+		want     []string
+	}{
+		{
+			name: "matching",
+			src: `
+type queryResolver struct{}
+
+func (r *queryResolver) Hello(context.Context, QueryResolver_Hello_Arg) string { return "" }
+func (r *queryResolver) Count() *int { return nil }
+`,
+		},
+		{
+			name: "missing method",
+			src: `
+type queryResolver struct{}
+
+func (r *queryResolver) Hello(context.Context, QueryResolver_Hello_Arg) string { return "" }
+`,
+			want: []string{
+				"schema.graphqls:3:3: Query.count: method Count of queryResolver is missing, want Count() *int",
+			},
+		},
+		{
+			name: "wrong signature",
+			src: `
+type queryResolver struct{}
+
+func (r *queryResolver) Hello(QueryResolver_Hello_Arg) *string { return nil }
+func (r queryResolver) Count() int { return 0 }
+`,
+			want: []string{
+				"schema.graphqls:2:3: Query.hello: method Hello of queryResolver is Hello(QueryResolver_Hello_Arg) *string, want Hello(context.Context, QueryResolver_Hello_Arg) string",
+				"schema.graphqls:3:3: Query.count: method Count of queryResolver is Count() int, want Count() *int",
+			},
+		},
+		{
+			name: "binding",
+			src: `
+type root struct{}
+
+func (root) Reset() bool { return false }
+`,
+			bindings: map[string]string{"MutationResolver": "root"},
+		},
+		{
+			name:     "binding to unknown type",
+			src:      ``,
+			bindings: map[string]string{"MutationResolver": "root"},
+			want: []string{
+				"schema.graphqls:6:6: type root bound to MutationResolver is not found in verify",
+			},
+		},
+	}
+
+	ts := gqlparser.NewFileParser("schema.graphqls", strings.NewReader(verifySchema)).ParseAndEvalSchema()
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator(ts, &Config{Package: &Package{Name: "verify"}})
+			pkg := checkVerifyPackage(t, tt.src)
+			var got []string
+			for _, p := range g.Verify(pkg, tt.bindings) {
+				got = append(got, p.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// checkVerifyPackage typechecks src as a package next to the resolver
+// interfaces of verifySchema. Only the argument struct is declared since
+// Verify does not look at the interfaces.
+func checkVerifyPackage(t *testing.T, src string) *types.Package {
+	fset := token.NewFileSet()
+	f, e := parser.ParseFile(fset, "impl.go", `package verify
+
+import "context"
+
+var _ context.Context
+
+type QueryResolver_Hello_Arg struct {
+	Name *string
+}
+`+src, 0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	conf := &types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, e := conf.Check("verify", fset, []*ast.File{f}, nil)
+	if e != nil {
+		t.Fatal(e)
+	}
+	return pkg
+}
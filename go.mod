@@ -1 +1,5 @@
 module github.com/RettyEng/gqlcodegen
+
+go 1.25.0
+
+require github.com/graph-gophers/graphql-go v1.10.3
@@ -0,0 +1,19 @@
+package gql
+
+// DefaultDeprecationReason is the reason of @deprecated without one.
+const DefaultDeprecationReason = "No longer supported"
+
+// Deprecation returns whether @deprecated is in dirs and the value of its
+// reason, which is DefaultDeprecationReason if it is omitted or null.
+func Deprecation(dirs []*DirectiveRef) (bool, string) {
+	for _, d := range dirs {
+		if d.Name != "deprecated" {
+			continue
+		}
+		if r, ok := d.Args["reason"]; ok && r.Value() != "null" {
+			return true, StringValue(r.Value())
+		}
+		return true, DefaultDeprecationReason
+	}
+	return false, ""
+}
@@ -1,6 +1,7 @@
 package gql
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/RettyEng/gqlcodegen/ast/directive"
@@ -30,6 +31,104 @@ func NewTypeSystem() *TypeSystem {
 	}
 }
 
+// Merge adds the definitions of other to t. Definitions of other replace
+// those of t with the same names, and root operation types of other are
+// used where t does not have them.
+func (t *TypeSystem) Merge(other *TypeSystem) {
+	for n, d := range other.ScalarTypes {
+		t.ScalarTypes[n] = d
+	}
+	for n, d := range other.ObjectTypes {
+		t.ObjectTypes[n] = d
+	}
+	for n, d := range other.InterfaceTypes {
+		t.InterfaceTypes[n] = d
+	}
+	for n, d := range other.UnionTypes {
+		t.UnionTypes[n] = d
+	}
+	for n, d := range other.EnumTypes {
+		t.EnumTypes[n] = d
+	}
+	for n, d := range other.InputObjectTypes {
+		t.InputObjectTypes[n] = d
+	}
+	for n, d := range other.Directives {
+		t.Directives[n] = d
+	}
+	t.Schema.Directives = append(t.Schema.Directives, other.Schema.Directives...)
+	if t.Schema.Query == nil {
+		t.Schema.Query = other.Schema.Query
+	}
+	if t.Schema.Mutation == nil {
+		t.Schema.Mutation = other.Schema.Mutation
+	}
+	if t.Schema.Subscription == nil {
+		t.Schema.Subscription = other.Schema.Subscription
+	}
+}
+
+// Add adds the definitions of other to t like Merge, but returns an error
+// without changing t if a type, a directive or a root operation type of
+// other is already defined differently in t.
+func (t *TypeSystem) Add(other *TypeSystem) error {
+	defined := t.typePositions()
+	added := other.typePositions()
+	for _, n := range SortedKeys(added) {
+		if p, ok := defined[n]; ok {
+			return fmt.Errorf("%s: type %s is already defined at %s", added[n], n, p)
+		}
+	}
+	for _, n := range SortedKeys(other.Directives) {
+		if d, ok := t.Directives[n]; ok {
+			return fmt.Errorf(
+				"%s: directive @%s is already defined at %s", other.Directives[n].Position, n, d.Position,
+			)
+		}
+	}
+	roots := []struct {
+		op       string
+		cur, new *TypeRef
+	}{
+		{"query", t.Schema.Query, other.Schema.Query},
+		{"mutation", t.Schema.Mutation, other.Schema.Mutation},
+		{"subscription", t.Schema.Subscription, other.Schema.Subscription},
+	}
+	for _, r := range roots {
+		if r.cur != nil && r.new != nil && r.cur.Name != r.new.Name {
+			return fmt.Errorf(
+				"%s: %s root type %s conflicts with %s at %s", r.new.Position, r.op, r.new.Name, r.cur.Name, r.cur.Position,
+			)
+		}
+	}
+	t.Merge(other)
+	return nil
+}
+
+// typePositions returns the positions of the types of t by their names.
+func (t *TypeSystem) typePositions() map[string]Position {
+	pos := map[string]Position{}
+	for n, d := range t.ScalarTypes {
+		pos[n] = d.Position
+	}
+	for n, d := range t.ObjectTypes {
+		pos[n] = d.Position
+	}
+	for n, d := range t.InterfaceTypes {
+		pos[n] = d.Position
+	}
+	for n, d := range t.UnionTypes {
+		pos[n] = d.Position
+	}
+	for n, d := range t.EnumTypes {
+		pos[n] = d.Position
+	}
+	for n, d := range t.InputObjectTypes {
+		pos[n] = d.Position
+	}
+	return pos
+}
+
 type Commentable interface {
 	GetDescription() string
 	GetDirectives() []*DirectiveRef
@@ -45,6 +144,7 @@ type Schema struct {
 type Scalar struct {
 	Description string
 	Name        string
+	Position    Position
 	Directives  []*DirectiveRef
 }
 
@@ -58,6 +158,7 @@ func (s *Scalar) GetDirectives() []*DirectiveRef {
 type Object struct {
 	Description string
 	Name        string
+	Position    Position
 	Implements  []*TypeRef
 	Directives  []*DirectiveRef
 	Fields      []*ObjectField
@@ -73,6 +174,7 @@ func (s *Object) GetDirectives() []*DirectiveRef {
 type Interface struct {
 	Description string
 	Name        string
+	Position    Position
 	Directives  []*DirectiveRef
 	Fields      []*ObjectField
 }
@@ -87,6 +189,7 @@ func (s *Interface) GetDirectives() []*DirectiveRef {
 type Union struct {
 	Description string
 	Name        string
+	Position    Position
 	Directives  []*DirectiveRef
 	Members     []*TypeRef
 }
@@ -101,6 +204,7 @@ func (s *Union) GetDirectives() []*DirectiveRef {
 type Enum struct {
 	Description string
 	Name        string
+	Position    Position
 	Directives  []*DirectiveRef
 	Values      []*EnumValue
 }
@@ -115,6 +219,7 @@ func (s *Enum) GetDirectives() []*DirectiveRef {
 type Directive struct {
 	Description string
 	Name        string
+	Position    Position
 	Arguments   []*InputValue
 	Location    []directive.Location
 }
@@ -122,6 +227,7 @@ type Directive struct {
 type EnumValue struct {
 	Description string
 	Name        string
+	Position    Position
 	Directives  []*DirectiveRef
 }
 
@@ -134,6 +240,7 @@ func (s *EnumValue) GetDirectives() []*DirectiveRef {
 
 type ObjectField struct {
 	Name        string
+	Position    Position
 	Type        *TypeRef
 	Description string
 	Directives  []*DirectiveRef
@@ -148,14 +255,40 @@ func (s *ObjectField) GetDirectives() []*DirectiveRef {
 }
 
 type DirectiveRef struct {
-	Name string
-	Args map[string]Value
+	Name     string
+	Position Position
+	Args     map[string]Value
+	// ArgNames holds names of Args in the order of appearance.
+	ArgNames []string
 }
 
 type TypeRef struct {
 	InnerType  *TypeRef
 	Name       string
 	IsNullable bool
+	Position   Position
+}
+
+func (t *TypeRef) String() string {
+	if t.Name == "[]" {
+		return "[" + t.InnerType.String() + "]" + nonNullMark(t)
+	}
+	return t.Name + nonNullMark(t)
+}
+
+// NamedType returns the name of the type t wraps in lists.
+func (t *TypeRef) NamedType() string {
+	for t.Name == "[]" {
+		t = t.InnerType
+	}
+	return t.Name
+}
+
+func nonNullMark(t *TypeRef) string {
+	if t.IsNullable {
+		return ""
+	}
+	return "!"
 }
 
 type Value interface {
@@ -188,6 +321,7 @@ func (l *List) Value() string {
 type InputObject struct {
 	Description string
 	Name        string
+	Position    Position
 	Directives  []*DirectiveRef
 	InputValue  []*InputValue
 }
@@ -202,6 +336,7 @@ func (s *InputObject) GetDirectives() []*DirectiveRef {
 type InputValue struct {
 	Description string
 	Name        string
+	Position    Position
 	Directives  []*DirectiveRef
 	Type        *TypeRef
 	Default     Value
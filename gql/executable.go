@@ -0,0 +1,139 @@
+package gql
+
+import "strings"
+
+// ExecutableDocument holds operations and fragments requested by clients in
+// the order of appearance.
+type ExecutableDocument struct {
+	Operations []*Operation
+	Fragments  []*Fragment
+}
+
+// Fragment returns the fragment named name, or nil if it is not defined.
+func (d *ExecutableDocument) Fragment(name string) *Fragment {
+	for _, f := range d.Fragments {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+type OperationType string
+
+const (
+	OperationQuery        OperationType = "query"
+	OperationMutation     OperationType = "mutation"
+	OperationSubscription OperationType = "subscription"
+)
+
+// Operation is a query, a mutation or a subscription. Name is empty for an
+// anonymous operation.
+type Operation struct {
+	Type         OperationType
+	Name         string
+	Position     Position
+	Variables    []*VariableDefinition
+	Directives   []*DirectiveRef
+	SelectionSet []Selection
+}
+
+type VariableDefinition struct {
+	Name       string
+	Position   Position
+	Type       *TypeRef
+	Default    Value
+	Directives []*DirectiveRef
+}
+
+type Fragment struct {
+	Name          string
+	Position      Position
+	TypeCondition *TypeRef
+	Directives    []*DirectiveRef
+	SelectionSet  []Selection
+}
+
+// Selection is *Field, *FragmentSpread or *InlineFragment.
+type Selection interface {
+	GetDirectives() []*DirectiveRef
+	GetPosition() Position
+}
+
+// Field is a selected field. Alias is empty if the field is not aliased.
+type Field struct {
+	Alias        string
+	Name         string
+	Position     Position
+	Args         map[string]Value
+	ArgNames     []string
+	Directives   []*DirectiveRef
+	SelectionSet []Selection
+}
+
+// ResponseKey returns the key of the field in the response.
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+func (f *Field) GetDirectives() []*DirectiveRef {
+	return f.Directives
+}
+func (f *Field) GetPosition() Position {
+	return f.Position
+}
+
+type FragmentSpread struct {
+	Name       string
+	Position   Position
+	Directives []*DirectiveRef
+}
+
+func (s *FragmentSpread) GetDirectives() []*DirectiveRef {
+	return s.Directives
+}
+func (s *FragmentSpread) GetPosition() Position {
+	return s.Position
+}
+
+// InlineFragment is an inline fragment. TypeCondition is nil if omitted.
+type InlineFragment struct {
+	TypeCondition *TypeRef
+	Position      Position
+	Directives    []*DirectiveRef
+	SelectionSet  []Selection
+}
+
+func (f *InlineFragment) GetDirectives() []*DirectiveRef {
+	return f.Directives
+}
+func (f *InlineFragment) GetPosition() Position {
+	return f.Position
+}
+
+// Variable is a reference to a variable used as a value.
+type Variable struct {
+	Name string
+}
+
+func (v *Variable) Value() string {
+	return "$" + v.Name
+}
+
+// ObjectValue is an input object value. FieldNames holds names of the fields
+// in order.
+type ObjectValue struct {
+	Fields     map[string]Value
+	FieldNames []string
+}
+
+func (o *ObjectValue) Value() string {
+	var fields []string
+	for _, n := range o.FieldNames {
+		fields = append(fields, n+": "+o.Fields[n].Value())
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
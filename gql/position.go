@@ -0,0 +1,22 @@
+package gql
+
+import "fmt"
+
+// Position is a location in a schema source.
+type Position struct {
+	Filename string
+	Line     int
+	Col      int
+}
+
+// String returns filename:line:col. Line and col are omitted if the line is
+// unknown, such as for definitions loaded from introspection results.
+func (p Position) String() string {
+	if p.Line == 0 {
+		return p.Filename
+	}
+	if p.Filename == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Col)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Col)
+}
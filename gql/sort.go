@@ -0,0 +1,16 @@
+package gql
+
+import (
+	"reflect"
+	"sort"
+)
+
+// SortedKeys returns the keys of m, which is a map keyed by string, in order.
+func SortedKeys(m interface{}) []string {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
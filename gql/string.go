@@ -0,0 +1,105 @@
+package gql
+
+import (
+	"strconv"
+	"strings"
+)
+
+// StringValue returns the value of the string literal raw, which is a string
+// or a block string as it appears in the source. Descriptions are kept in
+// this form.
+func StringValue(raw string) string {
+	if strings.HasPrefix(raw, `"""`) && strings.HasSuffix(raw, `"""`) && len(raw) >= 6 {
+		return blockStringValue(raw[3 : len(raw)-3])
+	}
+	if strings.HasPrefix(raw, `"`) && strings.HasSuffix(raw, `"`) && len(raw) >= 2 {
+		return unescape(raw[1 : len(raw)-1])
+	}
+	return raw
+}
+
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if i+5 > len(s) {
+				b.WriteString(`\u`)
+				continue
+			}
+			r, e := strconv.ParseUint(s[i+1:i+5], 16, 32)
+			if e != nil {
+				b.WriteString(`\u`)
+				continue
+			}
+			b.WriteRune(rune(r))
+			i += 4
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// blockStringValue implements BlockStringValue of the specification.
+func blockStringValue(raw string) string {
+	raw = strings.Replace(raw, `\"""`, `"""`, -1)
+	lines := splitLines(raw)
+
+	common := -1
+	for _, l := range lines[1:] {
+		indent := leadingWhiteSpace(l)
+		if indent == len(l) {
+			continue
+		}
+		if common == -1 || indent < common {
+			common = indent
+		}
+	}
+	if common > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) < common {
+				lines[i] = ""
+				continue
+			}
+			lines[i] = lines[i][common:]
+		}
+	}
+
+	for len(lines) > 0 && leadingWhiteSpace(lines[0]) == len(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && leadingWhiteSpace(lines[len(lines)-1]) == len(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func splitLines(s string) []string {
+	s = strings.Replace(s, "\r\n", "\n", -1)
+	s = strings.Replace(s, "\r", "\n", -1)
+	return strings.Split(s, "\n")
+}
+
+func leadingWhiteSpace(s string) int {
+	i := 0
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,152 @@
+// Package graph renders the types of a schema and their relations as a
+// diagram in DOT of Graphviz or Mermaid.
+package graph
+
+import (
+	"sort"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// Kind is the kind of a node or an edge.
+type Kind string
+
+const (
+	Object    Kind = "object"
+	Interface Kind = "interface"
+	Union     Kind = "union"
+	Input     Kind = "input"
+
+	// Field is an edge from a type to the type of its field.
+	Field Kind = "field"
+	// Implements is an edge from an object to an interface it implements.
+	Implements Kind = "implements"
+	// Member is an edge from a union to its member.
+	Member Kind = "member"
+)
+
+type Node struct {
+	Name string
+	Kind Kind
+}
+
+type Edge struct {
+	From, To string
+	Kind     Kind
+	// Label is the field name and the type of the field for field edges,
+	// and the kind otherwise.
+	Label string
+}
+
+// Graph is the types of a schema as nodes, with edges to the types they
+// refer to. Scalars and enums are not nodes.
+type Graph struct {
+	Nodes []*Node
+	Edges []*Edge
+}
+
+// Build returns the graph of ts. If root is not empty, only the types
+// reachable from root in depth edges are included, where depth 0 means no
+// limit.
+func Build(ts *gql.TypeSystem, root string, depth int) *Graph {
+	all := build(ts)
+	if root == "" {
+		return all
+	}
+
+	out := map[string][]*Edge{}
+	for _, e := range all.Edges {
+		out[e.From] = append(out[e.From], e)
+	}
+	reached := map[string]bool{root: true}
+	frontier := []string{root}
+	for d := 0; len(frontier) > 0 && (depth == 0 || d < depth); d++ {
+		var next []string
+		for _, n := range frontier {
+			for _, e := range out[n] {
+				if !reached[e.To] {
+					reached[e.To] = true
+					next = append(next, e.To)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	g := &Graph{}
+	for _, n := range all.Nodes {
+		if reached[n.Name] {
+			g.Nodes = append(g.Nodes, n)
+		}
+	}
+	for _, e := range all.Edges {
+		if reached[e.From] && reached[e.To] {
+			g.Edges = append(g.Edges, e)
+		}
+	}
+	return g
+}
+
+func build(ts *gql.TypeSystem) *Graph {
+	kinds := map[string]Kind{}
+	for n := range ts.ObjectTypes {
+		kinds[n] = Object
+	}
+	for n := range ts.InterfaceTypes {
+		kinds[n] = Interface
+	}
+	for n := range ts.UnionTypes {
+		kinds[n] = Union
+	}
+	for n := range ts.InputObjectTypes {
+		kinds[n] = Input
+	}
+	var names []string
+	for n := range kinds {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	g := &Graph{}
+	field := func(from, name string, typ *gql.TypeRef) {
+		to := typ.NamedType()
+		if _, ok := kinds[to]; ok {
+			g.Edges = append(g.Edges, &Edge{
+				From:  from,
+				To:    to,
+				Kind:  Field,
+				Label: name + ": " + typ.String(),
+			})
+		}
+	}
+	for _, n := range names {
+		g.Nodes = append(g.Nodes, &Node{Name: n, Kind: kinds[n]})
+		switch kinds[n] {
+		case Object:
+			o := ts.ObjectTypes[n]
+			for _, i := range o.Implements {
+				if kinds[i.Name] == Interface {
+					g.Edges = append(g.Edges, &Edge{From: n, To: i.Name, Kind: Implements, Label: string(Implements)})
+				}
+			}
+			for _, f := range o.Fields {
+				field(n, f.Name, f.Type)
+			}
+		case Interface:
+			for _, f := range ts.InterfaceTypes[n].Fields {
+				field(n, f.Name, f.Type)
+			}
+		case Union:
+			for _, m := range ts.UnionTypes[n].Members {
+				if kinds[m.Name] == Object {
+					g.Edges = append(g.Edges, &Edge{From: n, To: m.Name, Kind: Member, Label: string(Member)})
+				}
+			}
+		case Input:
+			for _, v := range ts.InputObjectTypes[n].InputValue {
+				field(n, v.Name, v.Type)
+			}
+		}
+	}
+	return g
+}
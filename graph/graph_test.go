@@ -0,0 +1,179 @@
+package graph
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/parser"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the generated ones")
+
+func parse(t *testing.T, src string) *gql.TypeSystem {
+	t.Helper()
+	top, e := parser.NewParser(strings.NewReader(src)).Parse()
+	if e != nil {
+		t.Fatal(e)
+	}
+	ts, e := top.Eval()
+	if e != nil {
+		t.Fatal(e)
+	}
+	return ts
+}
+
+// TestGolden compares diagrams of the example schema with the golden files.
+// Run it with -update to rewrite them.
+func TestGolden(t *testing.T) {
+	src, e := ioutil.ReadFile("../example/schema.graphqls")
+	if e != nil {
+		t.Fatal(e)
+	}
+	ts := parse(t, string(src))
+	tests := []struct {
+		file  string
+		root  string
+		depth int
+		write func(*Graph, *bytes.Buffer) error
+	}{
+		{"example.dot", "", 0, func(g *Graph, b *bytes.Buffer) error { return g.WriteDOT(b) }},
+		{"example.mmd", "", 0, func(g *Graph, b *bytes.Buffer) error { return g.WriteMermaid(b) }},
+		{"example_query_1.dot", "Query", 1, func(g *Graph, b *bytes.Buffer) error { return g.WriteDOT(b) }},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		if e := tt.write(Build(ts, tt.root, tt.depth), &b); e != nil {
+			t.Fatal(e)
+		}
+		p := path.Join("testdata", tt.file)
+		if *update {
+			if e := ioutil.WriteFile(p, b.Bytes(), 0644); e != nil {
+				t.Fatal(e)
+			}
+		}
+		want, e := ioutil.ReadFile(p)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if !bytes.Equal(b.Bytes(), want) {
+			t.Errorf("%s differs from the golden file, run go test -update if the changes are intended\n%s", tt.file, b.String())
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	ts := parse(t, `
+type Query { garage: Garage vehicle: Vehicle node(id: ID!): Node }
+type Garage implements Node { id: ID! trucks(filter: Filter): [Truck!]! }
+type Truck implements Node { id: ID! driver: Driver }
+type Driver { name: String }
+interface Node { id: ID! }
+union Vehicle = Truck
+input Filter { maker: String nested: Filter }
+enum Unused { A }
+`)
+	tests := []struct {
+		root  string
+		depth int
+		nodes []string
+		edges []string
+	}{
+		{
+			root:  "",
+			nodes: []string{"Driver", "Filter", "Garage", "Node", "Query", "Truck", "Vehicle"},
+			edges: []string{
+				"Filter -> Filter (nested: Filter)",
+				"Garage -> Node (implements)",
+				"Garage -> Truck (trucks: [Truck!]!)",
+				"Query -> Garage (garage: Garage)",
+				"Query -> Vehicle (vehicle: Vehicle)",
+				"Query -> Node (node: Node)",
+				"Truck -> Node (implements)",
+				"Truck -> Driver (driver: Driver)",
+				"Vehicle -> Truck (member)",
+			},
+		},
+		{
+			root:  "Query",
+			depth: 1,
+			nodes: []string{"Garage", "Node", "Query", "Vehicle"},
+			edges: []string{
+				"Garage -> Node (implements)",
+				"Query -> Garage (garage: Garage)",
+				"Query -> Vehicle (vehicle: Vehicle)",
+				"Query -> Node (node: Node)",
+			},
+		},
+		{
+			root:  "Query",
+			depth: 2,
+			nodes: []string{"Garage", "Node", "Query", "Truck", "Vehicle"},
+			edges: []string{
+				"Garage -> Node (implements)",
+				"Garage -> Truck (trucks: [Truck!]!)",
+				"Query -> Garage (garage: Garage)",
+				"Query -> Vehicle (vehicle: Vehicle)",
+				"Query -> Node (node: Node)",
+				"Truck -> Node (implements)",
+				"Vehicle -> Truck (member)",
+			},
+		},
+		{
+			root:  "Truck",
+			nodes: []string{"Driver", "Node", "Truck"},
+			edges: []string{
+				"Truck -> Node (implements)",
+				"Truck -> Driver (driver: Driver)",
+			},
+		},
+		{
+			root:  "Filter",
+			depth: 3,
+			nodes: []string{"Filter"},
+			edges: []string{"Filter -> Filter (nested: Filter)"},
+		},
+		{
+			root: "Unknown",
+		},
+	}
+	for _, tt := range tests {
+		g := Build(ts, tt.root, tt.depth)
+		var nodes, edges []string
+		for _, n := range g.Nodes {
+			nodes = append(nodes, n.Name)
+		}
+		for _, e := range g.Edges {
+			edges = append(edges, e.From+" -> "+e.To+" ("+e.Label+")")
+		}
+		if !reflect.DeepEqual(nodes, tt.nodes) {
+			t.Errorf("root %q depth %d: got nodes %q, want %q", tt.root, tt.depth, nodes, tt.nodes)
+		}
+		if !reflect.DeepEqual(edges, tt.edges) {
+			t.Errorf("root %q depth %d: got edges\n%s\nwant\n%s", tt.root, tt.depth, strings.Join(edges, "\n"), strings.Join(tt.edges, "\n"))
+		}
+	}
+}
+
+func TestWriteMermaidKeywords(t *testing.T) {
+	ts := parse(t, "type end { graph: graph }\ntype graph { subgraph: subgraph }\ntype subgraph { a: Int }")
+	var b bytes.Buffer
+	if e := Build(ts, "", 0).WriteMermaid(&b); e != nil {
+		t.Fatal(e)
+	}
+	want := `flowchart LR
+  t_end["end"]
+  t_graph["graph"]
+  t_subgraph["subgraph"]
+  t_end -->|"graph: graph"| t_graph
+  t_graph -->|"subgraph: subgraph"| t_subgraph
+`
+	if got := b.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var dotShapes = map[Kind]string{
+	Object:    "box",
+	Interface: "ellipse",
+	Union:     "diamond",
+	Input:     "parallelogram",
+}
+
+// WriteDOT writes g in DOT of Graphviz.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	p := &errWriter{w: w}
+	p.printf("digraph schema {\n")
+	p.printf("  rankdir=LR;\n")
+	for _, n := range g.Nodes {
+		p.printf("  %s [shape=%s];\n", strconv.Quote(n.Name), dotShapes[n.Kind])
+	}
+	for _, e := range g.Edges {
+		style := ""
+		if e.Kind != Field {
+			style = " style=dashed"
+		}
+		p.printf("  %s -> %s [label=%s%s];\n", strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(e.Label), style)
+	}
+	p.printf("}\n")
+	return p.err
+}
+
+// mermaidShapes are the brackets around the labels of nodes.
+var mermaidShapes = map[Kind][2]string{
+	Object:    {"[", "]"},
+	Interface: {"([", "])"},
+	Union:     {"{{", "}}"},
+	Input:     {"[/", "/]"},
+}
+
+// WriteMermaid writes g as a Mermaid flowchart.
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	p := &errWriter{w: w}
+	p.printf("flowchart LR\n")
+	for _, n := range g.Nodes {
+		s := mermaidShapes[n.Kind]
+		p.printf("  %s%s%s%s\n", mermaidID(n.Name), s[0], mermaidLabel(n.Name), s[1])
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Kind != Field {
+			arrow = "-.->"
+		}
+		p.printf("  %s %s|%s| %s\n", mermaidID(e.From), arrow, mermaidLabel(e.Label), mermaidID(e.To))
+	}
+	return p.err
+}
+
+// mermaidID returns the ID of the node of the type name. Names are prefixed
+// not to be taken as keywords such as end or subgraph.
+func mermaidID(name string) string {
+	return "t_" + name
+}
+
+// mermaidLabel quotes s, in which brackets and exclamation marks are not
+// allowed unquoted.
+func mermaidLabel(s string) string {
+	return `"` + strings.Replace(s, `"`, "#quot;", -1) + `"`
+}
+
+// errWriter keeps the first error of writes and skips the rest.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *errWriter) printf(format string, args ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
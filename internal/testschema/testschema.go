@@ -0,0 +1,37 @@
+// Package testschema generates schemas for tests and benchmarks.
+package testschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Large returns a valid schema of n object types with comments,
+// descriptions, arguments with default values, enums, inputs and
+// directives, which is about 20 lines per type.
+func Large(n int) string {
+	var b strings.Builder
+	b.WriteString("schema {\n  query: Query\n}\n\n")
+	b.WriteString("directive @cost(weight: Int = 1) on FIELD_DEFINITION\n\n")
+	b.WriteString("type Query {\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "  type%d(id: ID!): Type%d\n", i, i)
+	}
+	b.WriteString("}\n\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "# Type%d is generated.\n", i)
+		fmt.Fprintf(&b, "\"\"\"\nType number %d.\n\nIt has fields of every kind.\n\"\"\"\n", i)
+		fmt.Fprintf(&b, "type Type%d {\n", i)
+		b.WriteString("  id: ID!\n")
+		b.WriteString("  \"The name, escaped \\\"like\\\" this.\"\n")
+		b.WriteString("  name: String @deprecated(reason: \"Use label\")\n")
+		b.WriteString("  label(locale: String = \"en\"): String!\n")
+		fmt.Fprintf(&b, "  score(scale: Float = -1.5e3, offset: Int = %d): Float @cost(weight: 2)\n", i)
+		fmt.Fprintf(&b, "  kind: Kind%d! # trailing comment\n", i)
+		fmt.Fprintf(&b, "  related(first: Int = 10, filter: Filter%d = {kind: A, ids: [\"1\", \"2\"]}): [Type%d!]!\n", i, (i+1)%n)
+		b.WriteString("}\n\n")
+		fmt.Fprintf(&b, "enum Kind%d { A B C }\n\n", i)
+		fmt.Fprintf(&b, "input Filter%d {\n  kind: Kind%d\n  ids: [ID!]\n}\n\n", i, i)
+	}
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package introspection
+
+import (
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/parser"
+)
+
+// builtinSchema defines the built-in scalars and directives and the types of
+// the introspection system, which every schema has implicitly.
+const builtinSchema = `
+scalar Int
+scalar Float
+scalar String
+scalar Boolean
+scalar ID
+
+directive @skip(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
+directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
+directive @deprecated(
+  reason: String = "No longer supported"
+) on FIELD_DEFINITION | ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION | ENUM_VALUE
+
+type __Schema {
+  types: [__Type!]!
+  queryType: __Type!
+  mutationType: __Type
+  subscriptionType: __Type
+  directives: [__Directive!]!
+}
+
+type __Type {
+  kind: __TypeKind!
+  name: String
+  description: String
+  fields(includeDeprecated: Boolean = false): [__Field!]
+  interfaces: [__Type!]
+  possibleTypes: [__Type!]
+  enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
+  inputFields: [__InputValue!]
+  ofType: __Type
+}
+
+type __Field {
+  name: String!
+  description: String
+  args: [__InputValue!]!
+  type: __Type!
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+
+type __InputValue {
+  name: String!
+  description: String
+  type: __Type!
+  defaultValue: String
+}
+
+type __EnumValue {
+  name: String!
+  description: String
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+
+enum __TypeKind {
+  SCALAR
+  OBJECT
+  INTERFACE
+  UNION
+  ENUM
+  INPUT_OBJECT
+  LIST
+  NON_NULL
+}
+
+type __Directive {
+  name: String!
+  description: String
+  locations: [__DirectiveLocation!]!
+  args: [__InputValue!]!
+}
+
+enum __DirectiveLocation {
+  QUERY
+  MUTATION
+  SUBSCRIPTION
+  FIELD
+  FRAGMENT_DEFINITION
+  FRAGMENT_SPREAD
+  INLINE_FRAGMENT
+  VARIABLE_DEFINITION
+  SCHEMA
+  SCALAR
+  OBJECT
+  FIELD_DEFINITION
+  ARGUMENT_DEFINITION
+  INTERFACE
+  UNION
+  ENUM
+  ENUM_VALUE
+  INPUT_OBJECT
+  INPUT_FIELD_DEFINITION
+}
+`
+
+var builtins = parser.NewParser(strings.NewReader(builtinSchema)).ParseAndEvalSchema()
+
+// withBuiltins returns a copy of ts with the definitions of builtinSchema
+// which ts does not define.
+func withBuiltins(ts *gql.TypeSystem) *gql.TypeSystem {
+	res := gql.NewTypeSystem()
+	res.Merge(builtins)
+	res.Merge(ts)
+	res.Schema = ts.Schema
+	return res
+}
+
+// isBuiltin returns whether name is defined by builtinSchema.
+func isBuiltin(name string) bool {
+	_, scalar := builtins.ScalarTypes[name]
+	_, object := builtins.ObjectTypes[name]
+	_, enum := builtins.EnumTypes[name]
+	return scalar || object || enum
+}
@@ -0,0 +1,314 @@
+// Package introspection converts a schema into the result of the standard
+// introspection query, which tools read in place of a running server.
+package introspection
+
+import (
+	"sort"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// Kind is a value of __TypeKind.
+type Kind string
+
+const (
+	KindScalar      Kind = "SCALAR"
+	KindObject      Kind = "OBJECT"
+	KindInterface   Kind = "INTERFACE"
+	KindUnion       Kind = "UNION"
+	KindEnum        Kind = "ENUM"
+	KindInputObject Kind = "INPUT_OBJECT"
+	KindList        Kind = "LIST"
+	KindNonNull     Kind = "NON_NULL"
+)
+
+// Result is the data of the introspection query.
+type Result struct {
+	Schema *Schema `json:"__schema"`
+}
+
+type Schema struct {
+	QueryType        *TypeName    `json:"queryType"`
+	MutationType     *TypeName    `json:"mutationType"`
+	SubscriptionType *TypeName    `json:"subscriptionType"`
+	Types            []*Type      `json:"types"`
+	Directives       []*Directive `json:"directives"`
+}
+
+type TypeName struct {
+	Name string `json:"name"`
+}
+
+// Type is a named type. Fields which do not apply to its kind are nil.
+type Type struct {
+	Kind          Kind          `json:"kind"`
+	Name          string        `json:"name"`
+	Description   *string       `json:"description"`
+	Fields        []*Field      `json:"fields"`
+	InputFields   []*InputValue `json:"inputFields"`
+	Interfaces    []*TypeRef    `json:"interfaces"`
+	EnumValues    []*EnumValue  `json:"enumValues"`
+	PossibleTypes []*TypeRef    `json:"possibleTypes"`
+}
+
+// TypeRef refers to a named type, or wraps OfType if Kind is LIST or
+// NON_NULL.
+type TypeRef struct {
+	Kind   Kind     `json:"kind"`
+	Name   *string  `json:"name"`
+	OfType *TypeRef `json:"ofType"`
+}
+
+type Field struct {
+	Name              string        `json:"name"`
+	Description       *string       `json:"description"`
+	Args              []*InputValue `json:"args"`
+	Type              *TypeRef      `json:"type"`
+	IsDeprecated      bool          `json:"isDeprecated"`
+	DeprecationReason *string       `json:"deprecationReason"`
+}
+
+type InputValue struct {
+	Name         string   `json:"name"`
+	Description  *string  `json:"description"`
+	Type         *TypeRef `json:"type"`
+	DefaultValue *string  `json:"defaultValue"`
+}
+
+type EnumValue struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+type Directive struct {
+	Name        string        `json:"name"`
+	Description *string       `json:"description"`
+	Locations   []string      `json:"locations"`
+	Args        []*InputValue `json:"args"`
+}
+
+// Introspect returns the introspection of ts. Built-in scalars, directives
+// and the types of the introspection system are included as a server would
+// return them. Types and directives are sorted by name, and deprecated
+// fields and enum values are included.
+func Introspect(ts *gql.TypeSystem) *Result {
+	ts = withBuiltins(ts)
+	in := &introspector{ts: ts}
+	s := &Schema{
+		Types:      []*Type{},
+		Directives: []*Directive{},
+	}
+	s.QueryType = rootType(ts, ts.Schema.Query, "Query")
+	s.MutationType = rootType(ts, ts.Schema.Mutation, "Mutation")
+	s.SubscriptionType = rootType(ts, ts.Schema.Subscription, "Subscription")
+
+	for _, t := range ts.ScalarTypes {
+		s.Types = append(s.Types, &Type{
+			Kind:        KindScalar,
+			Name:        t.Name,
+			Description: description(t.Description),
+		})
+	}
+	for _, t := range ts.ObjectTypes {
+		interfaces := []*TypeRef{}
+		for _, i := range t.Implements {
+			interfaces = append(interfaces, in.typeRef(i))
+		}
+		s.Types = append(s.Types, &Type{
+			Kind:        KindObject,
+			Name:        t.Name,
+			Description: description(t.Description),
+			Fields:      in.fields(t.Fields),
+			Interfaces:  interfaces,
+		})
+	}
+	for _, t := range ts.InterfaceTypes {
+		s.Types = append(s.Types, &Type{
+			Kind:          KindInterface,
+			Name:          t.Name,
+			Description:   description(t.Description),
+			Fields:        in.fields(t.Fields),
+			PossibleTypes: in.implementations(t.Name),
+		})
+	}
+	for _, t := range ts.UnionTypes {
+		members := []*TypeRef{}
+		for _, m := range t.Members {
+			members = append(members, in.typeRef(m))
+		}
+		s.Types = append(s.Types, &Type{
+			Kind:          KindUnion,
+			Name:          t.Name,
+			Description:   description(t.Description),
+			PossibleTypes: members,
+		})
+	}
+	for _, t := range ts.EnumTypes {
+		values := []*EnumValue{}
+		for _, v := range t.Values {
+			deprecated, reason := deprecation(v.Directives)
+			values = append(values, &EnumValue{
+				Name:              v.Name,
+				Description:       description(v.Description),
+				IsDeprecated:      deprecated,
+				DeprecationReason: reason,
+			})
+		}
+		s.Types = append(s.Types, &Type{
+			Kind:        KindEnum,
+			Name:        t.Name,
+			Description: description(t.Description),
+			EnumValues:  values,
+		})
+	}
+	for _, t := range ts.InputObjectTypes {
+		s.Types = append(s.Types, &Type{
+			Kind:        KindInputObject,
+			Name:        t.Name,
+			Description: description(t.Description),
+			InputFields: in.inputValues(t.InputValue),
+		})
+	}
+	sort.Slice(s.Types, func(i, j int) bool {
+		return s.Types[i].Name < s.Types[j].Name
+	})
+
+	for _, d := range ts.Directives {
+		var locations []string
+		for _, l := range d.Location {
+			locations = append(locations, l.String())
+		}
+		s.Directives = append(s.Directives, &Directive{
+			Name:        d.Name,
+			Description: description(d.Description),
+			Locations:   locations,
+			Args:        in.inputValues(d.Arguments),
+		})
+	}
+	sort.Slice(s.Directives, func(i, j int) bool {
+		return s.Directives[i].Name < s.Directives[j].Name
+	})
+	return &Result{Schema: s}
+}
+
+type introspector struct {
+	ts *gql.TypeSystem
+}
+
+// kind returns the kind of the named type name.
+func (in *introspector) kind(name string) Kind {
+	if _, ok := in.ts.ObjectTypes[name]; ok {
+		return KindObject
+	}
+	if _, ok := in.ts.InterfaceTypes[name]; ok {
+		return KindInterface
+	}
+	if _, ok := in.ts.UnionTypes[name]; ok {
+		return KindUnion
+	}
+	if _, ok := in.ts.EnumTypes[name]; ok {
+		return KindEnum
+	}
+	if _, ok := in.ts.InputObjectTypes[name]; ok {
+		return KindInputObject
+	}
+	return KindScalar
+}
+
+// rootType returns the root operation type ref, or the type named name by
+// convention. It returns nil if the schema has no such type.
+func rootType(ts *gql.TypeSystem, ref *gql.TypeRef, name string) *TypeName {
+	if ref != nil {
+		name = ref.Name
+	}
+	if _, ok := ts.ObjectTypes[name]; !ok {
+		return nil
+	}
+	return &TypeName{Name: name}
+}
+
+func (in *introspector) fields(fs []*gql.ObjectField) []*Field {
+	res := []*Field{}
+	for _, f := range fs {
+		deprecated, reason := deprecation(f.Directives)
+		res = append(res, &Field{
+			Name:              f.Name,
+			Description:       description(f.Description),
+			Args:              in.inputValues(f.Args),
+			Type:              in.typeRef(f.Type),
+			IsDeprecated:      deprecated,
+			DeprecationReason: reason,
+		})
+	}
+	return res
+}
+
+func (in *introspector) inputValues(vs []*gql.InputValue) []*InputValue {
+	res := []*InputValue{}
+	for _, v := range vs {
+		var def *string
+		if v.Default != nil {
+			s := v.Default.Value()
+			def = &s
+		}
+		res = append(res, &InputValue{
+			Name:         v.Name,
+			Description:  description(v.Description),
+			Type:         in.typeRef(v.Type),
+			DefaultValue: def,
+		})
+	}
+	return res
+}
+
+func (in *introspector) typeRef(ref *gql.TypeRef) *TypeRef {
+	var t *TypeRef
+	if ref.Name == "[]" {
+		t = &TypeRef{Kind: KindList, OfType: in.typeRef(ref.InnerType)}
+	} else {
+		name := ref.Name
+		t = &TypeRef{Kind: in.kind(name), Name: &name}
+	}
+	if !ref.IsNullable {
+		t = &TypeRef{Kind: KindNonNull, OfType: t}
+	}
+	return t
+}
+
+// implementations returns refs to the object types implementing the
+// interface name, sorted by name.
+func (in *introspector) implementations(name string) []*TypeRef {
+	var names []string
+	for n, o := range in.ts.ObjectTypes {
+		for _, i := range o.Implements {
+			if i.Name == name {
+				names = append(names, n)
+			}
+		}
+	}
+	sort.Strings(names)
+	refs := []*TypeRef{}
+	for _, n := range names {
+		refs = append(refs, in.typeRef(&gql.TypeRef{Name: n, IsNullable: true}))
+	}
+	return refs
+}
+
+func description(raw string) *string {
+	if raw == "" {
+		return nil
+	}
+	s := gql.StringValue(raw)
+	return &s
+}
+
+// deprecation returns whether @deprecated is in dirs and its reason.
+func deprecation(dirs []*gql.DirectiveRef) (bool, *string) {
+	deprecated, reason := gql.Deprecation(dirs)
+	if !deprecated {
+		return false, nil
+	}
+	return true, &reason
+}
@@ -0,0 +1,217 @@
+package introspection
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/parser"
+	"github.com/RettyEng/gqlcodegen/printer"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the generated ones")
+
+// exampleGolden is the introspection result of the example schema.
+const exampleGolden = "testdata/example.json"
+
+func loadExample(t *testing.T) *gql.TypeSystem {
+	t.Helper()
+	f, e := os.Open("../example/schema.graphqls")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer f.Close()
+	top, e := parser.NewFileParser("schema.graphqls", f).Parse()
+	if e != nil {
+		t.Fatal(e)
+	}
+	ts, e := top.Eval()
+	if e != nil {
+		t.Fatal(e)
+	}
+	return ts
+}
+
+// TestIntrospectGolden compares the introspection of the example schema
+// with the golden file, which holds the built-in scalars and directives, the
+// types of the introspection system and deprecations besides the types of
+// the schema. Run it with -update to rewrite the golden file.
+func TestIntrospectGolden(t *testing.T) {
+	b, e := json.MarshalIndent(Introspect(loadExample(t)), "", "  ")
+	if e != nil {
+		t.Fatal(e)
+	}
+	b = append(b, '\n')
+	if *update {
+		if e := ioutil.WriteFile(exampleGolden, b, 0644); e != nil {
+			t.Fatal(e)
+		}
+	}
+	want, e := ioutil.ReadFile(exampleGolden)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !bytes.Equal(b, want) {
+		t.Errorf("introspection differs from %s, run go test -update if the changes are intended", exampleGolden)
+	}
+
+	// Spot checks so that a golden file rewritten by mistake is noticed.
+	var res Result
+	if e := json.Unmarshal(want, &res); e != nil {
+		t.Fatal(e)
+	}
+	types := map[string]*Type{}
+	for _, typ := range res.Schema.Types {
+		types[typ.Name] = typ
+	}
+	for _, n := range []string{
+		"Int", "Float", "String", "Boolean", "ID",
+		"__Schema", "__Type", "__TypeKind", "__Field", "__InputValue", "__EnumValue",
+		"__Directive", "__DirectiveLocation",
+	} {
+		if types[n] == nil {
+			t.Errorf("type %s is missing", n)
+		}
+	}
+	directives := map[string]bool{}
+	for _, d := range res.Schema.Directives {
+		directives[d.Name] = true
+	}
+	for _, n := range []string{"include", "skip", "deprecated", "special"} {
+		if !directives[n] {
+			t.Errorf("directive @%s is missing", n)
+		}
+	}
+	if m := types["Maker"]; m != nil {
+		for _, v := range m.EnumValues {
+			if v.Name == "ISUZU" && (!v.IsDeprecated || v.DeprecationReason == nil || *v.DeprecationReason != "not an euro truck") {
+				t.Errorf("deprecation of Maker.ISUZU is lost")
+			}
+		}
+	}
+}
+
+// TestLoadIntrospection checks that the introspection of the example schema
+// loads back to the same schema, except for what introspection cannot hold.
+func TestLoadIntrospection(t *testing.T) {
+	ts := loadExample(t)
+	b, e := json.Marshal(Introspect(ts))
+	if e != nil {
+		t.Fatal(e)
+	}
+	loaded, e := Load("upstream.json", bytes.NewReader(b))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if got, want := sprint(t, comparable(loaded)), sprint(t, comparable(ts)); got != want {
+		t.Errorf("loaded\n%s\nwant\n%s", got, want)
+	}
+
+	pos := loaded.ObjectTypes["Truck"].Fields[0].Position
+	if pos.String() != "upstream.json" {
+		t.Errorf("position of a loaded field is %q, want the file name alone", pos)
+	}
+}
+
+func TestLoadDescription(t *testing.T) {
+	for _, desc := range []string{
+		"a",
+		"a\nb",
+		"  a\n  b",
+		"a\n  b",
+		"\na",
+		"a\n\n",
+		"a\r\nb",
+		`a """ b` + "\n" + `"c"`,
+	} {
+		raw := rawDescription(&desc)
+		if v := gql.StringValue(raw); v != desc {
+			t.Errorf("description %q is loaded as %s, whose value is %q", desc, raw, v)
+		}
+	}
+}
+
+func sprint(t *testing.T, ts *gql.TypeSystem) string {
+	t.Helper()
+	var b bytes.Buffer
+	if e := printer.Fprint(&b, ts); e != nil {
+		t.Fatal(e)
+	}
+	return b.String()
+}
+
+// comparable drops directives from ts but deprecations of fields and enum
+// values, which are the only ones introspection holds, and makes
+// descriptions strings, so that ts prints the same as it does after loaded
+// from its introspection.
+func comparable(ts *gql.TypeSystem) *gql.TypeSystem {
+	desc := func(d *string) {
+		if *d != "" {
+			*d = strconv.Quote(gql.StringValue(*d))
+		}
+	}
+	deprecated := func(refs []*gql.DirectiveRef) []*gql.DirectiveRef {
+		var ret []*gql.DirectiveRef
+		for _, r := range refs {
+			if r.Name == "deprecated" {
+				ret = append(ret, r)
+			}
+		}
+		return ret
+	}
+	args := func(vs []*gql.InputValue) {
+		for _, v := range vs {
+			desc(&v.Description)
+			v.Directives = nil
+		}
+	}
+	fields := func(fs []*gql.ObjectField) {
+		for _, f := range fs {
+			desc(&f.Description)
+			f.Directives = deprecated(f.Directives)
+			args(f.Args)
+		}
+	}
+	ts.Schema.Directives = nil
+	for _, d := range ts.Directives {
+		desc(&d.Description)
+		args(d.Arguments)
+	}
+	for _, s := range ts.ScalarTypes {
+		desc(&s.Description)
+		s.Directives = nil
+	}
+	for _, o := range ts.ObjectTypes {
+		desc(&o.Description)
+		o.Directives = nil
+		fields(o.Fields)
+	}
+	for _, i := range ts.InterfaceTypes {
+		desc(&i.Description)
+		i.Directives = nil
+		fields(i.Fields)
+	}
+	for _, u := range ts.UnionTypes {
+		desc(&u.Description)
+		u.Directives = nil
+	}
+	for _, e := range ts.EnumTypes {
+		desc(&e.Description)
+		e.Directives = nil
+		for _, v := range e.Values {
+			desc(&v.Description)
+			v.Directives = deprecated(v.Directives)
+		}
+	}
+	for _, i := range ts.InputObjectTypes {
+		desc(&i.Description)
+		i.Directives = nil
+		args(i.InputValue)
+	}
+	return ts
+}
@@ -0,0 +1,284 @@
+package introspection
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/ast/directive"
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/parser"
+)
+
+// Load reads an introspection result into a gql.TypeSystem. The input is
+// either the data of the introspection query or the whole response holding
+// it as "data". Built-in scalars, directives and the types of the
+// introspection system are omitted as they are in a schema.
+func Load(filename string, r io.Reader) (*gql.TypeSystem, error) {
+	var res struct {
+		Schema *Schema `json:"__schema"`
+		Data   *Result `json:"data"`
+	}
+	if e := json.NewDecoder(r).Decode(&res); e != nil {
+		return nil, fmt.Errorf("%s: %v", filename, e)
+	}
+	s := res.Schema
+	if s == nil && res.Data != nil {
+		s = res.Data.Schema
+	}
+	if s == nil {
+		return nil, fmt.Errorf("%s: __schema is not found", filename)
+	}
+	l := &loader{pos: gql.Position{Filename: filename}}
+	ts, e := l.typeSystem(s)
+	if e != nil {
+		return nil, fmt.Errorf("%s: %v", filename, e)
+	}
+	return ts, nil
+}
+
+type loader struct {
+	// pos is the position of every definition loaded. It holds the file name
+	// alone as the definitions have no lines in the introspection result.
+	pos gql.Position
+}
+
+func (l *loader) typeSystem(s *Schema) (*gql.TypeSystem, error) {
+	ts := gql.NewTypeSystem()
+	if s.QueryType != nil {
+		ts.Schema.Query = l.namedRef(s.QueryType.Name)
+	}
+	if s.MutationType != nil {
+		ts.Schema.Mutation = l.namedRef(s.MutationType.Name)
+	}
+	if s.SubscriptionType != nil {
+		ts.Schema.Subscription = l.namedRef(s.SubscriptionType.Name)
+	}
+
+	for _, t := range s.Types {
+		if isBuiltin(t.Name) {
+			continue
+		}
+		desc := rawDescription(t.Description)
+		switch t.Kind {
+		case KindScalar:
+			ts.ScalarTypes[t.Name] = &gql.Scalar{
+				Description: desc,
+				Name:        t.Name,
+				Position:    l.pos,
+			}
+		case KindObject:
+			fields, e := l.fields(t.Fields)
+			if e != nil {
+				return nil, e
+			}
+			var implements []*gql.TypeRef
+			for _, i := range t.Interfaces {
+				implements = append(implements, l.typeRef(i))
+			}
+			ts.ObjectTypes[t.Name] = &gql.Object{
+				Description: desc,
+				Name:        t.Name,
+				Position:    l.pos,
+				Implements:  implements,
+				Fields:      fields,
+			}
+		case KindInterface:
+			fields, e := l.fields(t.Fields)
+			if e != nil {
+				return nil, e
+			}
+			ts.InterfaceTypes[t.Name] = &gql.Interface{
+				Description: desc,
+				Name:        t.Name,
+				Position:    l.pos,
+				Fields:      fields,
+			}
+		case KindUnion:
+			var members []*gql.TypeRef
+			for _, m := range t.PossibleTypes {
+				members = append(members, l.typeRef(m))
+			}
+			ts.UnionTypes[t.Name] = &gql.Union{
+				Description: desc,
+				Name:        t.Name,
+				Position:    l.pos,
+				Members:     members,
+			}
+		case KindEnum:
+			var values []*gql.EnumValue
+			for _, v := range t.EnumValues {
+				values = append(values, &gql.EnumValue{
+					Description: rawDescription(v.Description),
+					Name:        v.Name,
+					Position:    l.pos,
+					Directives:  l.deprecated(v.IsDeprecated, v.DeprecationReason),
+				})
+			}
+			ts.EnumTypes[t.Name] = &gql.Enum{
+				Description: desc,
+				Name:        t.Name,
+				Position:    l.pos,
+				Values:      values,
+			}
+		case KindInputObject:
+			values, e := l.inputValues(t.InputFields)
+			if e != nil {
+				return nil, e
+			}
+			ts.InputObjectTypes[t.Name] = &gql.InputObject{
+				Description: desc,
+				Name:        t.Name,
+				Position:    l.pos,
+				InputValue:  values,
+			}
+		default:
+			return nil, fmt.Errorf("unknown kind %s of type %s", t.Kind, t.Name)
+		}
+	}
+
+	for _, d := range s.Directives {
+		if _, ok := builtins.Directives[d.Name]; ok {
+			continue
+		}
+		args, e := l.inputValues(d.Args)
+		if e != nil {
+			return nil, e
+		}
+		var locations []directive.Location
+		for _, n := range d.Locations {
+			loc, ok := location(n)
+			if !ok {
+				return nil, fmt.Errorf("unknown location %s of directive %s", n, d.Name)
+			}
+			locations = append(locations, loc)
+		}
+		ts.Directives[d.Name] = &gql.Directive{
+			Description: rawDescription(d.Description),
+			Name:        d.Name,
+			Position:    l.pos,
+			Arguments:   args,
+			Location:    locations,
+		}
+	}
+	return ts, nil
+}
+
+func (l *loader) fields(fs []*Field) ([]*gql.ObjectField, error) {
+	var res []*gql.ObjectField
+	for _, f := range fs {
+		args, e := l.inputValues(f.Args)
+		if e != nil {
+			return nil, e
+		}
+		res = append(res, &gql.ObjectField{
+			Name:        f.Name,
+			Position:    l.pos,
+			Type:        l.typeRef(f.Type),
+			Description: rawDescription(f.Description),
+			Directives:  l.deprecated(f.IsDeprecated, f.DeprecationReason),
+			Args:        args,
+		})
+	}
+	return res, nil
+}
+
+func (l *loader) inputValues(vs []*InputValue) ([]*gql.InputValue, error) {
+	var res []*gql.InputValue
+	for _, v := range vs {
+		var def gql.Value
+		if v.DefaultValue != nil {
+			exp, e := parser.NewParser(strings.NewReader(*v.DefaultValue)).ParseValue()
+			if e != nil {
+				return nil, fmt.Errorf("default value of %s: %v", v.Name, e)
+			}
+			def = exp.Eval()
+		}
+		res = append(res, &gql.InputValue{
+			Description: rawDescription(v.Description),
+			Name:        v.Name,
+			Position:    l.pos,
+			Type:        l.typeRef(v.Type),
+			Default:     def,
+		})
+	}
+	return res, nil
+}
+
+func (l *loader) typeRef(ref *TypeRef) *gql.TypeRef {
+	switch ref.Kind {
+	case KindNonNull:
+		t := l.typeRef(ref.OfType)
+		t.IsNullable = false
+		return t
+	case KindList:
+		return &gql.TypeRef{
+			InnerType:  l.typeRef(ref.OfType),
+			Name:       "[]",
+			IsNullable: true,
+			Position:   l.pos,
+		}
+	}
+	name := ""
+	if ref.Name != nil {
+		name = *ref.Name
+	}
+	return l.namedRef(name)
+}
+
+func (l *loader) namedRef(name string) *gql.TypeRef {
+	return &gql.TypeRef{Name: name, IsNullable: true, Position: l.pos}
+}
+
+// deprecated returns @deprecated with reason if deprecated is true.
+func (l *loader) deprecated(deprecated bool, reason *string) []*gql.DirectiveRef {
+	if !deprecated {
+		return nil
+	}
+	d := &gql.DirectiveRef{Name: "deprecated", Position: l.pos, Args: map[string]gql.Value{}}
+	if reason != nil {
+		d.Args["reason"] = &gql.ValueImpl{Val: quote(*reason)}
+		d.ArgNames = []string{"reason"}
+	}
+	return []*gql.DirectiveRef{d}
+}
+
+func location(name string) (directive.Location, bool) {
+	for l := directive.QUERY; l <= directive.INPUT_FIELD_DEFINITION; l++ {
+		if l.String() == name {
+			return l, true
+		}
+	}
+	return 0, false
+}
+
+// rawDescription returns desc in the form descriptions take in the source,
+// as gql keeps them. Descriptions of multiple lines are block strings unless
+// their indentation or blank lines at the ends would be lost in one.
+func rawDescription(desc *string) string {
+	if desc == nil || *desc == "" {
+		return ""
+	}
+	lines := strings.Split(*desc, "\n")
+	first, last := lines[0], lines[len(lines)-1]
+	block := len(lines) > 1 &&
+		!strings.Contains(*desc, "\r") &&
+		strings.TrimSpace(first) != "" &&
+		strings.TrimLeft(first, " \t") == first &&
+		strings.TrimSpace(last) != ""
+	if block {
+		return `"""` + "\n" + strings.Replace(*desc, `"""`, `\"""`, -1) + "\n" + `"""`
+	}
+	return quote(*desc)
+}
+
+// quote returns s as a string literal.
+func quote(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
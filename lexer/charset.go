@@ -1,8 +1,15 @@
 package lexer
 
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Matcher matches runes read ahead by a Scanner.
 type Matcher interface {
-	HeadMatches(runes []rune) bool
-	MatchCount(runes []rune) int
+	// Match returns the number of the runes matching from the offset-th rune
+	// ahead, or 0 if they do not match.
+	Match(s *Scanner, offset int) int
 }
 
 /*********************************************************
@@ -17,20 +24,14 @@ func Not(m Matcher) Matcher {
 	return &not{m}
 }
 
-func (n *not) HeadMatches(rs []rune) bool {
-	if len(rs) == 0 {
-		return false
-	}
-	return !n.matcher.HeadMatches(rs)
-}
-
-func (n *not) MatchCount(rs []rune) int {
+// Match counts the runes up to the end or the first match of the matcher.
+func (n *not) Match(s *Scanner, offset int) int {
 	c := 0
 	for {
-		if c > len(rs) {
-			return len(rs)
+		if _, ok := s.peek(offset + c); !ok {
+			return c
 		}
-		if n.matcher.HeadMatches(rs[c:]) {
+		if n.matcher.Match(s, offset+c) != 0 {
 			return c
 		}
 		c++
@@ -49,27 +50,28 @@ func Union(ms ...Matcher) Matcher {
 	return &union{ms}
 }
 
+// StrUnion matches any of strs. Strings of a rune are matched as a set after
+// longer ones, so that a longer string wins over its first rune.
 func StrUnion(strs ...string) Matcher {
-	ms := make([]Matcher, len(strs))
-	for i, str := range strs {
-		ms[i] = Str(str)
-	}
-	return Union(ms...)
-}
-
-func (u *union) HeadMatches(rs []rune) bool {
-	for _, m := range u.matchers {
-		if m.HeadMatches(rs) {
-			return true
+	var ms []Matcher
+	set := ""
+	for _, str := range strs {
+		if utf8.RuneCountInString(str) == 1 {
+			set += str
+		} else {
+			ms = append(ms, Str(str))
 		}
 	}
-	return false
+	if set != "" {
+		ms = append(ms, runeSet(set))
+	}
+	return Union(ms...)
 }
 
-func (u *union) MatchCount(runes []rune) int {
+func (u *union) Match(s *Scanner, offset int) int {
 	for _, m := range u.matchers {
-		if m.HeadMatches(runes) {
-			return m.MatchCount(runes)
+		if c := m.Match(s, offset); c != 0 {
+			return c
 		}
 	}
 	return 0
@@ -80,28 +82,31 @@ Str
  *********************************************************/
 
 type strMatch struct {
-	s string
+	runes []rune
 }
 
 func Str(str string) Matcher {
-	return &strMatch{str}
+	return &strMatch{[]rune(str)}
 }
 
-func (sm *strMatch) HeadMatches(rs []rune) bool {
-	if len([]rune(sm.s)) > len(rs) {
-		return false
-	}
-	for i, r := range []rune(sm.s) {
-		if rs[i] != r {
-			return false
+func (sm *strMatch) Match(s *Scanner, offset int) int {
+	for i, r := range sm.runes {
+		if p, ok := s.peek(offset + i); !ok || p != r {
+			return 0
 		}
 	}
-	return true
+	return len(sm.runes)
 }
 
-func (sm *strMatch) MatchCount(runes []rune) int {
-	if sm.HeadMatches(runes) {
-		return len([]rune(sm.s))
+/*********************************************************
+RuneSet
+ *********************************************************/
+
+type runeSet string
+
+func (rs runeSet) Match(s *Scanner, offset int) int {
+	if r, ok := s.peek(offset); ok && strings.ContainsRune(string(rs), r) {
+		return 1
 	}
 	return 0
 }
@@ -130,16 +135,8 @@ func (f *F) To(r rune) Matcher {
 	}
 }
 
-func (c *charset) HeadMatches(rs []rune) bool {
-	if len(rs) == 0 {
-		return false
-	}
-	r := rs[0]
-	return c.from <= r && r <= c.to
-}
-
-func (c *charset) MatchCount(rs []rune) int {
-	if c.HeadMatches(rs) {
+func (c *charset) Match(s *Scanner, offset int) int {
+	if r, ok := s.peek(offset); ok && c.from <= r && r <= c.to {
 		return 1
 	}
 	return 0
@@ -167,8 +164,8 @@ var (
 	commentHead = Str("#")
 	commentTail = Not(lineTerminator)
 
-	nameHead = Union(Str("_"), From('A').To('Z'), From('a').To('z'))
-	nameTail = Union(nameHead, digit)
+	nameHead = Union(From('a').To('z'), From('A').To('Z'), Str("_"))
+	nameTail = Union(From('a').To('z'), From('A').To('Z'), digit, Str("_"))
 
 	negative = Str("-")
 	intVal   = digit
@@ -180,7 +177,7 @@ var (
 	strStart             = Str("\"")
 	strEnd               = Str("\"")
 	strUnicodeEscapeHead = Str(`\u`)
-	hex                  = Union(From(0).To(9), From('A').To('F'), From('a').To('f'))
+	hex                  = Union(From('0').To('9'), From('A').To('F'), From('a').To('f'))
 	strEscape            = StrUnion(
 		`\\`, `\"`, `\/`, `\b`, `\f`, `\n`, `\r`, `\t`,
 	)
@@ -193,5 +190,5 @@ var (
 
 	blockStrStart = Str(`"""`)
 	blockStrEnd   = Str(`"""`)
-	blockStrChar  = Union(Str(`\"""`), Not(Str(`"""`)))
+	blockStrChar  = Union(Str(`\"""`), Not(Union(Str(`"""`), Str(`\"""`))))
 )
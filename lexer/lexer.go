@@ -1,29 +1,45 @@
 package lexer
 
 import (
-	"bufio"
+	"fmt"
 	"io"
-	"log"
 
 	"github.com/RettyEng/gqlcodegen/lexer/token"
 )
 
+// Lexer splits a schema into tokens. Comments are not returned as tokens but
+// attached to the following token as its leading trivia, or to the preceding
+// token as its trailing trivia if they are on the same line.
+// Lexer panics with *Error on an illegal input.
 type Lexer struct {
 	scanner *Scanner
-	pool    []*token.Token
+	// pool is a stack of tokens pushed back.
+	pool []*token.Token
+	end  []*token.Token
+	// allTrivia makes whitespaces, line terminators, commas and BOM attached
+	// as well as comments.
+	allTrivia bool
 }
 
+// Error is a syntax error found by Lexer.
+type Error struct {
+	Line    int
+	Col     int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at line %d, col %d", e.Message, e.Line, e.Col)
+}
+
+func fail(line, col int, format string, args ...interface{}) {
+	panic(&Error{Line: line, Col: col, Message: fmt.Sprintf(format, args...)})
+}
+
+// NewLexer returns a lexer reading r as needed, which is buffered unless it
+// is an io.RuneReader.
 func NewLexer(r io.Reader) *Lexer {
-	buff := bufio.NewReader(r)
-	var runes []rune
-	for {
-		r, _, e := buff.ReadRune()
-		if e != nil {
-			break
-		}
-		runes = append(runes, r)
-	}
-	return &Lexer{NewScanner(runes), nil}
+	return &Lexer{scanner: NewScanner(r)}
 }
 
 func (l *Lexer) next() *token.Token {
@@ -45,6 +61,16 @@ func (l *Lexer) next() *token.Token {
 		return nil
 	}
 
+	// Names and punctuators, the most common tokens, are tried first. The
+	// order does not matter otherwise as they start with different runes.
+	if s.StartsWith(nameHead) {
+		return takeWhileAndAppend(token.TypeName, nameHead, nameTail)
+	}
+
+	if s.StartsWith(punctuator) {
+		return takeAndAppend(token.TypePunctuator, punctuator)
+	}
+
 	if s.StartsWith(unicodeBom) {
 		return takeAndAppend(token.TypeUnicodeBom, unicodeBom)
 	}
@@ -61,18 +87,10 @@ func (l *Lexer) next() *token.Token {
 		return takeAndAppend(token.TypeComma, comma)
 	}
 
-	if s.StartsWith(punctuator) {
-		return takeAndAppend(token.TypePunctuator, punctuator)
-	}
-
 	if s.StartsWith(commentHead) {
 		return takeWhileAndAppend(token.TypeComment, commentHead, commentTail)
 	}
 
-	if s.StartsWith(nameHead) {
-		return takeWhileAndAppend(token.TypeName, nameHead, nameTail)
-	}
-
 	if s.StartsWith(negative) || s.StartsWith(intVal) {
 		return l.takeNumber()
 	}
@@ -85,47 +103,107 @@ func (l *Lexer) next() *token.Token {
 		return l.takeString()
 	}
 
-	log.Fatalf(
-		"unexpected token %c at line %d, col %d",
-		s.runes[0], s.line, s.col,
-	)
+	r, _ := s.peek(0)
+	fail(s.line, s.col, "unexpected token %c", r)
 	return nil
 }
 
 func (l *Lexer) Pop() *token.Token {
-	if len(l.pool) != 0 {
-		t := l.pool[0]
-		l.pool = l.pool[1:]
+	if n := len(l.pool); n != 0 {
+		t := l.pool[n-1]
+		l.pool = l.pool[:n-1]
 		return t
 	}
-	ignored := map[token.Type]struct{}{
-		token.TypeUnicodeBom:     {},
-		token.TypeWhiteSpace:     {},
-		token.TypeLineTerminator: {},
-		token.TypeComment:        {},
-		token.TypeComma:          {},
-	}
 
+	var leading []*token.Token
 	for {
+		l.skip(ignored)
 		t := l.next()
 		if t == nil {
+			l.end = append(l.end, leading...)
 			return nil
 		}
-		if _, isIgnored := ignored[t.Type()]; !isIgnored {
+		if !t.IsTrivia() {
+			t.AttachTrivia(leading, l.takeTrailingTrivia())
 			return t
 		}
+		if l.keeps(t) {
+			leading = append(leading, t)
+		}
+	}
+}
+
+// EndTrivia returns trivia after the last token. It is available after Pop
+// returns nil.
+func (l *Lexer) EndTrivia() []*token.Token {
+	return l.end
+}
+
+// LineCol returns the position of the rune to be read next, which is the end
+// of the source after Pop returns nil.
+func (l *Lexer) LineCol() (int, int) {
+	return l.scanner.LineCol()
+}
+
+// KeepAllTrivia makes the lexer attach every trivia to tokens, so that the
+// source text around tokens is available. It must be called before Pop.
+func (l *Lexer) KeepAllTrivia() {
+	l.allTrivia = true
+}
+
+func (l *Lexer) keeps(t *token.Token) bool {
+	return l.allTrivia || t.Type() == token.TypeComment
+}
+
+// takeTrailingTrivia takes trivia up to the end of the current line.
+func (l *Lexer) takeTrailingTrivia() []*token.Token {
+	var trailing []*token.Token
+	for {
+		l.skip(inlineIgnored)
+		if !l.allTrivia && l.scanner.StartsWith(lineTerminator) {
+			l.scanner.Take(lineTerminator)
+			break
+		}
+		if !l.scanner.StartsWith(trailingTrivia) {
+			break
+		}
+		t := l.next()
+		if l.keeps(t) {
+			trailing = append(trailing, t)
+		}
+		if t.Type() == token.TypeLineTerminator {
+			break
+		}
+	}
+	return trailing
+}
+
+var (
+	// ignored are trivia other than comments.
+	ignored       = Union(unicodeBom, whiteSpace, lineTerminator, comma)
+	inlineIgnored = Union(whiteSpace, comma)
+	// trailingTrivia starts trivia trailing a token.
+	trailingTrivia = Union(whiteSpace, comma, commentHead, lineTerminator)
+)
+
+// skip drops the trivia matching m without making tokens unless all trivia
+// are kept.
+func (l *Lexer) skip(m Matcher) {
+	if !l.allTrivia {
+		l.scanner.Skip(m)
 	}
 }
 
+// Push pushes back t, which is popped next.
 func (l *Lexer) Push(t *token.Token) {
-	l.pool = append([]*token.Token{t}, l.pool...)
+	l.pool = append(l.pool, t)
 }
 
 func (l *Lexer) takeNumber() *token.Token {
 	s := l.scanner
 	negativeSign, line, col := s.TakeWhileMatch(negative)
 	fatal := func() {
-		log.Fatalf("illegal int token at line %d, col %d", line, col)
+		fail(line, col, "illegal int token")
 	}
 	intPart, _, _ := s.TakeWhileMatch(intVal)
 	if len([]rune(intPart)) == 0 {
@@ -160,10 +238,10 @@ func (l *Lexer) takeNumber() *token.Token {
 func (l *Lexer) takeBlockString() *token.Token {
 	s := l.scanner
 	value, line, col := s.Take(blockStrStart)
-	v, _, _ := s.Take(blockStrChar)
+	v, _, _ := s.TakeWhileMatch(blockStrChar)
 	value += v
 	if !s.StartsWith(blockStrEnd) {
-		log.Fatalf("illegal string at line %d, col %d", line, col)
+		fail(line, col, "illegal string")
 	}
 	v, _, _ = s.Take(blockStrEnd)
 	value += v
@@ -181,7 +259,7 @@ func (l *Lexer) takeString() *token.Token {
 			value += u
 			for i := 0; i < 4; i++ {
 				if !s.StartsWith(hex) {
-					log.Fatalf("illegal unicode escape at line %d, col %d", ul, uc)
+					fail(ul, uc, "illegal unicode escape")
 				}
 				h, _, _ := s.Take(hex)
 				value += h
@@ -193,7 +271,7 @@ func (l *Lexer) takeString() *token.Token {
 			value += v
 			break
 		}
-		log.Fatalf("illegal string at line %d, col %d", line, col)
+		fail(line, col, "illegal string")
 	}
 	return token.NewToken(token.TypeStrVal, value, line, col)
 }
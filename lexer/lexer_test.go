@@ -0,0 +1,153 @@
+package lexer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/RettyEng/gqlcodegen/internal/testschema"
+	"github.com/RettyEng/gqlcodegen/lexer/token"
+)
+
+// tokens returns the types and the values of the tokens of src, or the error
+// the lexer fails with.
+func tokens(src string) (got []string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*Error)
+			if !ok {
+				panic(r)
+			}
+			err = e
+		}
+	}()
+	l := NewLexer(strings.NewReader(src))
+	for t := l.Pop(); t != nil; t = l.Pop() {
+		got = append(got, fmt.Sprintf("%s %s", t.Type(), t.Value()))
+	}
+	return got, nil
+}
+
+func TestStrings(t *testing.T) {
+	str := func(v string) string { return token.TypeStrVal.String() + " " + v }
+	tests := []struct {
+		src  string
+		want []string
+		err  string
+	}{
+		{src: `"a"`, want: []string{str(`"a"`)}},
+		{src: `"a\"b" "é\u00e9\uABcd"`, want: []string{str(`"a\"b"`), str(`"é\u00e9\uABcd"`)}},
+		{src: `"\u00g9"`, err: "illegal unicode escape at line 1, col 2"},
+		{src: `"""a"""`, want: []string{str(`"""a"""`)}},
+		{src: "\"\"\"\n  a \"\" b\n\"\"\"", want: []string{str("\"\"\"\n  a \"\" b\n\"\"\"")}},
+		{src: `"""a \""" b""" """c"""`, want: []string{str(`"""a \""" b"""`), str(`"""c"""`)}},
+		{src: `"""\""""""`, want: []string{str(`"""\""""""`)}},
+		{src: `"""\"""`, err: "illegal string at line 1, col 1"},
+		{src: `"""a`, err: "illegal string at line 1, col 1"},
+	}
+	for _, tt := range tests {
+		got, e := tokens(tt.src)
+		if tt.err != "" {
+			if e == nil || e.Error() != tt.err {
+				t.Errorf("%s: got %q, %v, want error %s", tt.src, got, e, tt.err)
+			}
+			continue
+		}
+		if e != nil {
+			t.Errorf("%s: %v", tt.src, e)
+			continue
+		}
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: got %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+// TestLargeInput checks that tokens longer than the initial lookahead are
+// read whole.
+func TestLargeInput(t *testing.T) {
+	long := strings.Repeat("a", 10000)
+	src := `"""` + long + `\"""` + long + `"""` + " " + long
+	got, e := tokens(src)
+	if e != nil {
+		t.Fatal(e)
+	}
+	want := []string{
+		token.TypeStrVal.String() + " " + src[:len(src)-len(long)-1],
+		token.TypeName.String() + " " + long,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %d tokens, want the block string and the name", len(got))
+	}
+}
+
+var seeds = []string{
+	"",
+	"type A { a: Int }",
+	"\ufeff# comment\r\ntype A implements B & C @d(e: [1, -2.5e3, \"f\\u00e9\"]) {\r  a(b: C = {d: E}): [F!]! # trailing\n}",
+	"\"\"\"\nblock \\\"\"\" string\n\"\"\"\nscalar S",
+	"enum E { A, B, C }\nunion U = | A | B\ninput I { a: Int = 0 }",
+	"query Q($a: Int = 1) { a(b: $a) { ...F ... on T { c } } }\nfragment F on T { d }",
+	"\"unterminated",
+	"01",
+	"\"\\u12\"",
+}
+
+func FuzzLexer(f *testing.F) {
+	for _, s := range seeds {
+		f.Add(s)
+	}
+	f.Add(testschema.Large(2))
+	if b, e := ioutil.ReadFile("../example/schema.graphqls"); e == nil {
+		f.Add(string(b))
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		tokens, ok := lex(src)
+		if !ok || !utf8.ValidString(src) {
+			return
+		}
+		// All the trivia are kept, so the tokens make up the source.
+		var b strings.Builder
+		for _, t := range tokens {
+			b.WriteString(t.Value())
+		}
+		if b.String() != src {
+			t.Errorf("tokens make %q, want %q", b.String(), src)
+		}
+	})
+}
+
+// lex returns the tokens of src with all the trivia in order, or false if
+// src is illegal.
+func lex(src string) (tokens []*token.Token, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isErr := r.(*Error); !isErr {
+				panic(r)
+			}
+			ok = false
+		}
+	}()
+	l := NewLexer(strings.NewReader(src))
+	l.KeepAllTrivia()
+	for t := l.Pop(); t != nil; t = l.Pop() {
+		tokens = append(tokens, t.LeadingTrivia()...)
+		tokens = append(tokens, t)
+		tokens = append(tokens, t.TrailingTrivia()...)
+	}
+	return append(tokens, l.EndTrivia()...), true
+}
+
+func BenchmarkLexer(b *testing.B) {
+	src := testschema.Large(1500)
+	b.SetBytes(int64(len(src)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l := NewLexer(strings.NewReader(src))
+		for l.Pop() != nil {
+		}
+	}
+}
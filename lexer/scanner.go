@@ -1,48 +1,113 @@
 package lexer
 
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
 const (
 	linInit = 1
 	colInit = 1
+
+	// lookaheadInit is the initial size of the lookahead buffer, which is a
+	// power of 2. It doubles when a matcher looks further ahead, such as for
+	// a long block string.
+	lookaheadInit = 64
 )
 
+// Scanner reads runes from a buffered reader. Runes read ahead for matchers
+// are kept in a ring buffer until they are taken.
 type Scanner struct {
-	line  int
-	col   int
-	runes []rune
+	line int
+	col  int
+	r    io.RuneReader
+	// ring holds n runes read ahead from head.
+	ring []rune
+	head int
+	n    int
+	eof  bool
+	// buf is reused to build the values taken.
+	buf []byte
 }
 
-func NewScanner(rs []rune) *Scanner {
+func NewScanner(r io.Reader) *Scanner {
+	rr, ok := r.(io.RuneReader)
+	if !ok {
+		rr = bufio.NewReader(r)
+	}
 	return &Scanner{
-		line:  linInit,
-		col:   colInit,
-		runes: rs,
+		line: linInit,
+		col:  colInit,
+		r:    rr,
+		ring: make([]rune, lookaheadInit),
 	}
 }
 
+// peek returns the i-th rune ahead, or false if the input ends before it.
+func (s *Scanner) peek(i int) (rune, bool) {
+	for i >= s.n {
+		if s.eof {
+			return 0, false
+		}
+		r, _, e := s.r.ReadRune()
+		if e != nil {
+			s.eof = true
+			return 0, false
+		}
+		if s.n == len(s.ring) {
+			s.grow()
+		}
+		s.ring[(s.head+s.n)&(len(s.ring)-1)] = r
+		s.n++
+	}
+	return s.ring[(s.head+i)&(len(s.ring)-1)], true
+}
+
+// grow doubles the ring buffer keeping the runes in order.
+func (s *Scanner) grow() {
+	ring := make([]rune, 2*len(s.ring))
+	for i := 0; i < s.n; i++ {
+		ring[i] = s.ring[(s.head+i)&(len(s.ring)-1)]
+	}
+	s.ring = ring
+	s.head = 0
+}
+
+// Take takes the runes matching matcher once.
 func (s *Scanner) Take(matcher Matcher) (string, int, int) {
-	c := matcher.MatchCount(s.runes)
-	r, line, col := s.PopN(c)
-	return string(r), line, col
+	line, col := s.LineCol()
+	s.buf = s.buf[:0]
+	s.takeN(matcher.Match(s, 0))
+	return string(s.buf), line, col
 }
 
+// TakeWhileMatch takes the runes while they match matcher.
 func (s *Scanner) TakeWhileMatch(matcher Matcher) (string, int, int) {
-	var ret []rune
 	line, col := s.LineCol()
-	c := matcher.MatchCount(s.runes)
-	for c != 0 {
-		r, _, _ := s.PopN(c)
-		ret = append(ret, r...)
-		c = matcher.MatchCount(s.runes)
+	s.buf = s.buf[:0]
+	for c := matcher.Match(s, 0); c != 0; c = matcher.Match(s, 0) {
+		s.takeN(c)
+	}
+	return string(s.buf), line, col
+}
+
+// Skip drops the runes while they match matcher.
+func (s *Scanner) Skip(matcher Matcher) {
+	for c := matcher.Match(s, 0); c != 0; c = matcher.Match(s, 0) {
+		for i := 0; i < c; i++ {
+			s.Pop()
+		}
 	}
-	return string(ret), line, col
 }
 
 func (s *Scanner) StartsWith(m Matcher) bool {
-	return m.HeadMatches(s.runes)
+	return m.Match(s, 0) != 0
 }
 
 func (s *Scanner) HasNext() bool {
-	return len(s.runes) != 0
+	_, ok := s.peek(0)
+	return ok
 }
 
 func (s *Scanner) updateLineCol(popped rune) {
@@ -52,7 +117,7 @@ func (s *Scanner) updateLineCol(popped rune) {
 		s.col = colInit
 		return
 	case '\r':
-		if !s.StartsWith(Str("\n")) {
+		if r, ok := s.peek(0); !ok || r != '\n' {
 			s.line++
 			s.col = colInit
 			return
@@ -64,21 +129,25 @@ func (s *Scanner) updateLineCol(popped rune) {
 func (s *Scanner) LineCol() (int, int) {
 	return s.line, s.col
 }
+
 func (s *Scanner) Pop() (rune, int, int) {
 	line, col := s.LineCol()
-
-	r := s.runes[0]
-	s.runes = s.runes[1:]
+	r, _ := s.peek(0)
+	s.head = (s.head + 1) & (len(s.ring) - 1)
+	s.n--
 	s.updateLineCol(r)
 	return r, line, col
 }
 
-func (s *Scanner) PopN(n int) ([]rune, int, int) {
-	l, c := s.LineCol()
-	rs := make([]rune, n)
+// takeN pops n runes into buf.
+func (s *Scanner) takeN(n int) {
 	for i := 0; i < n; i++ {
 		r, _, _ := s.Pop()
-		rs[i] = r
+		if r < utf8.RuneSelf {
+			s.buf = append(s.buf, byte(r))
+			continue
+		}
+		var b [utf8.UTFMax]byte
+		s.buf = append(s.buf, b[:utf8.EncodeRune(b[:], r)]...)
 	}
-	return rs, l, c
 }
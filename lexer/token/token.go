@@ -5,10 +5,12 @@ type Token struct {
 	value     string
 	line      int
 	col       int
+	leading   []*Token
+	trailing  []*Token
 }
 
 func NewToken(t Type, v string, l, c int) *Token {
-	return &Token{t, v, l, c}
+	return &Token{t, v, l, c, nil, nil}
 }
 
 func (t *Token) Type() Type {
@@ -22,3 +24,28 @@ func (t *Token) Value() string {
 func (t *Token) LineCol() (int, int) {
 	return t.line, t.col
 }
+
+// LeadingTrivia returns trivia preceding the token.
+func (t *Token) LeadingTrivia() []*Token {
+	return t.leading
+}
+
+// TrailingTrivia returns trivia following the token on the same line.
+func (t *Token) TrailingTrivia() []*Token {
+	return t.trailing
+}
+
+// AttachTrivia sets trivia around the token.
+func (t *Token) AttachTrivia(leading, trailing []*Token) {
+	t.leading = leading
+	t.trailing = trailing
+}
+
+// IsTrivia returns whether the token is insignificant for the syntax.
+func (t *Token) IsTrivia() bool {
+	switch t.tokenType {
+	case TypeUnicodeBom, TypeWhiteSpace, TypeLineTerminator, TypeComment, TypeComma:
+		return true
+	}
+	return false
+}
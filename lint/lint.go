@@ -0,0 +1,132 @@
+// Package lint checks a schema against rules of style and design beyond the
+// validity by the specification.
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// Severity is how serious problems found by a rule are.
+type Severity int
+
+const (
+	// Off disables a rule.
+	Off Severity = iota
+	Warning
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	}
+	return "off"
+}
+
+// ParseSeverity returns the severity named s, which is one of "off",
+// "warning" and "error".
+func ParseSeverity(s string) (Severity, error) {
+	for _, sev := range []Severity{Off, Warning, Error} {
+		if sev.String() == s {
+			return sev, nil
+		}
+	}
+	return Off, fmt.Errorf("unknown severity %s", s)
+}
+
+// Report reports a problem at pos.
+type Report func(pos gql.Position, format string, args ...interface{})
+
+// Rule checks a schema and reports problems.
+type Rule interface {
+	// Name is the name of the rule used in configurations and suppression
+	// comments, such as type-name-pascal-case.
+	Name() string
+	Check(ts *gql.TypeSystem, report Report)
+}
+
+// Problem is a violation of a rule.
+type Problem struct {
+	Rule     string
+	Severity Severity
+	Position gql.Position
+	Message  string
+}
+
+func (p *Problem) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", p.Position, p.Severity, p.Message, p.Rule)
+}
+
+// Config is a set of rules and their severities.
+type Config struct {
+	Rules []Rule
+	// Severity holds severities of rules by their names. Rules missing in it
+	// are warnings.
+	Severity map[string]Severity
+}
+
+// DefaultConfig returns the configuration with DefaultRules as warnings.
+func DefaultConfig() *Config {
+	return &Config{
+		Rules:    DefaultRules(),
+		Severity: map[string]Severity{},
+	}
+}
+
+func (c *Config) severity(rule string) Severity {
+	if s, ok := c.Severity[rule]; ok {
+		return s
+	}
+	return Warning
+}
+
+// Lint checks ts by the rules of conf and returns problems which are not
+// suppressed by s, sorted by their positions. s may be nil.
+func Lint(ts *gql.TypeSystem, conf *Config, s *Suppressions) []*Problem {
+	var problems []*Problem
+	for _, r := range conf.Rules {
+		sev := conf.severity(r.Name())
+		if sev == Off {
+			continue
+		}
+		name := r.Name()
+		r.Check(ts, func(pos gql.Position, format string, args ...interface{}) {
+			if s.suppresses(name, pos) {
+				return
+			}
+			problems = append(problems, &Problem{
+				Rule:     name,
+				Severity: sev,
+				Position: pos,
+				Message:  fmt.Sprintf(format, args...),
+			})
+		})
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i].Position, problems[j].Position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Col < b.Col
+	})
+	return problems
+}
+
+// HasErrors returns whether problems contain an error.
+func HasErrors(problems []*Problem) bool {
+	for _, p := range problems {
+		if p.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+package lint
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/parser"
+)
+
+const filename = "schema.graphqls"
+
+func parse(t *testing.T, src string) *gql.TypeSystem {
+	t.Helper()
+	top, e := parser.NewFileParser(filename, strings.NewReader(src)).Parse()
+	if e != nil {
+		t.Fatal(e)
+	}
+	ts, e := top.Eval()
+	if e != nil {
+		t.Fatal(e)
+	}
+	return ts
+}
+
+func rule(name string) Rule {
+	for _, r := range DefaultRules() {
+		if r.Name() == name {
+			return r
+		}
+	}
+	return nil
+}
+
+func lint(t *testing.T, src string, conf *Config, s *Suppressions) []string {
+	t.Helper()
+	var got []string
+	for _, p := range Lint(parse(t, src), conf, s) {
+		got = append(got, p.String())
+	}
+	return got
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		rule string
+		src  string
+		want []string
+	}{
+		{
+			rule: "type-name-pascal-case",
+			src:  "type Query { a: truck_kind b: Maker }\nenum truck_kind { A }\nenum Maker { A }",
+			want: []string{"schema.graphqls:2:6: warning: enum name truck_kind should be PascalCase (type-name-pascal-case)"},
+		},
+		{
+			rule: "field-name-camel-case",
+			src:  "type Query { maker_name: String makerName: String }",
+			want: []string{"schema.graphqls:1:14: warning: field name Query.maker_name should be camelCase (field-name-camel-case)"},
+		},
+		{
+			rule: "argument-name-camel-case",
+			src:  "type Query { truck(TruckID: ID, id: ID): String }",
+			want: []string{"schema.graphqls:1:20: warning: argument name TruckID of Query.truck should be camelCase (argument-name-camel-case)"},
+		},
+		{
+			rule: "enum-value-upper-case",
+			src:  "type Query { m: Maker }\nenum Maker { Hino ISUZU_MOTORS }",
+			want: []string{"schema.graphqls:2:14: warning: enum value Maker.Hino should be UPPER_CASE (enum-value-upper-case)"},
+		},
+		{
+			rule: "description-required",
+			src:  "\"Root\"\ntype Query {\n  \"A truck\"\n  truck: String\n  garage: String\n}",
+			want: []string{"schema.graphqls:5:3: warning: field Query.garage should have a description (description-required)"},
+		},
+		{
+			rule: "deprecation-reason-required",
+			src:  "type Query { a: String @deprecated b: String @deprecated(reason: \"Use a\") }",
+			want: []string{"schema.graphqls:1:25: warning: deprecation of Query.a should have a reason (deprecation-reason-required)"},
+		},
+		{
+			rule: "input-type-suffix",
+			src:  "type Query { a(f: Filter, s: SortInput): String }\ninput Filter { a: Int }\ninput SortInput { a: Int }",
+			want: []string{"schema.graphqls:2:7: warning: input Filter should be suffixed with Input (input-type-suffix)"},
+		},
+		{
+			rule: "no-unused-types",
+			src:  "type Query { a: Used }\ntype Used { a: Int }\ntype Unused { a: Int }",
+			want: []string{"schema.graphqls:3:6: warning: type Unused is not used (no-unused-types)"},
+		},
+		{
+			rule: "list-field-pagination",
+			src:  "type Query { a: [T] b(first: Int): [T] c: [Int] d: T }\ntype T { a: Int }",
+			want: []string{"schema.graphqls:1:14: warning: list field Query.a should take an argument such as first or limit for pagination (list-field-pagination)"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.rule, func(t *testing.T) {
+			r := rule(tt.rule)
+			if r == nil {
+				t.Fatalf("rule %s is not a default rule", tt.rule)
+			}
+			got := lint(t, tt.src, &Config{Rules: []Rule{r}}, nil)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	const src = "type Query { a_b: [Query] }"
+	tests := []struct {
+		severity  map[string]Severity
+		want      []string
+		hasErrors bool
+	}{
+		{
+			severity: map[string]Severity{"description-required": Off},
+			want: []string{
+				"schema.graphqls:1:14: warning: field name Query.a_b should be camelCase (field-name-camel-case)",
+				"schema.graphqls:1:14: warning: list field Query.a_b should take an argument such as first or limit for pagination (list-field-pagination)",
+			},
+		},
+		{
+			severity: map[string]Severity{"description-required": Off, "list-field-pagination": Off, "field-name-camel-case": Error},
+			want: []string{
+				"schema.graphqls:1:14: error: field name Query.a_b should be camelCase (field-name-camel-case)",
+			},
+			hasErrors: true,
+		},
+		{
+			severity: map[string]Severity{"description-required": Off, "list-field-pagination": Off, "field-name-camel-case": Off},
+		},
+	}
+	for _, tt := range tests {
+		conf := DefaultConfig()
+		conf.Severity = tt.severity
+		ts := parse(t, src)
+		problems := Lint(ts, conf, nil)
+		var got []string
+		for _, p := range problems {
+			got = append(got, p.String())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("severity %v: got %q\nwant %q", tt.severity, got, tt.want)
+		}
+		if HasErrors(problems) != tt.hasErrors {
+			t.Errorf("severity %v: HasErrors is %v", tt.severity, !tt.hasErrors)
+		}
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	for _, sev := range []Severity{Off, Warning, Error} {
+		got, e := ParseSeverity(sev.String())
+		if e != nil || got != sev {
+			t.Errorf("ParseSeverity(%q) = %v, %v", sev.String(), got, e)
+		}
+	}
+	if _, e := ParseSeverity("fatal"); e == nil {
+		t.Errorf("ParseSeverity(%q) succeeded", "fatal")
+	}
+}
+
+func TestSuppressions(t *testing.T) {
+	conf := &Config{Rules: []Rule{rule("field-name-camel-case"), rule("argument-name-camel-case"), rule("enum-value-upper-case")}}
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "none",
+			src:  "type Query {\n  a_b: Int\n  c_d: Int\n}",
+			want: []string{"2:3: field-name-camel-case", "3:3: field-name-camel-case"},
+		},
+		{
+			name: "trailing",
+			src:  "type Query {\n  a_b: Int # lint-disable field-name-camel-case\n  c_d: Int\n}",
+			want: []string{"3:3: field-name-camel-case"},
+		},
+		{
+			name: "trailing another rule",
+			src:  "type Query {\n  a_b: Int # lint-disable enum-value-upper-case\n  c_d: Int\n}",
+			want: []string{"2:3: field-name-camel-case", "3:3: field-name-camel-case"},
+		},
+		{
+			name: "own line before a type",
+			src:  "# lint-disable field-name-camel-case\n\"Root\"\ntype Query {\n  a_b: Int\n  c_d(\n    x: Int\n  ): Int\n}\ntype Other {\n  e_f: Int\n}",
+			want: []string{"10:3: field-name-camel-case"},
+		},
+		{
+			name: "own line before a type on one line",
+			src:  "# lint-disable\ntype Query { a_b: Int }\nenum Maker { hino }\n",
+			want: []string{"3:14: enum-value-upper-case"},
+		},
+		{
+			name: "own line before a field",
+			src:  "type Query {\n  # lint-disable\n  \"A field\"\n  a_b(\n    x_y: Int\n  ): Int\n  c_d(x_y: Int): Int\n}",
+			want: []string{"7:3: field-name-camel-case", "7:7: argument-name-camel-case"},
+		},
+		{
+			name: "own line before an enum value",
+			src:  "enum Maker {\n  # lint-disable\n  hino @deprecated(\n    reason: \"gone\"\n  )\n  isuzu\n}",
+			want: []string{"6:3: enum-value-upper-case"},
+		},
+		{
+			name: "own line before a union",
+			src:  "# lint-disable\nunion U =\n  | A\n  | B\nenum Maker {\n  hino\n}\ntype A { a_b: Int }\ntype B { a: Int }",
+			want: []string{"6:3: enum-value-upper-case", "8:10: field-name-camel-case"},
+		},
+		{
+			name: "own line before an extension",
+			src:  "type Query { a: Int }\n# lint-disable field-name-camel-case\nextend type Query {\n  b_c: Int\n}\nextend type Query {\n  d_e: Int\n}",
+			want: []string{"7:3: field-name-camel-case"},
+		},
+		{
+			name: "file",
+			src:  "# lint-disable-file field-name-camel-case\ntype Query {\n  a_b: Int\n}\nenum Maker { hino }",
+			want: []string{"5:14: enum-value-upper-case"},
+		},
+		{
+			name: "file all rules",
+			src:  "type Query {\n  a_b: Int\n}\nenum Maker { hino }\n# lint-disable-file",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSuppressions()
+			if e := s.Add(filename, strings.NewReader(tt.src)); e != nil {
+				t.Fatal(e)
+			}
+			var got []string
+			for _, p := range Lint(parse(t, tt.src), conf, s) {
+				got = append(got, strings.TrimPrefix(p.Position.String(), filename+":")+": "+p.Rule)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// DefaultRules returns the rules checked by default.
+func DefaultRules() []Rule {
+	return []Rule{
+		typeNamePascalCase{},
+		fieldNameCamelCase{},
+		argumentNameCamelCase{},
+		enumValueUpperCase{},
+		descriptionRequired{},
+		deprecationReasonRequired{},
+		inputTypeSuffix{},
+		noUnusedTypes{},
+		listFieldPagination{},
+	}
+}
+
+var (
+	pascalCase = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	camelCase  = regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`)
+	upperCase  = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+// definition is a named definition with its position.
+type definition struct {
+	kind string
+	name string
+	pos  gql.Position
+	def  gql.Commentable
+}
+
+// types returns the type definitions of ts.
+func types(ts *gql.TypeSystem) []*definition {
+	var defs []*definition
+	for _, t := range ts.ScalarTypes {
+		defs = append(defs, &definition{"scalar", t.Name, t.Position, t})
+	}
+	for _, t := range ts.ObjectTypes {
+		defs = append(defs, &definition{"type", t.Name, t.Position, t})
+	}
+	for _, t := range ts.InterfaceTypes {
+		defs = append(defs, &definition{"interface", t.Name, t.Position, t})
+	}
+	for _, t := range ts.UnionTypes {
+		defs = append(defs, &definition{"union", t.Name, t.Position, t})
+	}
+	for _, t := range ts.EnumTypes {
+		defs = append(defs, &definition{"enum", t.Name, t.Position, t})
+	}
+	for _, t := range ts.InputObjectTypes {
+		defs = append(defs, &definition{"input", t.Name, t.Position, t})
+	}
+	return defs
+}
+
+// fields returns the fields of objects and interfaces and the input fields
+// of ts, named with their types.
+func fields(ts *gql.TypeSystem) []*definition {
+	var defs []*definition
+	for _, t := range ts.ObjectTypes {
+		for _, f := range t.Fields {
+			defs = append(defs, &definition{"field", t.Name + "." + f.Name, f.Position, f})
+		}
+	}
+	for _, t := range ts.InterfaceTypes {
+		for _, f := range t.Fields {
+			defs = append(defs, &definition{"field", t.Name + "." + f.Name, f.Position, f})
+		}
+	}
+	for _, t := range ts.InputObjectTypes {
+		for _, v := range t.InputValue {
+			defs = append(defs, &definition{"input field", t.Name + "." + v.Name, v.Position, v})
+		}
+	}
+	return defs
+}
+
+// shortName returns the last part of a name returned by fields.
+func shortName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+type typeNamePascalCase struct{}
+
+func (typeNamePascalCase) Name() string {
+	return "type-name-pascal-case"
+}
+
+func (typeNamePascalCase) Check(ts *gql.TypeSystem, report Report) {
+	for _, t := range types(ts) {
+		if !pascalCase.MatchString(t.name) {
+			report(t.pos, "%s name %s should be PascalCase", t.kind, t.name)
+		}
+	}
+}
+
+type fieldNameCamelCase struct{}
+
+func (fieldNameCamelCase) Name() string {
+	return "field-name-camel-case"
+}
+
+func (fieldNameCamelCase) Check(ts *gql.TypeSystem, report Report) {
+	for _, f := range fields(ts) {
+		if !camelCase.MatchString(shortName(f.name)) {
+			report(f.pos, "%s name %s should be camelCase", f.kind, f.name)
+		}
+	}
+}
+
+type argumentNameCamelCase struct{}
+
+func (argumentNameCamelCase) Name() string {
+	return "argument-name-camel-case"
+}
+
+func (argumentNameCamelCase) Check(ts *gql.TypeSystem, report Report) {
+	check := func(owner string, args []*gql.InputValue) {
+		for _, a := range args {
+			if !camelCase.MatchString(a.Name) {
+				report(a.Position, "argument name %s of %s should be camelCase", a.Name, owner)
+			}
+		}
+	}
+	for _, t := range ts.ObjectTypes {
+		for _, f := range t.Fields {
+			check(t.Name+"."+f.Name, f.Args)
+		}
+	}
+	for _, t := range ts.InterfaceTypes {
+		for _, f := range t.Fields {
+			check(t.Name+"."+f.Name, f.Args)
+		}
+	}
+	for _, d := range ts.Directives {
+		check("@"+d.Name, d.Arguments)
+	}
+}
+
+type enumValueUpperCase struct{}
+
+func (enumValueUpperCase) Name() string {
+	return "enum-value-upper-case"
+}
+
+func (enumValueUpperCase) Check(ts *gql.TypeSystem, report Report) {
+	for _, e := range ts.EnumTypes {
+		for _, v := range e.Values {
+			if !upperCase.MatchString(v.Name) {
+				report(v.Position, "enum value %s.%s should be UPPER_CASE", e.Name, v.Name)
+			}
+		}
+	}
+}
+
+type descriptionRequired struct{}
+
+func (descriptionRequired) Name() string {
+	return "description-required"
+}
+
+// Check reports types, fields and input fields without descriptions.
+func (descriptionRequired) Check(ts *gql.TypeSystem, report Report) {
+	for _, t := range types(ts) {
+		if t.def.GetDescription() == "" {
+			report(t.pos, "%s %s should have a description", t.kind, t.name)
+		}
+	}
+	for _, f := range fields(ts) {
+		if f.def.GetDescription() == "" {
+			report(f.pos, "%s %s should have a description", f.kind, f.name)
+		}
+	}
+}
+
+type deprecationReasonRequired struct{}
+
+func (deprecationReasonRequired) Name() string {
+	return "deprecation-reason-required"
+}
+
+func (deprecationReasonRequired) Check(ts *gql.TypeSystem, report Report) {
+	check := func(what string, dirs []*gql.DirectiveRef) {
+		for _, d := range dirs {
+			if d.Name != "deprecated" {
+				continue
+			}
+			r, ok := d.Args["reason"]
+			if !ok || strings.TrimSpace(gql.StringValue(r.Value())) == "" || r.Value() == "null" {
+				report(d.Position, "deprecation of %s should have a reason", what)
+			}
+		}
+	}
+	for _, t := range types(ts) {
+		check(t.name, t.def.GetDirectives())
+	}
+	for _, f := range fields(ts) {
+		check(f.name, f.def.GetDirectives())
+	}
+	for _, e := range ts.EnumTypes {
+		for _, v := range e.Values {
+			check(e.Name+"."+v.Name, v.Directives)
+		}
+	}
+}
+
+type inputTypeSuffix struct{}
+
+func (inputTypeSuffix) Name() string {
+	return "input-type-suffix"
+}
+
+func (inputTypeSuffix) Check(ts *gql.TypeSystem, report Report) {
+	for _, t := range ts.InputObjectTypes {
+		if !strings.HasSuffix(t.Name, "Input") {
+			report(t.Position, "input %s should be suffixed with Input", t.Name)
+		}
+	}
+}
+
+type noUnusedTypes struct{}
+
+func (noUnusedTypes) Name() string {
+	return "no-unused-types"
+}
+
+// Check reports types which are not reachable from the root operation
+// types. Objects implementing a reachable interface are reachable.
+func (noUnusedTypes) Check(ts *gql.TypeSystem, report Report) {
+	used := map[string]bool{}
+	var visit func(name string)
+	visitFields := func(fs []*gql.ObjectField) {
+		for _, f := range fs {
+			visit(f.Type.NamedType())
+			for _, a := range f.Args {
+				visit(a.Type.NamedType())
+			}
+		}
+	}
+	visit = func(name string) {
+		if used[name] {
+			return
+		}
+		used[name] = true
+		if o, ok := ts.ObjectTypes[name]; ok {
+			for _, i := range o.Implements {
+				visit(i.Name)
+			}
+			visitFields(o.Fields)
+		}
+		if i, ok := ts.InterfaceTypes[name]; ok {
+			visitFields(i.Fields)
+			for n, o := range ts.ObjectTypes {
+				for _, impl := range o.Implements {
+					if impl.Name == i.Name {
+						visit(n)
+					}
+				}
+			}
+		}
+		if u, ok := ts.UnionTypes[name]; ok {
+			for _, m := range u.Members {
+				visit(m.Name)
+			}
+		}
+		if i, ok := ts.InputObjectTypes[name]; ok {
+			for _, v := range i.InputValue {
+				visit(v.Type.NamedType())
+			}
+		}
+	}
+
+	roots := []struct {
+		ref  *gql.TypeRef
+		name string
+	}{
+		{ts.Schema.Query, "Query"},
+		{ts.Schema.Mutation, "Mutation"},
+		{ts.Schema.Subscription, "Subscription"},
+	}
+	for _, r := range roots {
+		if r.ref != nil {
+			visit(r.ref.Name)
+		} else if _, ok := ts.ObjectTypes[r.name]; ok {
+			visit(r.name)
+		}
+	}
+	for _, d := range ts.Directives {
+		for _, a := range d.Arguments {
+			visit(a.Type.NamedType())
+		}
+	}
+	for _, t := range types(ts) {
+		if !used[t.name] {
+			report(t.pos, "%s %s is not used", t.kind, t.name)
+		}
+	}
+}
+
+// paginationArgs are names of arguments limiting the number of items.
+var paginationArgs = map[string]bool{
+	"first": true, "last": true, "limit": true, "size": true,
+}
+
+type listFieldPagination struct{}
+
+func (listFieldPagination) Name() string {
+	return "list-field-pagination"
+}
+
+// Check reports fields returning lists of objects, interfaces or unions
+// without arguments to limit their length, such as first or limit.
+func (listFieldPagination) Check(ts *gql.TypeSystem, report Report) {
+	check := func(typ string, fs []*gql.ObjectField) {
+		for _, f := range fs {
+			if !isList(f.Type) || !isComposite(ts, f.Type.NamedType()) {
+				continue
+			}
+			paginated := false
+			for _, a := range f.Args {
+				paginated = paginated || paginationArgs[a.Name]
+			}
+			if !paginated {
+				report(f.Position, "list field %s.%s should take an argument such as first or limit for pagination", typ, f.Name)
+			}
+		}
+	}
+	for _, t := range ts.ObjectTypes {
+		check(t.Name, t.Fields)
+	}
+	for _, t := range ts.InterfaceTypes {
+		check(t.Name, t.Fields)
+	}
+}
+
+func isList(ref *gql.TypeRef) bool {
+	return ref.Name == "[]"
+}
+
+func isComposite(ts *gql.TypeSystem, name string) bool {
+	_, obj := ts.ObjectTypes[name]
+	_, iface := ts.InterfaceTypes[name]
+	_, union := ts.UnionTypes[name]
+	return obj || iface || union
+}
@@ -0,0 +1,218 @@
+package lint
+
+import (
+	"io"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/ast"
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/lexer"
+	"github.com/RettyEng/gqlcodegen/lexer/token"
+)
+
+const (
+	disableComment     = "lint-disable"
+	disableFileComment = "lint-disable-file"
+)
+
+// Suppressions are problems suppressed by comments in schema files.
+//
+// A comment "# lint-disable rule-a, rule-b" suppresses the rules on the line
+// it trails. On its own line, it suppresses them on all the lines of the
+// definition following it, which is a type or directive definition at the top
+// level, or a field, argument or enum value in a type.
+// "# lint-disable-file rule-a" suppresses the rules in the whole file.
+// Without rule names, all the rules are suppressed.
+type Suppressions struct {
+	// lines holds rules suppressed in each file and line. The rule "" means
+	// all the rules.
+	lines map[string]map[int]map[string]bool
+	files map[string]map[string]bool
+}
+
+func NewSuppressions() *Suppressions {
+	return &Suppressions{
+		lines: map[string]map[int]map[string]bool{},
+		files: map[string]map[string]bool{},
+	}
+}
+
+// Add reads suppression comments in the schema source of filename. The
+// source must be lexically valid.
+func (s *Suppressions) Add(filename string, r io.Reader) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*lexer.Error)
+			if !ok {
+				panic(r)
+			}
+			err = e
+		}
+	}()
+
+	l := lexer.NewLexer(r)
+	// pending holds rules of comments on their own lines which apply to the
+	// next definition. Descriptions are skipped as the definition follows.
+	var pending []string
+	// spans are definitions with suppressed rules whose ends are not reached.
+	var spans []*span
+	// prev holds the previous token in each nesting depth of brackets.
+	prev := []*token.Token{nil}
+	for t := l.Pop(); t != nil; t = l.Pop() {
+		line, _ := t.LineCol()
+		depth := len(prev) - 1
+		if isClosing(t) && depth > 0 {
+			depth--
+			prev = prev[:depth+1]
+			spans = s.end(filename, spans, depth+1)
+		} else if startsDefinition(t, depth, prev[depth]) {
+			spans = s.end(filename, spans, depth)
+		}
+		if t.Type() != token.TypeStrVal {
+			for _, sp := range spans {
+				sp.last = line
+			}
+		}
+		for _, c := range ast.Comments(t.LeadingTrivia()) {
+			pending = append(pending, s.parse(filename, c)...)
+		}
+		if t.Type() != token.TypeStrVal && pending != nil {
+			spans = append(spans, &span{depth: depth, first: line, last: line, rules: pending})
+			pending = nil
+		}
+		for _, c := range ast.Comments(t.TrailingTrivia()) {
+			s.suppress(filename, line, s.parse(filename, c))
+		}
+		prev[depth] = t
+		if isOpening(t) {
+			prev = append(prev, t)
+		}
+	}
+	s.end(filename, spans, 0)
+	for _, c := range ast.Comments(l.EndTrivia()) {
+		s.parse(filename, c)
+	}
+	return nil
+}
+
+// parse returns rules of the line suppression comment c, or nil if c is not
+// one. File suppressions are recorded.
+func (s *Suppressions) parse(filename string, c *token.Token) []string {
+	fields := strings.Fields(strings.Replace(strings.TrimPrefix(c.Value(), "#"), ",", " ", -1))
+	if len(fields) == 0 {
+		return nil
+	}
+	rules := fields[1:]
+	if len(rules) == 0 {
+		rules = []string{""}
+	}
+	switch fields[0] {
+	case disableComment:
+		return rules
+	case disableFileComment:
+		if s.files[filename] == nil {
+			s.files[filename] = map[string]bool{}
+		}
+		for _, r := range rules {
+			s.files[filename][r] = true
+		}
+	}
+	return nil
+}
+
+// span is the lines of a definition following a suppression comment.
+type span struct {
+	// depth is the nesting depth of brackets of the definition.
+	depth       int
+	first, last int
+	rules       []string
+}
+
+// end suppresses the rules of spans at depth or deeper, which end before the
+// current token, and returns the rest.
+func (s *Suppressions) end(filename string, spans []*span, depth int) []*span {
+	var rest []*span
+	for _, sp := range spans {
+		if sp.depth < depth {
+			rest = append(rest, sp)
+			continue
+		}
+		for line := sp.first; line <= sp.last; line++ {
+			s.suppress(filename, line, sp.rules)
+		}
+	}
+	return rest
+}
+
+// definitionKeywords start definitions at the top level.
+var definitionKeywords = map[string]bool{
+	"schema": true, "scalar": true, "type": true, "interface": true, "union": true,
+	"enum": true, "input": true, "directive": true, "extend": true,
+}
+
+// startsDefinition returns whether t, preceded by prev in the same depth,
+// starts a definition. At the top level definitions start with keywords, and
+// in brackets fields, arguments and enum values start with names which are
+// not types, directives or values.
+func startsDefinition(t *token.Token, depth int, prev *token.Token) bool {
+	if t.Type() != token.TypeName {
+		return false
+	}
+	if depth == 0 {
+		if !definitionKeywords[t.Value()] || prev == nil {
+			return definitionKeywords[t.Value()]
+		}
+		switch prev.Value() {
+		case "=", "|", "&", "@", ":", "implements", "on":
+			return false
+		}
+		return !definitionKeywords[prev.Value()]
+	}
+	switch prev.Value() {
+	case ":", "@", "=":
+		return prev.Type() != token.TypePunctuator
+	}
+	return true
+}
+
+func isOpening(t *token.Token) bool {
+	if t.Type() != token.TypePunctuator {
+		return false
+	}
+	v := t.Value()
+	return v == "{" || v == "(" || v == "["
+}
+
+func isClosing(t *token.Token) bool {
+	if t.Type() != token.TypePunctuator {
+		return false
+	}
+	v := t.Value()
+	return v == "}" || v == ")" || v == "]"
+}
+
+func (s *Suppressions) suppress(filename string, line int, rules []string) {
+	if len(rules) == 0 {
+		return
+	}
+	if s.lines[filename] == nil {
+		s.lines[filename] = map[int]map[string]bool{}
+	}
+	if s.lines[filename][line] == nil {
+		s.lines[filename][line] = map[string]bool{}
+	}
+	for _, r := range rules {
+		s.lines[filename][line][r] = true
+	}
+}
+
+func (s *Suppressions) suppresses(rule string, pos gql.Position) bool {
+	if s == nil {
+		return false
+	}
+	if f := s.files[pos.Filename]; f[rule] || f[""] {
+		return true
+	}
+	l := s.lines[pos.Filename][pos.Line]
+	return l[rule] || l[""]
+}
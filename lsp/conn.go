@@ -0,0 +1,53 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+)
+
+// conn reads and writes JSON-RPC messages framed by Content-Length headers.
+type conn struct {
+	r *bufio.Reader
+	w io.Writer
+}
+
+func (c *conn) read() (*message, error) {
+	header, e := textproto.NewReader(c.r).ReadMIMEHeader()
+	if e != nil {
+		return nil, e
+	}
+	length, e := strconv.Atoi(header.Get("Content-Length"))
+	if e != nil {
+		return nil, fmt.Errorf("illegal Content-Length: %v", e)
+	}
+	body := make([]byte, length)
+	if _, e := io.ReadFull(c.r, body); e != nil {
+		return nil, e
+	}
+	msg := &message{}
+	if e := json.Unmarshal(body, msg); e != nil {
+		return nil, &responseError{Code: codeParseError, Message: e.Error()}
+	}
+	return msg, nil
+}
+
+// write writes a response or a notification.
+func (c *conn) write(msg interface{}) error {
+	body, e := json.Marshal(msg)
+	if e != nil {
+		return e
+	}
+	if _, e := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(body)); e != nil {
+		return e
+	}
+	_, e = c.w.Write(body)
+	return e
+}
+
+func (e *responseError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,243 @@
+// Package lsp implements a language server of GraphQL schemas and
+// operations speaking the Language Server Protocol.
+//
+// Schema files (*.graphqls) under the workspace root and the documents
+// opened by the client make the schema. Operation documents (*.graphql) are
+// validated against it.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// Server is a language server reading requests from r and writing
+// responses to w.
+type Server struct {
+	conn      *conn
+	ws        *workspace
+	published map[string]bool
+}
+
+func NewServer(r io.Reader, w io.Writer) *Server {
+	return &Server{
+		conn:      &conn{r: bufio.NewReader(r), w: w},
+		ws:        newWorkspace(),
+		published: map[string]bool{},
+	}
+}
+
+// Serve handles messages until the client sends exit or closes the
+// connection.
+func (s *Server) Serve() error {
+	for {
+		msg, e := s.conn.read()
+		if e == io.EOF {
+			return nil
+		}
+		if re, ok := e.(*responseError); ok {
+			if e := s.conn.write(&errorResponse{JSONRPC: "2.0", Error: re}); e != nil {
+				return e
+			}
+			continue
+		}
+		if e != nil {
+			return e
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		if e := s.handle(msg); e != nil {
+			return e
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) error {
+	result, rerr := s.dispatch(msg)
+	if msg.ID == nil {
+		if s.isChange(msg.Method) && rerr == nil {
+			return s.publishDiagnostics()
+		}
+		return nil
+	}
+	if rerr != nil {
+		return s.conn.write(&errorResponse{JSONRPC: "2.0", ID: msg.ID, Error: rerr})
+	}
+	return s.conn.write(&response{JSONRPC: "2.0", ID: msg.ID, Result: result})
+}
+
+// isChange returns whether the notification method changes documents.
+func (s *Server) isChange(method string) bool {
+	switch method {
+	case "initialized", "textDocument/didOpen", "textDocument/didChange", "textDocument/didClose":
+		return true
+	}
+	return false
+}
+
+func (s *Server) dispatch(msg *message) (interface{}, *responseError) {
+	switch msg.Method {
+	case "initialize":
+		p := &initializeParams{}
+		if e := unmarshal(msg.Params, p); e != nil {
+			return nil, e
+		}
+		if root := uriToPath(p.RootURI); root != "" {
+			s.ws.load(root)
+		}
+		return &initializeResult{Capabilities: serverCapabilities{
+			TextDocumentSync:       textDocumentSyncFull,
+			DefinitionProvider:     true,
+			HoverProvider:          true,
+			CompletionProvider:     &completionOptions{TriggerCharacters: []string{"@"}},
+			DocumentSymbolProvider: true,
+		}}, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		p := &didOpenParams{}
+		if e := unmarshal(msg.Params, p); e != nil {
+			return nil, e
+		}
+		s.ws.docs[p.TextDocument.URI] = p.TextDocument.Text
+		return nil, nil
+	case "textDocument/didChange":
+		p := &didChangeParams{}
+		if e := unmarshal(msg.Params, p); e != nil {
+			return nil, e
+		}
+		if n := len(p.ContentChanges); n > 0 {
+			s.ws.docs[p.TextDocument.URI] = p.ContentChanges[n-1].Text
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		p := &didCloseParams{}
+		if e := unmarshal(msg.Params, p); e != nil {
+			return nil, e
+		}
+		s.ws.close(p.TextDocument.URI)
+		return nil, nil
+	case "textDocument/definition":
+		p := &textDocumentPositionParams{}
+		if e := unmarshal(msg.Params, p); e != nil {
+			return nil, e
+		}
+		return s.definition(p), nil
+	case "textDocument/hover":
+		p := &textDocumentPositionParams{}
+		if e := unmarshal(msg.Params, p); e != nil {
+			return nil, e
+		}
+		return s.hover(p), nil
+	case "textDocument/completion":
+		p := &textDocumentPositionParams{}
+		if e := unmarshal(msg.Params, p); e != nil {
+			return nil, e
+		}
+		return s.completion(p), nil
+	case "textDocument/documentSymbol":
+		p := &documentSymbolParams{}
+		if e := unmarshal(msg.Params, p); e != nil {
+			return nil, e
+		}
+		return s.ws.symbols(p.TextDocument.URI), nil
+	}
+	if msg.ID == nil {
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+}
+
+func unmarshal(params json.RawMessage, v interface{}) *responseError {
+	if len(params) == 0 {
+		return nil
+	}
+	if e := json.Unmarshal(params, v); e != nil {
+		return &responseError{Code: codeInvalidParams, Message: e.Error()}
+	}
+	return nil
+}
+
+// publishDiagnostics sends diagnostics of every document, including empty
+// ones to clear diagnostics sent before.
+func (s *Server) publishDiagnostics() error {
+	diags := s.ws.analyze()
+	for uri := range s.published {
+		if _, ok := diags[uri]; !ok {
+			diags[uri] = []Diagnostic{}
+		}
+	}
+	var uris []string
+	for uri := range diags {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	for _, uri := range uris {
+		if len(diags[uri]) == 0 && !s.published[uri] {
+			continue
+		}
+		s.published[uri] = len(diags[uri]) > 0
+		n := &notification{
+			JSONRPC: "2.0",
+			Method:  "textDocument/publishDiagnostics",
+			Params:  &publishDiagnosticsParams{URI: uri, Diagnostics: diags[uri]},
+		}
+		if e := s.conn.write(n); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func (s *Server) definition(p *textDocumentPositionParams) []Location {
+	name, _, isDirective := s.ws.wordAt(p.TextDocument.URI, p.Position)
+	if name == "" {
+		return []Location{}
+	}
+	pos, ok := s.ws.position(name, isDirective)
+	if !ok {
+		return []Location{}
+	}
+	return []Location{{URI: pos.Filename, Range: toRange(pos, nameLength(name))}}
+}
+
+func (s *Server) hover(p *textDocumentPositionParams) *Hover {
+	name, r, isDirective := s.ws.wordAt(p.TextDocument.URI, p.Position)
+	if name == "" {
+		return nil
+	}
+	var text string
+	if isDirective {
+		text = s.ws.directive(name)
+	} else {
+		text = s.ws.typeSDL(name)
+	}
+	if text == "" {
+		return nil
+	}
+	return &Hover{
+		Contents: markupContent{Kind: "markdown", Value: "```graphql\n" + text + "\n```"},
+		Range:    &r,
+	}
+}
+
+// completion returns directive names after @ and type names otherwise.
+func (s *Server) completion(p *textDocumentPositionParams) []CompletionItem {
+	_, _, isDirective := s.ws.wordAt(p.TextDocument.URI, p.Position)
+	items := []CompletionItem{}
+	if isDirective {
+		for _, name := range s.ws.directiveNames() {
+			items = append(items, CompletionItem{Label: name, Kind: completionFunction, Detail: "directive"})
+		}
+		return items
+	}
+	for _, t := range s.ws.typeNames() {
+		items = append(items, CompletionItem{Label: t.name, Kind: completionClass, Detail: t.kind})
+	}
+	return items
+}
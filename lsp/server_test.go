@@ -0,0 +1,354 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const (
+	schemaURI = "file:///workspace/schema.graphqls"
+	opsURI    = "file:///workspace/ops.graphql"
+)
+
+const schemaSrc = `"A truck"
+type Truck {
+  maker: Maker! @cache
+  driver: Driver
+}
+
+type Query {
+  truck: Truck
+}
+
+enum Maker {
+  HINO
+  ISUZU
+}
+
+directive @cache(ttl: Int) on FIELD_DEFINITION
+`
+
+// testMessage is a response or a notification from the server.
+type testMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+// client drives a server over pipes.
+type client struct {
+	t    *testing.T
+	w    io.WriteCloser
+	msgs chan *testMessage
+	done chan error
+	id   int
+	// diags holds the last diagnostics published for each document.
+	diags map[string][]Diagnostic
+}
+
+func newClient(t *testing.T) *client {
+	t.Helper()
+	serverR, clientW := io.Pipe()
+	clientR, serverW := io.Pipe()
+	c := &client{
+		t:     t,
+		w:     clientW,
+		msgs:  make(chan *testMessage),
+		done:  make(chan error, 1),
+		diags: map[string][]Diagnostic{},
+	}
+	go func() {
+		c.done <- NewServer(serverR, serverW).Serve()
+		serverW.Close()
+	}()
+	go func() {
+		defer close(c.msgs)
+		r := bufio.NewReader(clientR)
+		for {
+			header, e := textproto.NewReader(r).ReadMIMEHeader()
+			if e != nil {
+				return
+			}
+			length, _ := strconv.Atoi(header.Get("Content-Length"))
+			body := make([]byte, length)
+			if _, e := io.ReadFull(r, body); e != nil {
+				return
+			}
+			msg := &testMessage{}
+			if e := json.Unmarshal(body, msg); e != nil {
+				return
+			}
+			c.msgs <- msg
+		}
+	}()
+	c.request("initialize", map[string]interface{}{}, nil)
+	c.notify("initialized", map[string]interface{}{})
+	return c
+}
+
+func (c *client) write(msg interface{}) {
+	c.t.Helper()
+	body, e := json.Marshal(msg)
+	if e != nil {
+		c.t.Fatal(e)
+	}
+	if _, e := io.WriteString(c.w, "Content-Length: "+strconv.Itoa(len(body))+"\r\n\r\n"+string(body)); e != nil {
+		c.t.Fatal(e)
+	}
+}
+
+func (c *client) notify(method string, params interface{}) {
+	c.t.Helper()
+	c.write(map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params})
+}
+
+// request sends a request and decodes its result to result, recording
+// diagnostics published before the response.
+func (c *client) request(method string, params interface{}, result interface{}) {
+	c.t.Helper()
+	c.id++
+	c.write(map[string]interface{}{"jsonrpc": "2.0", "id": c.id, "method": method, "params": params})
+	for msg := range c.msgs {
+		if msg.Method == "textDocument/publishDiagnostics" {
+			p := &publishDiagnosticsParams{}
+			if e := json.Unmarshal(msg.Params, p); e != nil {
+				c.t.Fatal(e)
+			}
+			c.diags[p.URI] = p.Diagnostics
+			continue
+		}
+		if msg.ID == nil || *msg.ID != c.id {
+			c.t.Fatalf("unexpected message %+v", msg)
+		}
+		if msg.Error != nil {
+			c.t.Fatalf("%s: %s", method, msg.Error.Message)
+		}
+		if result != nil {
+			if e := json.Unmarshal(msg.Result, result); e != nil {
+				c.t.Fatal(e)
+			}
+		}
+		return
+	}
+	c.t.Fatalf("connection is closed before the response of %s", method)
+}
+
+// sync waits for the diagnostics of the notifications sent before, which
+// precede the response of shutdown.
+func (c *client) sync() {
+	c.t.Helper()
+	c.request("shutdown", nil, nil)
+}
+
+func (c *client) open(uri, text string) {
+	c.t.Helper()
+	c.notify("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]string{"uri": uri, "text": text},
+	})
+	c.sync()
+}
+
+func (c *client) change(uri, text string) {
+	c.t.Helper()
+	c.notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]string{"uri": uri},
+		"contentChanges": []map[string]string{{"text": text}},
+	})
+	c.sync()
+}
+
+func (c *client) close() {
+	c.t.Helper()
+	c.notify("exit", nil)
+	if e := <-c.done; e != nil {
+		c.t.Errorf("Serve returned %v", e)
+	}
+	c.w.Close()
+}
+
+func positionParams(uri string, line, character int) map[string]interface{} {
+	return map[string]interface{}{
+		"textDocument": map[string]string{"uri": uri},
+		"position":     Position{Line: line, Character: character},
+	}
+}
+
+// messages returns the diagnostics of uri as "line:character: message".
+func (c *client) messages(uri string) []string {
+	var msgs []string
+	for _, d := range c.diags[uri] {
+		msgs = append(msgs, strconv.Itoa(d.Range.Start.Line)+":"+strconv.Itoa(d.Range.Start.Character)+": "+d.Message)
+	}
+	return msgs
+}
+
+func TestDiagnostics(t *testing.T) {
+	c := newClient(t)
+	defer c.close()
+
+	c.open(schemaURI, strings.Replace(schemaSrc, "Maker! @cache", "Maker! @cached", 1))
+	want := []string{"2:17: unknown directive @cached", "3:10: unknown type Driver"}
+	if got := c.messages(schemaURI); !reflect.DeepEqual(got, want) && !reflect.DeepEqual(got, []string{want[1], want[0]}) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	c.change(schemaURI, "type Query {\n  truck: Truck\n")
+	if got, want := c.messages(schemaURI), []string{"2:0: unexpected eof"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	c.change(schemaURI, schemaSrc+"type Driver { name: String }\n")
+	if got := c.messages(schemaURI); len(got) != 0 {
+		t.Errorf("diagnostics are not cleared: %q", got)
+	}
+
+	c.open(opsURI, "query Q($id: ID) {\n  truck { maker color }\n}\n")
+	want = []string{
+		"1:16: cannot query field color on type Truck",
+		"0:9: variable $id is never used in operation Q",
+	}
+	if got := c.messages(opsURI); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestExtendUnknownType(t *testing.T) {
+	c := newClient(t)
+	defer c.close()
+
+	c.open(schemaURI, schemaSrc+"type Driver { name: String }\n")
+	c.change(schemaURI, schemaSrc+"type Driver { name: String }\n\nextend type Trailer {\n  length: Int\n}\n")
+	want := []string{"18:12: cannot extend undefined type Trailer"}
+	if got := c.messages(schemaURI); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// The server keeps serving with the schema before the change.
+	var locs []Location
+	c.request("textDocument/definition", positionParams(schemaURI, 7, 10), &locs)
+	if len(locs) != 1 || locs[0].Range.Start.Line != 1 {
+		t.Errorf("got definitions %+v", locs)
+	}
+
+	c.change(schemaURI, schemaSrc+"type Driver { name: String }\n\nextend type Truck {\n  length: Int\n}\n")
+	if got := c.messages(schemaURI); len(got) != 0 {
+		t.Errorf("diagnostics are not cleared: %q", got)
+	}
+}
+
+func TestDefinition(t *testing.T) {
+	c := newClient(t)
+	defer c.close()
+	c.open(schemaURI, schemaSrc)
+
+	tests := []struct {
+		line, character int
+		want            []Location
+	}{
+		// truck: Truck
+		{7, 10, []Location{{URI: schemaURI, Range: Range{Position{1, 5}, Position{1, 10}}}}},
+		// maker: Maker! @cache
+		{2, 20, []Location{{URI: schemaURI, Range: Range{Position{15, 11}, Position{15, 16}}}}},
+		// A built-in scalar is not in any document.
+		{15, 23, []Location{}},
+		// Blank.
+		{5, 0, []Location{}},
+	}
+	for _, tt := range tests {
+		var got []Location
+		c.request("textDocument/definition", positionParams(schemaURI, tt.line, tt.character), &got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("definition at %d:%d is %+v, want %+v", tt.line, tt.character, got, tt.want)
+		}
+	}
+}
+
+func TestHover(t *testing.T) {
+	c := newClient(t)
+	defer c.close()
+	c.open(schemaURI, schemaSrc)
+
+	tests := []struct {
+		line, character int
+		want            string
+	}{
+		{7, 10, "```graphql\n\"A truck\"\ntype Truck {\n  maker: Maker! @cache\n  driver: Driver\n}\n```"},
+		{2, 20, "```graphql\ndirective @cache(ttl: Int) on FIELD_DEFINITION\n```"},
+		{2, 10, "```graphql\nenum Maker {\n  HINO\n  ISUZU\n}\n```"},
+	}
+	for _, tt := range tests {
+		var got *Hover
+		c.request("textDocument/hover", positionParams(schemaURI, tt.line, tt.character), &got)
+		if got == nil || got.Contents.Value != tt.want {
+			t.Errorf("hover at %d:%d is %+v, want %q", tt.line, tt.character, got, tt.want)
+		}
+	}
+
+	var got *Hover
+	c.request("textDocument/hover", positionParams(schemaURI, 3, 11), &got)
+	if got != nil {
+		t.Errorf("hover of an undefined type is %+v", got)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	c := newClient(t)
+	defer c.close()
+	c.open(schemaURI, schemaSrc)
+
+	labels := func(line, character int) []string {
+		var items []CompletionItem
+		c.request("textDocument/completion", positionParams(schemaURI, line, character), &items)
+		var ret []string
+		for _, i := range items {
+			ret = append(ret, i.Label)
+		}
+		return ret
+	}
+	if got, want := labels(3, 11), []string{"Boolean", "Float", "ID", "Int", "Maker", "Query", "String", "Truck"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("completion of types is %q, want %q", got, want)
+	}
+	if got, want := labels(2, 18), []string{"cache", "deprecated", "include", "skip"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("completion of directives is %q, want %q", got, want)
+	}
+}
+
+func TestDocumentSymbol(t *testing.T) {
+	c := newClient(t)
+	defer c.close()
+	c.open(schemaURI, schemaSrc)
+
+	var syms []DocumentSymbol
+	c.request("textDocument/documentSymbol", map[string]interface{}{
+		"textDocument": map[string]string{"uri": schemaURI},
+	}, &syms)
+	var got []string
+	for _, s := range syms {
+		got = append(got, s.Detail+" "+s.Name+" "+strconv.Itoa(s.Range.Start.Line))
+		for _, child := range s.Children {
+			got = append(got, "  "+child.Name+" "+child.Detail)
+		}
+	}
+	want := []string{
+		"type Truck 1",
+		"  maker Maker!",
+		"  driver Driver",
+		"type Query 6",
+		"  truck Truck",
+		"enum Maker 10",
+		"  HINO ",
+		"  ISUZU ",
+		"directive @cache 15",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,495 @@
+package lsp
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RettyEng/gqlcodegen/ast"
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/parser"
+	"github.com/RettyEng/gqlcodegen/printer"
+	"github.com/RettyEng/gqlcodegen/validator"
+)
+
+var builtinScalars = []string{"Boolean", "Float", "ID", "Int", "String"}
+
+// builtinDirectives are SDL of directives every schema has.
+var builtinDirectives = map[string]string{
+	"skip": `"Directs the executor to skip this field or fragment when the if argument is true."
+directive @skip(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT`,
+	"include": `"Directs the executor to include this field or fragment only when the if argument is true."
+directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT`,
+	"deprecated": `"Marks an element of a GraphQL schema as no longer supported."
+directive @deprecated(reason: String = "No longer supported") on FIELD_DEFINITION | ENUM_VALUE`,
+}
+
+// workspace holds the documents the server knows, which are files of the
+// workspace and documents opened by the client.
+type workspace struct {
+	docs map[string]string
+	// ts is the schema made of all the schema documents.
+	ts *gql.TypeSystem
+}
+
+func newWorkspace() *workspace {
+	return &workspace{
+		docs: map[string]string{},
+		ts:   gql.NewTypeSystem(),
+	}
+}
+
+// isSchema returns whether the document uri is a schema rather than
+// operations.
+func isSchema(uri string) bool {
+	return strings.HasSuffix(uri, ".graphqls")
+}
+
+// load reads the schema files under root.
+func (w *workspace) load(root string) {
+	_ = filepath.Walk(root, func(p string, info os.FileInfo, e error) error {
+		if e != nil {
+			return nil
+		}
+		if info.IsDir() && strings.HasPrefix(info.Name(), ".") && p != root {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || filepath.Ext(p) != ".graphqls" {
+			return nil
+		}
+		src, e := ioutil.ReadFile(p)
+		if e != nil {
+			return nil
+		}
+		w.docs[pathToURI(p)] = string(src)
+		return nil
+	})
+}
+
+// analyze rebuilds the schema and returns diagnostics of every document.
+func (w *workspace) analyze() map[string][]Diagnostic {
+	diags := map[string][]Diagnostic{}
+	var uris []string
+	for uri := range w.docs {
+		diags[uri] = []Diagnostic{}
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	var exps []ast.DefinitionExpression
+	for _, uri := range uris {
+		if !isSchema(uri) {
+			continue
+		}
+		top, e := parser.NewFileParser(uri, strings.NewReader(w.docs[uri])).Parse()
+		if e != nil {
+			diags[uri] = append(diags[uri], syntaxDiagnostic(e))
+			continue
+		}
+		exps = append(exps, top.Expressions...)
+	}
+	if ts, e := (&ast.TopLevel{Expressions: exps}).Eval(); e == nil {
+		w.ts = ts
+	} else if ee, ok := e.(*ast.EvalError); ok {
+		uri := ee.Position.Filename
+		diags[uri] = append(diags[uri], diagnostic(ee.Position, 1, ee.Message))
+	}
+	for _, d := range w.undefinedReferences() {
+		diags[d.uri] = append(diags[d.uri], d.Diagnostic)
+	}
+
+	for _, uri := range uris {
+		if isSchema(uri) {
+			continue
+		}
+		doc, e := parser.NewFileParser(uri, strings.NewReader(w.docs[uri])).ParseExecutable()
+		if e != nil {
+			diags[uri] = append(diags[uri], syntaxDiagnostic(e))
+			continue
+		}
+		for _, v := range validator.Validate(w.ts, doc.Eval()) {
+			diags[uri] = append(diags[uri], diagnostic(v.Position, 1, v.Message))
+		}
+	}
+	return diags
+}
+
+func syntaxDiagnostic(e error) Diagnostic {
+	if se, ok := e.(*parser.SyntaxError); ok {
+		return diagnostic(se.Position, 1, se.Message)
+	}
+	return diagnostic(gql.Position{Line: 1, Col: 1}, 1, e.Error())
+}
+
+func diagnostic(pos gql.Position, length int, message string) Diagnostic {
+	return Diagnostic{
+		Range:    toRange(pos, length),
+		Severity: severityError,
+		Source:   "gqlcodegen",
+		Message:  message,
+	}
+}
+
+type uriDiagnostic struct {
+	uri string
+	Diagnostic
+}
+
+// undefinedReferences returns diagnostics of references to types and
+// directives which are not defined.
+func (w *workspace) undefinedReferences() []uriDiagnostic {
+	var diags []uriDiagnostic
+	ref := func(r *gql.TypeRef) {
+		for r.Name == "[]" {
+			r = r.InnerType
+		}
+		if w.definition(r.Name) == nil {
+			msg := "unknown type " + r.Name
+			diags = append(diags, uriDiagnostic{r.Position.Filename, diagnostic(r.Position, nameLength(r.Name), msg)})
+		}
+	}
+	dirs := func(ds []*gql.DirectiveRef) {
+		for _, d := range ds {
+			if w.directive(d.Name) == "" {
+				msg := "unknown directive @" + d.Name
+				diags = append(diags, uriDiagnostic{d.Position.Filename, diagnostic(d.Position, nameLength(d.Name), msg)})
+			}
+		}
+	}
+	args := func(vs []*gql.InputValue) {
+		for _, v := range vs {
+			ref(v.Type)
+			dirs(v.Directives)
+		}
+	}
+	fields := func(fs []*gql.ObjectField) {
+		for _, f := range fs {
+			ref(f.Type)
+			args(f.Args)
+			dirs(f.Directives)
+		}
+	}
+	for _, t := range w.ts.ScalarTypes {
+		dirs(t.Directives)
+	}
+	for _, t := range w.ts.ObjectTypes {
+		for _, i := range t.Implements {
+			ref(i)
+		}
+		dirs(t.Directives)
+		fields(t.Fields)
+	}
+	for _, t := range w.ts.InterfaceTypes {
+		dirs(t.Directives)
+		fields(t.Fields)
+	}
+	for _, t := range w.ts.UnionTypes {
+		for _, m := range t.Members {
+			ref(m)
+		}
+		dirs(t.Directives)
+	}
+	for _, t := range w.ts.EnumTypes {
+		dirs(t.Directives)
+		for _, v := range t.Values {
+			dirs(v.Directives)
+		}
+	}
+	for _, t := range w.ts.InputObjectTypes {
+		dirs(t.Directives)
+		args(t.InputValue)
+	}
+	for _, d := range w.ts.Directives {
+		args(d.Arguments)
+	}
+	return diags
+}
+
+// definition returns the definition of the type name, which is a
+// gql.Commentable or nil if it is not defined. Built-in scalars are
+// defined with no position.
+func (w *workspace) definition(name string) gql.Commentable {
+	if t, ok := w.ts.ScalarTypes[name]; ok {
+		return t
+	}
+	if t, ok := w.ts.ObjectTypes[name]; ok {
+		return t
+	}
+	if t, ok := w.ts.InterfaceTypes[name]; ok {
+		return t
+	}
+	if t, ok := w.ts.UnionTypes[name]; ok {
+		return t
+	}
+	if t, ok := w.ts.EnumTypes[name]; ok {
+		return t
+	}
+	if t, ok := w.ts.InputObjectTypes[name]; ok {
+		return t
+	}
+	for _, s := range builtinScalars {
+		if s == name {
+			return &gql.Scalar{Name: name}
+		}
+	}
+	return nil
+}
+
+// directive returns the SDL of the directive name, or "" if it is not
+// defined.
+func (w *workspace) directive(name string) string {
+	if d, ok := w.ts.Directives[name]; ok {
+		ts := gql.NewTypeSystem()
+		ts.Directives[name] = d
+		return sdl(ts)
+	}
+	if d, ok := builtinDirectives[name]; ok {
+		return d
+	}
+	return ""
+}
+
+// position returns the position of the definition of the type or the
+// directive name, or false if it is not in a document.
+func (w *workspace) position(name string, isDirective bool) (gql.Position, bool) {
+	var pos gql.Position
+	if isDirective {
+		d, ok := w.ts.Directives[name]
+		if !ok {
+			return pos, false
+		}
+		pos = d.Position
+	} else {
+		switch t := w.definition(name).(type) {
+		case *gql.Scalar:
+			pos = t.Position
+		case *gql.Object:
+			pos = t.Position
+		case *gql.Interface:
+			pos = t.Position
+		case *gql.Union:
+			pos = t.Position
+		case *gql.Enum:
+			pos = t.Position
+		case *gql.InputObject:
+			pos = t.Position
+		}
+	}
+	return pos, pos.Filename != ""
+}
+
+// typeSDL returns the SDL of the type name.
+func (w *workspace) typeSDL(name string) string {
+	ts := gql.NewTypeSystem()
+	switch t := w.definition(name).(type) {
+	case *gql.Scalar:
+		ts.ScalarTypes[name] = t
+	case *gql.Object:
+		ts.ObjectTypes[name] = t
+	case *gql.Interface:
+		ts.InterfaceTypes[name] = t
+	case *gql.Union:
+		ts.UnionTypes[name] = t
+	case *gql.Enum:
+		ts.EnumTypes[name] = t
+	case *gql.InputObject:
+		ts.InputObjectTypes[name] = t
+	default:
+		return ""
+	}
+	return sdl(ts)
+}
+
+func sdl(ts *gql.TypeSystem) string {
+	var b bytes.Buffer
+	_ = printer.Fprint(&b, ts)
+	return strings.TrimSpace(b.String())
+}
+
+// wordAt returns the name at pos of the document uri with its range, and
+// whether it follows @.
+func (w *workspace) wordAt(uri string, pos Position) (string, Range, bool) {
+	lines := strings.Split(w.docs[uri], "\n")
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return "", Range{}, false
+	}
+	line := []rune(lines[pos.Line])
+	isName := func(r rune) bool {
+		return r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9'
+	}
+	start, end := pos.Character, pos.Character
+	if start > len(line) {
+		return "", Range{}, false
+	}
+	for start > 0 && isName(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isName(line[end]) {
+		end++
+	}
+	r := Range{Start: Position{pos.Line, start}, End: Position{pos.Line, end}}
+	return string(line[start:end]), r, start > 0 && line[start-1] == '@'
+}
+
+func toRange(pos gql.Position, length int) Range {
+	start := Position{Line: pos.Line - 1, Character: pos.Col - 1}
+	if start.Line < 0 {
+		start.Line = 0
+	}
+	if start.Character < 0 {
+		start.Character = 0
+	}
+	end := start
+	end.Character += length
+	return Range{Start: start, End: end}
+}
+
+func pathToURI(p string) string {
+	abs, e := filepath.Abs(p)
+	if e != nil {
+		abs = p
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String()
+}
+
+func uriToPath(uri string) string {
+	u, e := url.Parse(uri)
+	if e != nil || u.Scheme != "file" {
+		return ""
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func nameLength(name string) int {
+	return utf8.RuneCountInString(name)
+}
+
+// close forgets the document uri, restoring the schema file of the
+// workspace if it exists.
+func (w *workspace) close(uri string) {
+	delete(w.docs, uri)
+	if !isSchema(uri) {
+		return
+	}
+	if src, e := ioutil.ReadFile(uriToPath(uri)); e == nil {
+		w.docs[uri] = string(src)
+	}
+}
+
+type typeName struct {
+	name string
+	kind string
+}
+
+// typeNames returns names of the types including built-in scalars, sorted.
+func (w *workspace) typeNames() []typeName {
+	var names []typeName
+	for _, s := range builtinScalars {
+		if _, ok := w.ts.ScalarTypes[s]; !ok {
+			names = append(names, typeName{s, "scalar"})
+		}
+	}
+	for n := range w.ts.ScalarTypes {
+		names = append(names, typeName{n, "scalar"})
+	}
+	for n := range w.ts.ObjectTypes {
+		names = append(names, typeName{n, "type"})
+	}
+	for n := range w.ts.InterfaceTypes {
+		names = append(names, typeName{n, "interface"})
+	}
+	for n := range w.ts.UnionTypes {
+		names = append(names, typeName{n, "union"})
+	}
+	for n := range w.ts.EnumTypes {
+		names = append(names, typeName{n, "enum"})
+	}
+	for n := range w.ts.InputObjectTypes {
+		names = append(names, typeName{n, "input"})
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i].name < names[j].name })
+	return names
+}
+
+// directiveNames returns names of the directives including built-in ones,
+// sorted.
+func (w *workspace) directiveNames() []string {
+	var names []string
+	for n := range builtinDirectives {
+		if _, ok := w.ts.Directives[n]; !ok {
+			names = append(names, n)
+		}
+	}
+	for n := range w.ts.Directives {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// symbols returns the definitions in the document uri with their fields
+// and values, in the order of the document.
+func (w *workspace) symbols(uri string) []DocumentSymbol {
+	syms := []DocumentSymbol{}
+	symbol := func(name, detail string, kind int, pos gql.Position) DocumentSymbol {
+		r := toRange(pos, nameLength(name))
+		return DocumentSymbol{Name: name, Detail: detail, Kind: kind, Range: r, SelectionRange: r}
+	}
+	fields := func(fs []*gql.ObjectField) []DocumentSymbol {
+		var children []DocumentSymbol
+		for _, f := range fs {
+			children = append(children, symbol(f.Name, f.Type.String(), symbolField, f.Position))
+		}
+		return children
+	}
+	add := func(pos gql.Position, sym DocumentSymbol) {
+		if pos.Filename == uri {
+			syms = append(syms, sym)
+		}
+	}
+	for _, t := range w.ts.ScalarTypes {
+		add(t.Position, symbol(t.Name, "scalar", symbolTypeParameter, t.Position))
+	}
+	for _, t := range w.ts.ObjectTypes {
+		s := symbol(t.Name, "type", symbolClass, t.Position)
+		s.Children = fields(t.Fields)
+		add(t.Position, s)
+	}
+	for _, t := range w.ts.InterfaceTypes {
+		s := symbol(t.Name, "interface", symbolInterface, t.Position)
+		s.Children = fields(t.Fields)
+		add(t.Position, s)
+	}
+	for _, t := range w.ts.UnionTypes {
+		add(t.Position, symbol(t.Name, "union", symbolEnum, t.Position))
+	}
+	for _, t := range w.ts.EnumTypes {
+		s := symbol(t.Name, "enum", symbolEnum, t.Position)
+		for _, v := range t.Values {
+			s.Children = append(s.Children, symbol(v.Name, "", symbolEnumMember, v.Position))
+		}
+		add(t.Position, s)
+	}
+	for _, t := range w.ts.InputObjectTypes {
+		s := symbol(t.Name, "input", symbolStruct, t.Position)
+		for _, v := range t.InputValue {
+			s.Children = append(s.Children, symbol(v.Name, v.Type.String(), symbolField, v.Position))
+		}
+		add(t.Position, s)
+	}
+	for _, d := range w.ts.Directives {
+		s := symbol(d.Name, "directive", symbolFunction, d.Position)
+		s.Name = "@" + d.Name
+		add(d.Position, s)
+	}
+	sort.Slice(syms, func(i, j int) bool {
+		a, b := syms[i].Range.Start, syms[j].Range.Start
+		return a.Line < b.Line || a.Line == b.Line && a.Character < b.Character
+	})
+	return syms
+}
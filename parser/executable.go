@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"bufio"
+	"os"
+
+	"github.com/RettyEng/gqlcodegen/ast"
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/lexer/token"
+)
+
+// ParseExecutable parses an executable document, which consists of
+// operations and fragments. Errors in the source are returned as
+// *SyntaxError.
+func (p *Parser) ParseExecutable() (doc *ast.ExecutableDocument, err error) {
+	defer p.recoverError(&err)
+	var exp []ast.ExecutableDefinitionExpression
+	for p.hasNext() {
+		t := p.prefetch(0)
+		switch t.Value() {
+		case "{", "query", "mutation", "subscription":
+			exp = append(exp, p.parseOperation())
+		case "fragment":
+			exp = append(exp, p.parseFragment())
+		default:
+			unexpectedToken(t)
+		}
+	}
+	return &ast.ExecutableDocument{
+		Definitions: exp,
+		Trivia:      ast.Trivia{Closing: p.lexer.EndTrivia()},
+	}, nil
+}
+
+// ParseExecutableFile parses the executable document in file.
+func ParseExecutableFile(file string) (*ast.ExecutableDocument, error) {
+	f, e := os.Open(file)
+	if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+	return NewFileParser(file, bufio.NewReader(f)).ParseExecutable()
+}
+
+func (p *Parser) parseOperation() ast.ExecutableDefinitionExpression {
+	p.begin()
+	t := p.prefetch(0)
+	if t.Value() == "{" {
+		return &ast.OperationExpression{
+			OperationType: gql.OperationQuery,
+			Pos:           p.position(t),
+			SelectionSet:  p.parseSelectionSet(),
+			Trivia:        p.end(),
+		}
+	}
+	t = p.pop()
+	validateTokenValue(t, "query", "mutation", "subscription")
+	var name ast.NameExpression
+	if p.preTypeCheck(0, token.TypeName) {
+		name = p.parseName()
+	}
+	variables := p.parseVariableDefinitionsOrEmpty()
+	directives := p.parseDirectivesOrEmpty()
+	return &ast.OperationExpression{
+		OperationType: gql.OperationType(t.Value()),
+		Name:          name,
+		Pos:           p.position(t),
+		Variables:     variables,
+		Directives:    directives,
+		SelectionSet:  p.parseSelectionSet(),
+		Trivia:        p.end(),
+	}
+}
+
+func (p *Parser) parseVariableDefinitionsOrEmpty() []*ast.VariableDefinitionExpression {
+	var vars []*ast.VariableDefinitionExpression
+	if !p.preValueCheck(0, "(") {
+		return vars
+	}
+	_ = p.pop()
+	for !p.preValueCheck(0, ")") {
+		vars = append(vars, p.parseVariableDefinition())
+	}
+	_ = p.pop()
+	return vars
+}
+
+func (p *Parser) parseVariableDefinition() *ast.VariableDefinitionExpression {
+	p.begin()
+	validateTokenValue(p.pop(), "$")
+	name := p.parseName()
+	validateTokenValue(p.pop(), ":")
+	typeRef := p.parseTypeRef()
+	var value ast.ValueExpression
+	if p.preValueCheck(0, "=") {
+		_ = p.pop()
+		value = p.parseValue()
+	}
+	return &ast.VariableDefinitionExpression{
+		Name:         name,
+		Type:         typeRef,
+		DefaultValue: value,
+		Directives:   p.parseDirectivesOrEmpty(),
+		Trivia:       p.end(),
+	}
+}
+
+func (p *Parser) parseFragment() ast.ExecutableDefinitionExpression {
+	p.begin()
+	validateTokenValue(p.pop(), "fragment")
+	if p.preValueCheck(0, "on") {
+		unexpectedToken(p.pop())
+	}
+	name := p.parseName()
+	validateTokenValue(p.pop(), "on")
+	typeCondition := p.parseNamedType()
+	directives := p.parseDirectivesOrEmpty()
+	return &ast.FragmentExpression{
+		Name:          name,
+		TypeCondition: typeCondition,
+		Directives:    directives,
+		SelectionSet:  p.parseSelectionSet(),
+		Trivia:        p.end(),
+	}
+}
+
+// parseNamedType parses a type condition, which is a named type without
+// modifiers.
+func (p *Parser) parseNamedType() ast.TypeRefExpression {
+	return &ast.TypeRefExpressionImpl{
+		IsNullable: true,
+		Name:       p.parseName(),
+	}
+}
+
+// parseSelectionSet parses selections, of which there must be at least one.
+func (p *Parser) parseSelectionSet() []ast.SelectionExpression {
+	validateTokenValue(p.pop(), "{")
+	selections := []ast.SelectionExpression{p.parseSelection()}
+	for !p.preValueCheck(0, "}") {
+		selections = append(selections, p.parseSelection())
+	}
+	_ = p.pop()
+	return selections
+}
+
+func (p *Parser) parseSelectionSetOrEmpty() []ast.SelectionExpression {
+	if !p.preValueCheck(0, "{") {
+		return nil
+	}
+	return p.parseSelectionSet()
+}
+
+func (p *Parser) parseSelection() ast.SelectionExpression {
+	if !p.preValueCheck(0, "...") {
+		return p.parseField()
+	}
+	if p.preTypeCheck(1, token.TypeName) && !p.preValueCheck(1, "on") {
+		return p.parseFragmentSpread()
+	}
+	return p.parseInlineFragment()
+}
+
+func (p *Parser) parseField() ast.SelectionExpression {
+	p.begin()
+	var alias ast.NameExpression
+	name := p.parseName()
+	if p.preValueCheck(0, ":") {
+		_ = p.pop()
+		alias, name = name, p.parseName()
+	}
+	args, argNames := p.parseArguments()
+	directives := p.parseDirectivesOrEmpty()
+	return &ast.FieldExpression{
+		Alias:        alias,
+		Name:         name,
+		Args:         args,
+		ArgNames:     argNames,
+		Directives:   directives,
+		SelectionSet: p.parseSelectionSetOrEmpty(),
+		Trivia:       p.end(),
+	}
+}
+
+func (p *Parser) parseFragmentSpread() ast.SelectionExpression {
+	p.begin()
+	validateTokenValue(p.pop(), "...")
+	name := p.parseName()
+	return &ast.FragmentSpreadExpression{
+		Name:       name,
+		Directives: p.parseDirectivesOrEmpty(),
+		Trivia:     p.end(),
+	}
+}
+
+func (p *Parser) parseInlineFragment() ast.SelectionExpression {
+	p.begin()
+	t := p.pop()
+	validateTokenValue(t, "...")
+	var typeCondition ast.TypeRefExpression
+	if p.preValueCheck(0, "on") {
+		_ = p.pop()
+		typeCondition = p.parseNamedType()
+	}
+	directives := p.parseDirectivesOrEmpty()
+	return &ast.InlineFragmentExpression{
+		TypeCondition: typeCondition,
+		Pos:           p.position(t),
+		Directives:    directives,
+		SelectionSet:  p.parseSelectionSet(),
+		Trivia:        p.end(),
+	}
+}
@@ -0,0 +1,280 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/ast"
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+func TestParseExecutable(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		// ast is the parsed document in the form of astString.
+		ast string
+		// eval is the evaluated document in the form of docString.
+		eval string
+	}{
+		{
+			name: "anonymous query",
+			src:  "{ trucks { maker } }",
+			ast:  "query { trucks { maker } }",
+			eval: "query 1:1 { trucks 1:3 { maker 1:12 } }",
+		},
+		{
+			name: "variables with defaults",
+			src:  "query Q($size: Int = 20, $class: [Class!]! = [ELITE], $cursor: String) { a }",
+			ast:  "query Q($size: Int = 20, $class: [Class!]! = [ELITE], $cursor: String) { a }",
+			eval: "query Q 1:1 ($size 1:10: Int = 20, $class 1:27: [Class!]! = [ELITE], $cursor 1:56: String) { a 1:74 }",
+		},
+		{
+			name: "nested selections",
+			src:  "query {\n  garage(id: 1) {\n    trucks {\n      maker\n      number\n    }\n  }\n}",
+			ast:  "query { garage(id: 1) { trucks { maker number } } }",
+			eval: "query 1:1 { garage(id: 1) 2:3 { trucks 3:5 { maker 4:7 number 5:7 } } }",
+		},
+		{
+			name: "aliases",
+			src:  "mutation M { first: order(id: \"a\") second: order(id: $id) }",
+			ast:  "mutation M { first: order(id: \"a\") second: order(id: $id) }",
+			eval: "mutation M 1:1 { first: order(id: \"a\") 1:14 second: order(id: $id) 1:36 }",
+		},
+		{
+			name: "fragment spreads",
+			src:  "query { garage { ...Trucks } }\nfragment Trucks on Garage { trucks { ...Maker } }\nfragment Maker on Truck { maker }",
+			ast:  "query { garage { ...Trucks } } fragment Trucks on Garage { trucks { ...Maker } } fragment Maker on Truck { maker }",
+			eval: "query 1:1 { garage 1:9 { ...Trucks 1:21 } } fragment Trucks 2:10 on Garage { trucks 2:29 { ...Maker 2:41 } } fragment Maker 3:10 on Truck { maker 3:27 }",
+		},
+		{
+			name: "inline fragments",
+			src:  "{ vehicle { number ... on Truck { maker } ... { capacity } } }",
+			ast:  "query { vehicle { number ... on Truck { maker } ... { capacity } } }",
+			eval: "query 1:1 { vehicle 1:3 { number 1:13 ... 1:20 on Truck { maker 1:35 } ... 1:43 { capacity 1:49 } } }",
+		},
+		{
+			name: "directives",
+			src:  "query Q($a: Boolean @v) @op { a @include(if: $a) ...F @skip(if: true) ... @include(if: false) { b } }\nfragment F on Query @frag { c @d(x: 1, y: {z: [1, 2]}) }",
+			ast:  "query Q($a: Boolean @v) @op { a @include(if: $a) ...F @skip(if: true) ... @include(if: false) { b } } fragment F on Query @frag { c @d(x: 1, y: {z: [1, 2]}) }",
+			eval: "query Q 1:1 ($a 1:10: Boolean @v) @op { a 1:31 @include(if: $a) ...F 1:53 @skip(if: true) ... 1:71 @include(if: false) { b 1:97 } } fragment F 2:10 on Query @frag { c 2:29 @d(x: 1, y: {z: [1, 2]}) }",
+		},
+		{
+			name: "subscription",
+			src:  "subscription S { orderUpdated(id: \"1\") { status } }",
+			ast:  "subscription S { orderUpdated(id: \"1\") { status } }",
+			eval: "subscription S 1:1 { orderUpdated(id: \"1\") 1:18 { status 1:42 } }",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			doc, e := NewParser(strings.NewReader(tt.src)).ParseExecutable()
+			if e != nil {
+				t.Fatal(e)
+			}
+			if got := astString(doc); got != tt.ast {
+				t.Errorf("got AST\n%s\nwant\n%s", got, tt.ast)
+			}
+			if got := docString(doc.Eval()); got != tt.eval {
+				t.Errorf("got evaluated\n%s\nwant\n%s", got, tt.eval)
+			}
+		})
+	}
+}
+
+func TestParseExecutableError(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"type A { a: Int }", "ops.graphql:1:1: unexpected token 'type'"},
+		{"query Q($a Int) { a }", "ops.graphql:1:12: unexpected token 'Int'"},
+		{"{ a { b }", "ops.graphql:1:10: unexpected eof"},
+		{"fragment F { a }", "ops.graphql:1:12: unexpected token '{'"},
+	}
+	for _, tt := range tests {
+		_, e := NewFileParser("ops.graphql", strings.NewReader(tt.src)).ParseExecutable()
+		if e == nil || e.Error() != tt.want {
+			t.Errorf("%q: got %v, want %s", tt.src, e, tt.want)
+		}
+	}
+}
+
+// astString returns the definitions of doc on one line.
+func astString(doc *ast.ExecutableDocument) string {
+	var defs []string
+	for _, d := range doc.Definitions {
+		switch d := d.(type) {
+		case *ast.OperationExpression:
+			s := string(d.OperationType)
+			if d.Name != nil {
+				s += " " + d.Name.Eval()
+			}
+			var vars []string
+			for _, v := range d.Variables {
+				vs := "$" + v.Name.Eval() + ": " + v.Type.Eval().String()
+				if v.DefaultValue != nil {
+					vs += " = " + v.DefaultValue.Eval().Value()
+				}
+				vars = append(vars, vs+astDirectives(v.Directives))
+			}
+			if len(vars) > 0 {
+				s += "(" + strings.Join(vars, ", ") + ")"
+			}
+			defs = append(defs, s+astDirectives(d.Directives)+astSelections(d.SelectionSet))
+		case *ast.FragmentExpression:
+			defs = append(defs, fmt.Sprintf(
+				"fragment %s on %s%s%s",
+				d.Name.Eval(), d.TypeCondition.Eval(), astDirectives(d.Directives), astSelections(d.SelectionSet),
+			))
+		default:
+			defs = append(defs, fmt.Sprintf("%T", d))
+		}
+	}
+	return strings.Join(defs, " ")
+}
+
+func astSelections(set []ast.SelectionExpression) string {
+	if len(set) == 0 {
+		return ""
+	}
+	var sels []string
+	for _, s := range set {
+		switch s := s.(type) {
+		case *ast.FieldExpression:
+			f := s.Name.Eval()
+			if s.Alias != nil {
+				f = s.Alias.Eval() + ": " + f
+			}
+			var args []string
+			for _, n := range s.ArgNames {
+				args = append(args, n+": "+s.Args[n].Eval().Value())
+			}
+			if len(args) > 0 {
+				f += "(" + strings.Join(args, ", ") + ")"
+			}
+			sels = append(sels, f+astDirectives(s.Directives)+astSelections(s.SelectionSet))
+		case *ast.FragmentSpreadExpression:
+			sels = append(sels, "..."+s.Name.Eval()+astDirectives(s.Directives))
+		case *ast.InlineFragmentExpression:
+			f := "..."
+			if s.TypeCondition != nil {
+				f += " on " + s.TypeCondition.Eval().String()
+			}
+			sels = append(sels, f+astDirectives(s.Directives)+astSelections(s.SelectionSet))
+		default:
+			sels = append(sels, fmt.Sprintf("%T", s))
+		}
+	}
+	return " { " + strings.Join(sels, " ") + " }"
+}
+
+func astDirectives(dirs []ast.DirectiveExpression) string {
+	var refs []*gql.DirectiveRef
+	for _, d := range dirs {
+		refs = append(refs, d.Eval())
+	}
+	return directivesString(refs)
+}
+
+// docString returns the operations and the fragments of doc on one line, with
+// the positions of the operations, the variables and the selections.
+func docString(doc *gql.ExecutableDocument) string {
+	var defs []string
+	for _, o := range doc.Operations {
+		s := string(o.Type)
+		if o.Name != "" {
+			s += " " + o.Name
+		}
+		s += " " + posString(o.Position)
+		var vars []string
+		for _, v := range o.Variables {
+			vs := "$" + v.Name + " " + posString(v.Position) + ": " + v.Type.String()
+			if v.Default != nil {
+				vs += " = " + v.Default.Value()
+			}
+			vars = append(vars, vs+directivesString(v.Directives))
+		}
+		if len(vars) > 0 {
+			s += " (" + strings.Join(vars, ", ") + ")"
+		}
+		defs = append(defs, s+directivesString(o.Directives)+selectionsString(o.SelectionSet))
+	}
+	for _, f := range doc.Fragments {
+		defs = append(defs, fmt.Sprintf(
+			"fragment %s %s on %s%s%s",
+			f.Name, posString(f.Position), f.TypeCondition, directivesString(f.Directives), selectionsString(f.SelectionSet),
+		))
+	}
+	return strings.Join(defs, " ")
+}
+
+func selectionsString(set []gql.Selection) string {
+	if len(set) == 0 {
+		return ""
+	}
+	var sels []string
+	for _, s := range set {
+		switch s := s.(type) {
+		case *gql.Field:
+			f := s.Name
+			if s.Alias != "" {
+				f = s.Alias + ": " + f
+			}
+			var args []string
+			for _, n := range s.ArgNames {
+				args = append(args, n+": "+s.Args[n].Value())
+			}
+			if len(args) > 0 {
+				f += "(" + strings.Join(args, ", ") + ")"
+			}
+			sels = append(sels, f+" "+posString(s.Position)+directivesString(s.Directives)+selectionsString(s.SelectionSet))
+		case *gql.FragmentSpread:
+			sels = append(sels, "..."+s.Name+" "+posString(s.Position)+directivesString(s.Directives))
+		case *gql.InlineFragment:
+			f := "... " + posString(s.Position)
+			if s.TypeCondition != nil {
+				f += " on " + s.TypeCondition.String()
+			}
+			sels = append(sels, f+directivesString(s.Directives)+selectionsString(s.SelectionSet))
+		}
+	}
+	return " { " + strings.Join(sels, " ") + " }"
+}
+
+func directivesString(dirs []*gql.DirectiveRef) string {
+	s := ""
+	for _, d := range dirs {
+		s += " @" + d.Name
+		var args []string
+		for _, n := range d.ArgNames {
+			args = append(args, n+": "+d.Args[n].Value())
+		}
+		if len(args) > 0 {
+			s += "(" + strings.Join(args, ", ") + ")"
+		}
+	}
+	return s
+}
+
+func posString(p gql.Position) string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Col)
+}
+
+func TestParseExecutableFile(t *testing.T) {
+	if _, e := ParseExecutableFile(filepath.Join(t.TempDir(), "missing.graphql")); e == nil {
+		t.Error("missing file is parsed without an error")
+	}
+	file := filepath.Join(t.TempDir(), "ops.graphql")
+	if e := ioutil.WriteFile(file, []byte("{ a }\n{"), 0644); e != nil {
+		t.Fatal(e)
+	}
+	_, e := ParseExecutableFile(file)
+	if want := file + ":2:2: unexpected eof"; e == nil || e.Error() != want {
+		t.Errorf("got %v, want %s", e, want)
+	}
+}
@@ -1,7 +1,6 @@
 package parser
 
 import (
-	"errors"
 	"io"
 	"log"
 	"strings"
@@ -15,8 +14,21 @@ import (
 )
 
 type Parser struct {
-	lexer *lexer.Lexer
-	ast   *ast.TopLevel
+	lexer    *lexer.Lexer
+	ast      *ast.TopLevel
+	filename string
+	// nodes holds tokens of nodes being parsed. The innermost node is last.
+	nodes [][]*token.Token
+}
+
+// SyntaxError is an error in the source of a schema.
+type SyntaxError struct {
+	Position gql.Position
+	Message  string
+}
+
+func (e *SyntaxError) Error() string {
+	return e.Position.String() + ": " + e.Message
 }
 
 func NewParser(reader io.Reader) *Parser {
@@ -25,10 +37,35 @@ func NewParser(reader io.Reader) *Parser {
 	}
 }
 
+// NewFileParser returns a parser whose positions in the parsed schema refer
+// to filename.
+func NewFileParser(filename string, reader io.Reader) *Parser {
+	p := NewParser(reader)
+	p.filename = filename
+	return p
+}
+
+// KeepAllTrivia makes nodes of the parsed schema retain all trivia around
+// them, including whitespaces, line terminators and commas. Only comments are
+// retained by default. It must be called before parsing.
+func (p *Parser) KeepAllTrivia() {
+	p.lexer.KeepAllTrivia()
+}
+
 func (p *Parser) ParseSchema() *ast.TopLevel {
+	t, e := p.Parse()
+	if e != nil {
+		log.Fatal(e)
+	}
+	return t
+}
+
+// Parse parses the schema. Errors in the source are returned as *SyntaxError.
+func (p *Parser) Parse() (top *ast.TopLevel, err error) {
 	if p.ast != nil {
-		return p.ast
+		return p.ast, nil
 	}
+	defer p.recoverError(&err)
 	var exp []ast.DefinitionExpression
 	for {
 		if !p.hasNext() {
@@ -66,12 +103,52 @@ func (p *Parser) ParseSchema() *ast.TopLevel {
 			unexpectedToken(t)
 		}
 	}
-	p.ast = &ast.TopLevel{exp}
-	return p.ast
+	p.ast = &ast.TopLevel{
+		Expressions: exp,
+		Trivia:      ast.Trivia{Closing: p.lexer.EndTrivia()},
+	}
+	return p.ast, nil
 }
 
+// ParseValue parses the source as a single value, such as a default value of
+// an argument.
+func (p *Parser) ParseValue() (v ast.ValueExpression, err error) {
+	defer p.recoverError(&err)
+	v = p.parseValue()
+	if p.hasNext() {
+		unexpectedToken(p.pop())
+	}
+	return v, nil
+}
+
+// recoverError recovers from a panic on a syntax error and stores it to err.
+func (p *Parser) recoverError(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	switch e := r.(type) {
+	case *SyntaxError:
+		e.Position.Filename = p.filename
+		*err = e
+	case *lexer.Error:
+		*err = &SyntaxError{
+			Position: gql.Position{Filename: p.filename, Line: e.Line, Col: e.Col},
+			Message:  e.Message,
+		}
+	default:
+		panic(r)
+	}
+}
+
+// ParseAndEvalSchema parses and evaluates the schema, and exits on an error
+// like ParseSchema.
 func (p *Parser) ParseAndEvalSchema() *gql.TypeSystem {
-	return p.ParseSchema().Eval()
+	ts, e := p.ParseSchema().Eval()
+	if e != nil {
+		log.Fatal(e)
+	}
+	return ts
 }
 
 func (p *Parser) parseExtend() ast.DefinitionExpression {
@@ -98,6 +175,7 @@ func (p *Parser) parseExtend() ast.DefinitionExpression {
 }
 
 func (p *Parser) parseExtendInput() ast.DefinitionExpression {
+	p.begin()
 	validateTokenValue(p.pop(), "extend")
 	validateTokenValue(p.pop(), "input")
 	n := p.parseName()
@@ -108,13 +186,18 @@ func (p *Parser) parseExtendInput() ast.DefinitionExpression {
 		for !p.preValueCheck(0, "}") {
 			body = append(body, p.parseInputValue())
 		}
+		_ = p.pop()
 	}
 	return &ast.ExtendInputObjectExpression{
-		n, direc, body,
+		NameExpression:                    n,
+		DirectiveExpressions:              direc,
+		DefineInputObjectFieldExpressions: body,
+		Trivia:                            p.end(),
 	}
 }
 
 func (p *Parser) parseExtendUnion() ast.DefinitionExpression {
+	p.begin()
 	validateTokenValue(p.pop(), "extend")
 	validateTokenValue(p.pop(), "union")
 	n := p.parseName()
@@ -123,10 +206,16 @@ func (p *Parser) parseExtendUnion() ast.DefinitionExpression {
 	if p.preValueCheck(0, "=") {
 		body = p.parseUnionBody()
 	}
-	return &ast.ExtendUnionExpression{n, direc, body}
+	return &ast.ExtendUnionExpression{
+		NameExpression:       n,
+		DirectiveExpressions: direc,
+		UnionExpression:      body,
+		Trivia:               p.end(),
+	}
 }
 
 func (p *Parser) parseExtendInterface() ast.DefinitionExpression {
+	p.begin()
 	validateTokenValue(p.pop(), "extend")
 	validateTokenValue(p.pop(), "interface")
 	n := p.parseName()
@@ -136,11 +225,15 @@ func (p *Parser) parseExtendInterface() ast.DefinitionExpression {
 		body = p.parseInterfaceBody()
 	}
 	return &ast.ExtendInterfaceExpression{
-		n, direc, body,
+		NameExpression:       n,
+		DirectiveExpressions: direc,
+		InterfaceExpression:  body,
+		Trivia:               p.end(),
 	}
 }
 
 func (p *Parser) parseExtendSchema() ast.DefinitionExpression {
+	p.begin()
 	validateTokenValue(p.pop(), "extend")
 	validateTokenValue(p.pop(), "schema")
 	direc := p.parseDirectivesOrEmpty()
@@ -148,18 +241,28 @@ func (p *Parser) parseExtendSchema() ast.DefinitionExpression {
 	if p.preValueCheck(0, "{") {
 		body = p.parseSchemaBody()
 	}
-	return &ast.ExtendSchemaExpression{direc, body}
+	return &ast.ExtendSchemaExpression{
+		DirectiveExpressions: direc,
+		Expressions:          body,
+		Trivia:               p.end(),
+	}
 }
 
 func (p *Parser) parseExtendScalar() ast.DefinitionExpression {
+	p.begin()
 	validateTokenValue(p.pop(), "extend")
 	validateTokenValue(p.pop(), "scalar")
 	n := p.parseName()
 	d := p.parseDirectives()
-	return &ast.ExtendScalarExpression{n, d}
+	return &ast.ExtendScalarExpression{
+		NameExpression:       n,
+		DirectiveExpressions: d,
+		Trivia:               p.end(),
+	}
 }
 
 func (p *Parser) parseExtendEnum() ast.DefinitionExpression {
+	p.begin()
 	validateTokenValue(p.pop(), "extend")
 	validateTokenValue(p.pop(), "enum")
 	n := p.parseName()
@@ -169,11 +272,15 @@ func (p *Parser) parseExtendEnum() ast.DefinitionExpression {
 		body = p.parseEnumBody()
 	}
 	return &ast.ExtendEnumExpression{
-		n, d, body,
+		NameExpression:       n,
+		DirectiveExpressions: d,
+		EnumExpression:       body,
+		Trivia:               p.end(),
 	}
 }
 
 func (p *Parser) parseExtendObject() ast.DefinitionExpression {
+	p.begin()
 	validateTokenValue(p.pop(), "extend")
 	validateTokenValue(p.pop(), "type")
 	n := p.parseName()
@@ -182,31 +289,40 @@ func (p *Parser) parseExtendObject() ast.DefinitionExpression {
 	if p.preValueCheck(0, "{") {
 		exp = append(exp, p.parseObjectBody()...)
 	}
-	return &ast.ExtendObjectExpression{n, d, exp}
+	return &ast.ExtendObjectExpression{
+		NameExpression:       n,
+		DirectiveExpressions: d,
+		ObjectExpression:     exp,
+		Trivia:               p.end(),
+	}
 }
 
 func (p *Parser) parseInput() ast.DefinitionExpression {
+	p.begin()
 	desc := p.parseDescriptionOrEmpty()
 	t := p.pop()
 	validateTokenValue(t, "input")
 	n := p.parseName()
 	direc := p.parseDirectivesOrEmpty()
-	t = p.pop()
-	validateTokenValue(t, "{")
 	var args []ast.InputValueExpression
-	for !p.preValueCheck(0, "}") {
-		args = append(args, p.parseInputValue())
+	if p.preValueCheck(0, "{") {
+		_ = p.pop()
+		for !p.preValueCheck(0, "}") {
+			args = append(args, p.parseInputValue())
+		}
+		_ = p.pop()
 	}
-	_ = p.pop()
 	return &ast.DefineInputObjectExpression{
 		DescriptionExpression:             desc,
 		NameExpression:                    n,
 		DirectiveExpressions:              direc,
 		DefineInputObjectFieldExpressions: args,
+		Trivia:                            p.end(),
 	}
 }
 
 func (p *Parser) parseDirective() ast.DefinitionExpression {
+	p.begin()
 	desc := p.parseDescriptionOrEmpty()
 	t := p.pop()
 	validateTokenValue(t, "directive")
@@ -233,6 +349,7 @@ func (p *Parser) parseDirective() ast.DefinitionExpression {
 		NameExpression:        n,
 		ArgsExpression:        args,
 		Expressions:           locs,
+		Trivia:                p.end(),
 	}
 }
 
@@ -241,12 +358,14 @@ func (p *Parser) parseDirectiveLocation() ast.DirectiveInternalExpression {
 	validateTokenType(t, token.TypeName)
 	for i := 0; !strings.HasPrefix(directive.Location(i).String(), "Location("); i++ {
 		if t.Value() == directive.Location(i).String() {
-			return &ast.DefineDirectiveLocationExpression{directive.Location(i)}
+			return &ast.DefineDirectiveLocationExpression{Location: directive.Location(i)}
 		}
 	}
 	l, c := t.LineCol()
-	log.Fatalf("unknown directive location %s at line %d, col %d", t.Value(), l, c)
-	return nil
+	panic(&SyntaxError{
+		Position: gql.Position{Line: l, Col: c},
+		Message:  "unknown directive location " + t.Value(),
+	})
 }
 
 func (p *Parser) parseDirectiveArgsDefinition() []ast.InputValueExpression {
@@ -261,17 +380,22 @@ func (p *Parser) parseDirectiveArgsDefinition() []ast.InputValueExpression {
 }
 
 func (p *Parser) parseUnion() ast.DefinitionExpression {
+	p.begin()
 	desc := p.parseDescriptionOrEmpty()
 	t := p.pop()
 	validateTokenValue(t, "union")
 	n := p.parseName()
 	directives := p.parseDirectivesOrEmpty()
-	body := p.parseUnionBody()
+	var body []ast.UnionInternalExpression
+	if p.preValueCheck(0, "=") {
+		body = p.parseUnionBody()
+	}
 	return &ast.DefineUnionExpression{
 		DescriptionExpression: desc,
 		NameExpression:        n,
 		DirectiveExpressions:  directives,
 		UnionExpression:       body,
+		Trivia:                p.end(),
 	}
 }
 
@@ -282,26 +406,31 @@ func (p *Parser) parseUnionBody() []ast.UnionInternalExpression {
 		_ = p.pop()
 	}
 	var ts []ast.UnionInternalExpression
-	ts = append(ts, &ast.DefineUnionMemberExpression{p.parseTypeRef()})
+	ts = append(ts, &ast.DefineUnionMemberExpression{TypeExp: p.parseTypeRef()})
 	for p.preValueCheck(0, "|") {
 		_ = p.pop()
-		ts = append(ts, &ast.DefineUnionMemberExpression{p.parseTypeRef()})
+		ts = append(ts, &ast.DefineUnionMemberExpression{TypeExp: p.parseTypeRef()})
 	}
 	return ts
 }
 
 func (p *Parser) parseInterface() ast.DefinitionExpression {
+	p.begin()
 	desc := p.parseDescriptionOrEmpty()
 	t := p.pop()
 	validateTokenValue(t, "interface")
 	n := p.parseName()
 	directives := p.parseDirectivesOrEmpty()
-	body := p.parseInterfaceBody()
+	var body []ast.InterfaceInternalExpression
+	if p.preValueCheck(0, "{") {
+		body = p.parseInterfaceBody()
+	}
 	return &ast.DefineInterfaceExpression{
 		DescriptionExpression: desc,
 		NameExpression:        n,
 		DirectiveExpressions:  directives,
 		InterfaceExpression:   body,
+		Trivia:                p.end(),
 	}
 }
 
@@ -317,19 +446,23 @@ func (p *Parser) parseInterfaceBody() []ast.InterfaceInternalExpression {
 }
 
 func (p *Parser) parseType() ast.DefinitionExpression {
+	p.begin()
 	desc := p.parseDescriptionOrEmpty()
 	t := p.pop()
 	validateTokenValue(t, "type")
 	n := p.parseName()
 	exps := p.parseImplementsOrEmpty()
 	directives := p.parseDirectivesOrEmpty()
-	exps = append(exps, p.parseObjectBody()...)
+	if p.preValueCheck(0, "{") {
+		exps = append(exps, p.parseObjectBody()...)
+	}
 
 	return &ast.DefineObjectExpression{
 		DescriptionExpression: desc,
 		NameExpression:        n,
 		DirectiveExpressions:  directives,
 		ObjectExpression:      exps,
+		Trivia:                p.end(),
 	}
 }
 
@@ -345,6 +478,7 @@ func (p *Parser) parseObjectBody() []ast.ObjectInternalExpression {
 }
 
 func (p *Parser) parseInterfaceField() ast.InterfaceInternalExpression {
+	p.begin()
 	desc := p.parseDescriptionOrEmpty()
 	n := p.parseName()
 	args := p.parseFieldArgsOrEmpty()
@@ -358,10 +492,12 @@ func (p *Parser) parseInterfaceField() ast.InterfaceInternalExpression {
 		DescriptionExp: desc,
 		DirectivesExp:  directives,
 		ArgsExp:        args,
+		Trivia:         p.end(),
 	}
 }
 
 func (p *Parser) parseObjectField() ast.ObjectInternalExpression {
+	p.begin()
 	desc := p.parseDescriptionOrEmpty()
 	n := p.parseName()
 	args := p.parseFieldArgsOrEmpty()
@@ -375,6 +511,7 @@ func (p *Parser) parseObjectField() ast.ObjectInternalExpression {
 		Description: desc,
 		Directives:  directives,
 		Args:        args,
+		Trivia:      p.end(),
 	}
 }
 
@@ -392,6 +529,7 @@ func (p *Parser) parseFieldArgsOrEmpty() []ast.InputValueExpression {
 }
 
 func (p *Parser) parseInputValue() ast.InputValueExpression {
+	p.begin()
 	desc := p.parseDescriptionOrEmpty()
 	n := p.parseName()
 	t := p.pop()
@@ -409,6 +547,7 @@ func (p *Parser) parseInputValue() ast.InputValueExpression {
 		Type:         typ,
 		DefaultValue: def,
 		Directives:   directives,
+		Trivia:       p.end(),
 	}
 }
 
@@ -421,27 +560,32 @@ func (p *Parser) parseImplementsOrEmpty() []ast.ObjectInternalExpression {
 	if p.preValueCheck(0, "&") {
 		_ = p.pop()
 	}
-	exps = append(exps, &ast.ImplementExpression{p.parseTypeRef()})
+	exps = append(exps, &ast.ImplementExpression{TypeExp: p.parseTypeRef()})
 	for p.preValueCheck(0, "&") {
 		_ = p.pop()
-		exps = append(exps, &ast.ImplementExpression{p.parseTypeRef()})
+		exps = append(exps, &ast.ImplementExpression{TypeExp: p.parseTypeRef()})
 	}
 	return exps
 }
 
 func (p *Parser) parseEnum() ast.DefinitionExpression {
+	p.begin()
 	desc := p.parseDescriptionOrEmpty()
 	t := p.pop()
 	validateTokenValue(t, "enum")
 	name := p.parseName()
 	directives := p.parseDirectivesOrEmpty()
-	values := p.parseEnumBody()
+	var values []ast.EnumInternalExpression
+	if p.preValueCheck(0, "{") {
+		values = p.parseEnumBody()
+	}
 
 	return &ast.DefineEnumExpression{
 		DescriptionExpression: desc,
 		NameExpression:        name,
 		DirectiveExpressions:  directives,
 		EnumExpression:        values,
+		Trivia:                p.end(),
 	}
 }
 
@@ -450,6 +594,7 @@ func (p *Parser) parseEnumBody() []ast.EnumInternalExpression {
 	validateTokenValue(t, "{")
 	var values []ast.EnumInternalExpression
 	for !p.preValueCheck(0, "}") {
+		p.begin()
 		desc := p.parseDescriptionOrEmpty()
 		name := p.parseName()
 		directives := p.parseDirectivesOrEmpty()
@@ -457,6 +602,7 @@ func (p *Parser) parseEnumBody() []ast.EnumInternalExpression {
 			Directives:  directives,
 			Name:        name,
 			Description: desc,
+			Trivia:      p.end(),
 		})
 	}
 	_ = p.pop()
@@ -464,6 +610,7 @@ func (p *Parser) parseEnumBody() []ast.EnumInternalExpression {
 }
 
 func (p *Parser) parseScalar() ast.DefinitionExpression {
+	p.begin()
 	desc := p.parseDescriptionOrEmpty()
 	t := p.pop()
 	validateTokenValue(t, "scalar")
@@ -473,67 +620,70 @@ func (p *Parser) parseScalar() ast.DefinitionExpression {
 		DescriptionExpression: desc,
 		NameExpression:        name,
 		DirectiveExpressions:  directives,
+		Trivia:                p.end(),
 	}
 }
 func (p *Parser) parseDescriptionOrEmpty() ast.DescriptionExpression {
-	t := p.lexer.Pop()
+	t := p.pop()
 	if t.Type() != token.TypeStrVal {
-		p.lexer.Push(t)
+		p.push(t)
 		return &ast.EmptyDescription{}
 	}
-	return &ast.DescriptionExpressionImpl{t.Value()}
+	return &ast.DescriptionExpressionImpl{Description: t.Value()}
 }
 
 func (p *Parser) parseSchema() ast.DefinitionExpression {
+	p.begin()
 	t := p.pop()
 	validateTokenValue(t, "schema")
 	directives := p.parseDirectivesOrEmpty()
 	exp := p.parseSchemaBody()
 	return &ast.DefineSchemaExpression{
 		Expressions: exp, DirectiveExpressions: directives,
+		Trivia: p.end(),
 	}
 }
 
+// parseSchemaBody parses root operation types, of which there must be at
+// least one.
 func (p *Parser) parseSchemaBody() []ast.SchemaInternalExpression {
 	t := p.pop()
 	validateTokenValue(t, "{")
-	t = p.pop()
-	var exp []ast.SchemaInternalExpression
-	for t.Value() != "}" {
-		switch t.Value() {
-		case "query":
-			t = p.pop()
-			validateTokenValue(t, ":")
-			texp := p.parseTypeRef()
-			exp = append(exp, &ast.DefineQueryExpression{texp})
-		case "mutation":
-			t = p.pop()
-			validateTokenValue(t, ":")
-			texp := p.parseTypeRef()
-			exp = append(exp, &ast.DefineMutationExpression{texp})
-		case "subscription":
-			t = p.pop()
-			validateTokenValue(t, ":")
-			texp := p.parseTypeRef()
-			exp = append(exp, &ast.DefineSubscriptionExpression{texp})
-		}
-		t = p.pop()
+	exp := []ast.SchemaInternalExpression{p.parseOperationType()}
+	for !p.preValueCheck(0, "}") {
+		exp = append(exp, p.parseOperationType())
 	}
+	_ = p.pop()
 	return exp
 }
 
+func (p *Parser) parseOperationType() ast.SchemaInternalExpression {
+	p.begin()
+	op := p.pop()
+	validateTokenValue(op, "query", "mutation", "subscription")
+	t := p.pop()
+	validateTokenValue(t, ":")
+	texp := p.parseTypeRef()
+	switch op.Value() {
+	case "query":
+		return &ast.DefineQueryExpression{Type: texp, Trivia: p.end()}
+	case "mutation":
+		return &ast.DefineMutationExpression{Type: texp, Trivia: p.end()}
+	}
+	return &ast.DefineSubscriptionExpression{Type: texp, Trivia: p.end()}
+}
+
 func (p *Parser) parseDirectivesOrEmpty() []ast.DirectiveExpression {
 	var directves []ast.DirectiveExpression
 	for p.preValueCheck(0, "@") {
 		_ = p.pop()
 		name := p.parseName()
-		args := map[string]ast.ValueExpression{}
-		if p.preValueCheck(0, "(") {
-			args = p.parseDirectiveArgs()
-		}
+		args, names := p.parseArguments()
 		directves = append(
 			directves,
-			&ast.DirectiveExpressionImpl{name.Eval(), args},
+			&ast.DirectiveExpressionImpl{
+				Name: name.Eval(), Pos: name.Position(), Args: args, ArgNames: names,
+			},
 		)
 	}
 	return directves
@@ -545,19 +695,22 @@ func (p *Parser) parseDirectives() []ast.DirectiveExpression {
 	for p.preValueCheck(0, "@") {
 		_ = p.pop()
 		name := p.parseName()
-		args := p.parseDirectiveArgs()
+		args, names := p.parseArguments()
 		directves = append(
 			directves,
-			&ast.DirectiveExpressionImpl{name.Eval(), args},
+			&ast.DirectiveExpressionImpl{
+				Name: name.Eval(), Pos: name.Position(), Args: args, ArgNames: names,
+			},
 		)
 	}
 	return directves
 }
 
-func (p *Parser) parseDirectiveArgs() map[string]ast.ValueExpression {
+func (p *Parser) parseArguments() (map[string]ast.ValueExpression, []string) {
 	args := map[string]ast.ValueExpression{}
-	if ! p.preValueCheck(0, "(") {
-		return args
+	var names []string
+	if !p.preValueCheck(0, "(") {
+		return args, names
 	}
 	t := p.pop()
 	t = p.pop()
@@ -568,9 +721,10 @@ func (p *Parser) parseDirectiveArgs() map[string]ast.ValueExpression {
 		validateTokenValue(t, ":")
 		v := p.parseValue()
 		args[name] = v
+		names = append(names, name)
 		t = p.pop()
 	}
-	return args
+	return args, names
 }
 
 func (p *Parser) parseValue() ast.ValueExpression {
@@ -581,14 +735,35 @@ func (p *Parser) parseValue() ast.ValueExpression {
 			Value: t.Value(),
 		}
 	}
-	if t.Value() == "[" {
+	switch t.Value() {
+	case "[":
 		p.push(t)
 		return p.parseListValue()
+	case "{":
+		p.push(t)
+		return p.parseObjectValue()
+	case "$":
+		return &ast.VariableExpressionImpl{Name: p.parseName().Eval()}
 	}
 	unexpectedToken(t)
 	return nil
 }
 
+func (p *Parser) parseObjectValue() ast.ValueExpression {
+	t := p.pop()
+	validateTokenValue(t, "{")
+	fields := map[string]ast.ValueExpression{}
+	var names []string
+	for !p.preValueCheck(0, "}") {
+		name := p.parseName().Eval()
+		validateTokenValue(p.pop(), ":")
+		fields[name] = p.parseValue()
+		names = append(names, name)
+	}
+	p.pop()
+	return &ast.ObjectValueExpressionImpl{Fields: fields, FieldNames: names}
+}
+
 func (p *Parser) parseListValue() ast.ValueExpression {
 	t := p.pop()
 	validateTokenValue(t, "[")
@@ -601,9 +776,14 @@ func (p *Parser) parseListValue() ast.ValueExpression {
 }
 
 func (p *Parser) parseName() ast.NameExpression {
-	t := p.lexer.Pop()
+	t := p.pop()
 	validateTokenType(t, token.TypeName)
-	return &ast.NameExpressionImpl{t.Value()}
+	return &ast.NameExpressionImpl{Name: t.Value(), Pos: p.position(t)}
+}
+
+func (p *Parser) position(t *token.Token) gql.Position {
+	l, c := t.LineCol()
+	return gql.Position{Filename: p.filename, Line: l, Col: c}
 }
 
 func (p *Parser) parseTypeRef() ast.TypeRefExpression {
@@ -620,7 +800,10 @@ func (p *Parser) parseTypeRef() ast.TypeRefExpression {
 		_ = p.pop()
 		isNullable = false
 	}
-	return &ast.TypeRefExpressionImpl{nil, isNullable, name}
+	return &ast.TypeRefExpressionImpl{
+		IsNullable: isNullable,
+		Name:       name,
+	}
 }
 
 func (p *Parser) parseList() ast.TypeRefExpression {
@@ -634,7 +817,11 @@ func (p *Parser) parseList() ast.TypeRefExpression {
 		_ = p.pop()
 		isNullable = false
 	}
-	return &ast.TypeRefExpressionImpl{inner, isNullable, &ast.NameExpressionImpl{"[]"}}
+	return &ast.TypeRefExpressionImpl{
+		InnerType:  inner,
+		IsNullable: isNullable,
+		Name:       &ast.NameExpressionImpl{Name: "[]", Pos: p.position(t)},
+	}
 }
 
 func validateTokenValue(t *token.Token, value ...string) {
@@ -655,9 +842,14 @@ func validateTokenType(token *token.Token, ts ...token.Type) {
 	unexpectedToken(token)
 }
 
-func assertNotNil(t *token.Token) {
+// assertNotNil reports the end of the source if t is nil.
+func (p *Parser) assertNotNil(t *token.Token) {
 	if t == nil {
-		log.Fatalf("unexpected eof")
+		l, c := p.lexer.LineCol()
+		panic(&SyntaxError{
+			Position: gql.Position{Line: l, Col: c},
+			Message:  "unexpected eof",
+		})
 	}
 }
 
@@ -669,25 +861,73 @@ func validateToken(token *token.Token, predicate func(t *token.Token) bool) {
 
 func unexpectedToken(t *token.Token) {
 	l, c := t.LineCol()
-	panic(errors.New(""))
-	log.Fatalf("unexpected token '%s' at line %d, col %d", t.Value(), l, c)
+	panic(&SyntaxError{
+		Position: gql.Position{Line: l, Col: c},
+		Message:  "unexpected token '" + t.Value() + "'",
+	})
 }
 
 func (p *Parser) pop() *token.Token {
-	t := p.lexer.Pop()
-	assertNotNil(t)
+	t := p.popOrNil()
+	p.assertNotNil(t)
 	return t
 }
 
 func (p *Parser) popOrNil() *token.Token {
 	t := p.lexer.Pop()
+	if t != nil && len(p.nodes) > 0 {
+		top := len(p.nodes) - 1
+		p.nodes[top] = append(p.nodes[top], t)
+	}
 	return t
 }
 
 func (p *Parser) push(t *token.Token) {
+	if len(p.nodes) > 0 {
+		top := len(p.nodes) - 1
+		if n := len(p.nodes[top]); n > 0 && p.nodes[top][n-1] == t {
+			p.nodes[top] = p.nodes[top][:n-1]
+		}
+	}
 	p.lexer.Push(t)
 }
 
+// begin starts a node. Tokens popped until the corresponding end belong to
+// the node unless they belong to its children.
+func (p *Parser) begin() {
+	p.nodes = append(p.nodes, nil)
+}
+
+// end finishes the node begun last and returns trivia around it.
+func (p *Parser) end() ast.Trivia {
+	tokens := p.nodes[len(p.nodes)-1]
+	p.nodes = p.nodes[:len(p.nodes)-1]
+	if len(tokens) == 0 {
+		return ast.Trivia{}
+	}
+	first, last := tokens[0], tokens[len(tokens)-1]
+	line, _ := first.LineCol()
+	trivia := ast.Trivia{
+		Leading:  append([]*token.Token(nil), first.LeadingTrivia()...),
+		Trailing: last.TrailingTrivia(),
+		Line:     line,
+	}
+	if len(tokens) == 1 {
+		return trivia
+	}
+	trivia.Leading = append(trivia.Leading, first.TrailingTrivia()...)
+	for _, t := range tokens[1 : len(tokens)-1] {
+		trivia.Leading = append(trivia.Leading, t.LeadingTrivia()...)
+		trivia.Leading = append(trivia.Leading, t.TrailingTrivia()...)
+	}
+	if last.Value() == "}" {
+		trivia.Closing = last.LeadingTrivia()
+	} else {
+		trivia.Leading = append(trivia.Leading, last.LeadingTrivia()...)
+	}
+	return trivia
+}
+
 func (p *Parser) preCheck(index int, predicate func(t *token.Token) bool) bool {
 	var store []*token.Token
 	defer func() {
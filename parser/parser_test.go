@@ -0,0 +1,269 @@
+package parser
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/ast"
+	"github.com/RettyEng/gqlcodegen/internal/testschema"
+	"github.com/RettyEng/gqlcodegen/lexer"
+	"github.com/RettyEng/gqlcodegen/lexer/token"
+	"github.com/RettyEng/gqlcodegen/printer"
+)
+
+var schemaSeeds = []string{
+	"",
+	"type A { a: Int }",
+	"schema { query: Q mutation: M }\ntype Q { a(b: Int = 1, c: [String!] = [\"d\"]): A @e(f: {g: H}) }",
+	"# leading\n\"desc\" interface I { a: Int } # trailing\ntype T implements I & J { a: Int }\n# end",
+	"enum E { A @deprecated(reason: \"no\") B }\nunion U = A | B\ninput I { a: Int = 0 }\nscalar S",
+	"extend type A { b: Int }\nextend enum E { C }\ndirective @d(a: Int) on FIELD_DEFINITION | OBJECT",
+	"type A {",
+	"type A { a: }",
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range schemaSeeds {
+		f.Add(s)
+	}
+	f.Add(testschema.Large(2))
+	if b, e := ioutil.ReadFile("../example/schema.graphqls"); e == nil {
+		f.Add(string(b))
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		top, e := NewParser(strings.NewReader(src)).Parse()
+		if e != nil {
+			return
+		}
+		// Printing is stable once the schema is printed.
+		var printed bytes.Buffer
+		if e := printer.Fprint(&printed, top); e != nil {
+			t.Fatal(e)
+		}
+		top, e = NewParser(bytes.NewReader(printed.Bytes())).Parse()
+		if e != nil {
+			t.Fatalf("printed schema does not parse: %v\n%s", e, printed.String())
+		}
+		var reprinted bytes.Buffer
+		if e := printer.Fprint(&reprinted, top); e != nil {
+			t.Fatal(e)
+		}
+		if printed.String() != reprinted.String() {
+			t.Errorf("printed\n%s\nthen\n%s", printed.String(), reprinted.String())
+		}
+	})
+}
+
+var executableSeeds = []string{
+	"{ a }",
+	"query Q($a: Int = 1, $b: [B!]!) @c { a(b: $a) { ...F ... on T { c } ... @d { e } } }\nfragment F on T { f: g(h: {i: [1, 2.5, \"j\", true, null, K]}) }",
+	"mutation { a } subscription S { b }",
+	"{ a(",
+}
+
+func FuzzParseExecutable(f *testing.F) {
+	for _, s := range executableSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		doc, e := NewParser(strings.NewReader(src)).ParseExecutable()
+		if e != nil {
+			return
+		}
+		var printed bytes.Buffer
+		if e := printer.Fprint(&printed, doc.Eval()); e != nil {
+			t.Fatal(e)
+		}
+		doc, e = NewParser(bytes.NewReader(printed.Bytes())).ParseExecutable()
+		if e != nil {
+			t.Fatalf("printed document does not parse: %v\n%s", e, printed.String())
+		}
+		var reprinted bytes.Buffer
+		if e := printer.Fprint(&reprinted, doc.Eval()); e != nil {
+			t.Fatal(e)
+		}
+		if printed.String() != reprinted.String() {
+			t.Errorf("printed\n%s\nthen\n%s", printed.String(), reprinted.String())
+		}
+	})
+}
+
+func TestSyntaxError(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"type A {", "schema.graphqls:1:9: unexpected eof"},
+		{"type A {\n  a: Int\n", "schema.graphqls:3:1: unexpected eof"},
+		{"type A { a: Int }\ndirective", "schema.graphqls:2:10: unexpected eof"},
+		{"type A { a: }", "schema.graphqls:1:13: unexpected token '}'"},
+		{"type A {\n  a: Int\n} }", "schema.graphqls:3:3: unexpected token '}'"},
+		{"input A { a: Int = ? }", "schema.graphqls:1:20: unexpected token ?"},
+	}
+	for _, tt := range tests {
+		_, e := NewFileParser("schema.graphqls", strings.NewReader(tt.src)).Parse()
+		if e == nil {
+			t.Errorf("%q is parsed without an error", tt.src)
+			continue
+		}
+		if _, ok := e.(*SyntaxError); !ok {
+			t.Errorf("%q: got %T, want *SyntaxError", tt.src, e)
+		}
+		if e.Error() != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.src, e.Error(), tt.want)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{src: "extend type A { b: Int }\ntype A { a: Int }\nextend scalar S @s\nscalar S"},
+		{src: "type A { a: Int }\nextend type B { b: Int }", want: "schema.graphqls:2:13: cannot extend undefined type B"},
+		{src: "scalar A\nextend enum A { X }", want: "schema.graphqls:2:13: cannot extend undefined enum A"},
+		{src: "extend input I { a: Int }", want: "schema.graphqls:1:14: cannot extend undefined input I"},
+	}
+	for _, tt := range tests {
+		top, e := NewFileParser("schema.graphqls", strings.NewReader(tt.src)).Parse()
+		if e != nil {
+			t.Fatal(e)
+		}
+		ts, e := top.Eval()
+		if tt.want == "" {
+			if e != nil {
+				t.Errorf("%q: %v", tt.src, e)
+			} else if fs := ts.ObjectTypes["A"].Fields; len(fs) != 2 || fs[0].Name != "a" {
+				t.Errorf("%q: extension is not evaluated after the definition", tt.src)
+			}
+			continue
+		}
+		if _, ok := e.(*ast.EvalError); !ok || e.Error() != tt.want {
+			t.Errorf("%q: got %v, want *ast.EvalError %s", tt.src, e, tt.want)
+		}
+	}
+}
+
+// TestKeepAllTrivia rebuilds sources from their significant tokens and the
+// trivia of the nodes parsed with KeepAllTrivia. Every trivia must be held by
+// exactly one node.
+func TestKeepAllTrivia(t *testing.T) {
+	schemas := []string{
+		"\uFEFFtype A {\r\n\ta: Int,, b(x: Int, y: [String!] = [\"a\", \"b\"]) : A\r\n}\r\n",
+		"schema @a { query: Q, mutation: M }  # schema\n\nextend schema { subscription: S }\n\n\n",
+		"\"\"\"\n  block\n\"\"\"\nenum E { A @deprecated( reason : \"no\" ) , B } # e\n# end",
+		"union U =\n  | A\n  | B\ninput I { a: Int = 0, b: I = { a: 1 } }\nscalar S @s\ndirective @d(a: Int) on\n  | FIELD\n  | OBJECT\n",
+		"interface I { a: Int } extend interface I @i\ntype T implements I & J { a: Int }\nextend type T { b: Int }\nextend union U = C\nextend enum E { C }\nextend input I { c: Int }\nextend scalar S @s",
+		testschema.Large(2),
+	}
+	if b, e := ioutil.ReadFile("../example/schema.graphqls"); e == nil {
+		schemas = append(schemas, string(b))
+	} else {
+		t.Error(e)
+	}
+	for _, src := range schemas {
+		p := NewParser(strings.NewReader(src))
+		p.KeepAllTrivia()
+		top, e := p.Parse()
+		if e != nil {
+			t.Errorf("%v\n%s", e, src)
+			continue
+		}
+		checkRebuilt(t, src, top)
+	}
+
+	documents := []string{
+		"query Q($a: Int = 1, $b: [B!]!) @c {\n  a(b: $a) { ...F, ... on T { c } ... @d { e } }\n}\n\nfragment F on T { f: g(h: {i: [1, 2.5]}) } # f\n",
+		"# anonymous\n{ a , b }\n\nmutation { a }\r\nsubscription S { b }",
+	}
+	for _, src := range documents {
+		p := NewParser(strings.NewReader(src))
+		p.KeepAllTrivia()
+		doc, e := p.ParseExecutable()
+		if e != nil {
+			t.Errorf("%v\n%s", e, src)
+			continue
+		}
+		checkRebuilt(t, src, doc)
+	}
+}
+
+func checkRebuilt(t *testing.T, src string, node interface{}) {
+	t.Helper()
+	var trivia []*token.Token
+	collectTrivia(reflect.ValueOf(node), &trivia)
+
+	tokens := append([]*token.Token(nil), trivia...)
+	seen := map[*token.Token]bool{}
+	for _, tr := range trivia {
+		if seen[tr] {
+			l, c := tr.LineCol()
+			t.Errorf("trivia %q at %d:%d is held twice\n%s", tr.Value(), l, c, src)
+		}
+		seen[tr] = true
+	}
+	lx := lexer.NewLexer(strings.NewReader(src))
+	for tk := lx.Pop(); tk != nil; tk = lx.Pop() {
+		tokens = append(tokens, tk)
+	}
+	sort.SliceStable(tokens, func(i, j int) bool {
+		li, ci := tokens[i].LineCol()
+		lj, cj := tokens[j].LineCol()
+		return li < lj || li == lj && ci < cj
+	})
+
+	var b strings.Builder
+	for _, tk := range tokens {
+		b.WriteString(tk.Value())
+	}
+	if b.String() != src {
+		t.Errorf("rebuilt\n%q\nwant\n%q", b.String(), src)
+	}
+}
+
+var triviaType = reflect.TypeOf(ast.Trivia{})
+
+// collectTrivia appends tokens of every ast.Trivia reachable from v.
+func collectTrivia(v reflect.Value, trivia *[]*token.Token) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() || v.Type() == reflect.TypeOf(&token.Token{}) {
+			return
+		}
+		collectTrivia(v.Elem(), trivia)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			collectTrivia(v.Index(i), trivia)
+		}
+	case reflect.Struct:
+		if v.Type() == triviaType {
+			tv := v.Interface().(ast.Trivia)
+			*trivia = append(*trivia, tv.Leading...)
+			*trivia = append(*trivia, tv.Trailing...)
+			*trivia = append(*trivia, tv.Closing...)
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				collectTrivia(v.Field(i), trivia)
+			}
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	src := testschema.Large(1500)
+	b.SetBytes(int64(len(src)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, e := NewParser(strings.NewReader(src)).Parse(); e != nil {
+			b.Fatal(e)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package printer
+
+import (
+	"github.com/RettyEng/gqlcodegen/ast"
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+/*********************************************************
+TopLevel
+ *********************************************************/
+
+func (p *printer) topLevel(t *ast.TopLevel) {
+	for _, e := range t.Expressions {
+		p.separate()
+		p.definition(e)
+	}
+	if len(ast.Comments(t.Trivia.Closing)) > 0 {
+		p.separate()
+		p.comments(t.Trivia.Closing, "", 0)
+	}
+}
+
+func (p *printer) definition(e ast.DefinitionExpression) {
+	switch d := e.(type) {
+	case *ast.DefineSchemaExpression:
+		p.schemaExpression("", d.DirectiveExpressions, d.Expressions, d.Trivia)
+	case *ast.ExtendSchemaExpression:
+		p.schemaExpression("extend ", d.DirectiveExpressions, d.Expressions, d.Trivia)
+	case *ast.DefineScalarExpression:
+		ts := gql.NewTypeSystem()
+		d.Eval(ts)
+		p.scalar(ts.ScalarTypes[d.NameExpression.Eval()], "", d.Trivia)
+	case *ast.ExtendScalarExpression:
+		p.scalar(&gql.Scalar{
+			Name:       d.NameExpression.Eval(),
+			Directives: evalDirectives(d.DirectiveExpressions),
+		}, "extend ", d.Trivia)
+	case *ast.DefineObjectExpression:
+		p.objectExpression("", d.DescriptionExpression, d.NameExpression, d.DirectiveExpressions, d.ObjectExpression, d.Trivia)
+	case *ast.ExtendObjectExpression:
+		p.objectExpression("extend ", &ast.EmptyDescription{}, d.NameExpression, d.DirectiveExpressions, d.ObjectExpression, d.Trivia)
+	case *ast.DefineInterfaceExpression:
+		p.interfaceExpression("", d.DescriptionExpression, d.NameExpression, d.DirectiveExpressions, d.InterfaceExpression, d.Trivia)
+	case *ast.ExtendInterfaceExpression:
+		p.interfaceExpression("extend ", &ast.EmptyDescription{}, d.NameExpression, d.DirectiveExpressions, d.InterfaceExpression, d.Trivia)
+	case *ast.DefineUnionExpression:
+		p.unionExpression("", d.DescriptionExpression, d.NameExpression, d.DirectiveExpressions, d.UnionExpression, d.Trivia)
+	case *ast.ExtendUnionExpression:
+		p.unionExpression("extend ", &ast.EmptyDescription{}, d.NameExpression, d.DirectiveExpressions, d.UnionExpression, d.Trivia)
+	case *ast.DefineEnumExpression:
+		p.enumExpression("", d.DescriptionExpression, d.NameExpression, d.DirectiveExpressions, d.EnumExpression, d.Trivia)
+	case *ast.ExtendEnumExpression:
+		p.enumExpression("extend ", &ast.EmptyDescription{}, d.NameExpression, d.DirectiveExpressions, d.EnumExpression, d.Trivia)
+	case *ast.DefineInputObjectExpression:
+		p.inputExpression("", d.DescriptionExpression, d.NameExpression, d.DirectiveExpressions, d.DefineInputObjectFieldExpressions, d.Trivia)
+	case *ast.ExtendInputObjectExpression:
+		p.inputExpression("extend ", &ast.EmptyDescription{}, d.NameExpression, d.DirectiveExpressions, d.DefineInputObjectFieldExpressions, d.Trivia)
+	case *ast.DirectiveDefinition:
+		ts := gql.NewTypeSystem()
+		d.Eval(ts)
+		p.directiveDefinition(ts.Directives[d.NameExpression.Eval()], d.Trivia, argTrivia(d.ArgsExpression))
+	default:
+		p.errorf("unsupported definition %T", e)
+	}
+}
+
+func evalDirectives(exp []ast.DirectiveExpression) []*gql.DirectiveRef {
+	var ret []*gql.DirectiveRef
+	for _, e := range exp {
+		ret = append(ret, e.Eval())
+	}
+	return ret
+}
+
+// argTrivia returns trivia of each argument in exps.
+func argTrivia(exps []ast.InputValueExpression) []ast.Trivia {
+	ret := make([]ast.Trivia, len(exps))
+	for i, e := range exps {
+		if v, ok := e.(*ast.InputValueExpressionImpl); ok {
+			ret[i] = v.Trivia
+		}
+	}
+	return ret
+}
+
+// openBlock starts a block unless it is empty. It returns false if the
+// definition has been finished instead.
+func (p *printer) openBlock(n int, tv ast.Trivia) bool {
+	if n == 0 && len(ast.Comments(tv.Closing)) == 0 {
+		p.end(tv)
+		return false
+	}
+	p.printf(" {\n")
+	return true
+}
+
+func (p *printer) schemaExpression(
+	keyword string, direc []ast.DirectiveExpression, exps []ast.SchemaInternalExpression, tv ast.Trivia,
+) {
+	p.head("", "", true, tv)
+	p.printf("%sschema%s", keyword, directives(evalDirectives(direc)))
+	if !p.openBlock(len(exps), tv) {
+		return
+	}
+	for _, e := range exps {
+		switch o := e.(type) {
+		case *ast.DefineQueryExpression:
+			p.head("", indent, false, o.Trivia)
+			p.printf("%squery: %s", indent, o.Type.Eval())
+			p.end(o.Trivia)
+		case *ast.DefineMutationExpression:
+			p.head("", indent, false, o.Trivia)
+			p.printf("%smutation: %s", indent, o.Type.Eval())
+			p.end(o.Trivia)
+		case *ast.DefineSubscriptionExpression:
+			p.head("", indent, false, o.Trivia)
+			p.printf("%ssubscription: %s", indent, o.Type.Eval())
+			p.end(o.Trivia)
+		default:
+			p.errorf("unsupported schema expression %T", e)
+		}
+	}
+	p.closeBlock(tv)
+}
+
+func (p *printer) objectExpression(
+	keyword string,
+	desc ast.DescriptionExpression,
+	name ast.NameExpression,
+	direc []ast.DirectiveExpression,
+	exps []ast.ObjectInternalExpression,
+	tv ast.Trivia,
+) {
+	obj := &gql.Object{}
+	var fields []*ast.DefineFieldExpression
+	for _, e := range exps {
+		switch f := e.(type) {
+		case *ast.ImplementExpression:
+			f.Eval(obj)
+		case *ast.DefineFieldExpression:
+			fields = append(fields, f)
+		default:
+			p.errorf("unsupported object expression %T", e)
+		}
+	}
+	p.head(desc.Eval(), "", true, tv)
+	p.printf(
+		"%stype %s%s%s",
+		keyword, name.Eval(), implements(obj.Implements), directives(evalDirectives(direc)),
+	)
+	if !p.openBlock(len(fields), tv) {
+		return
+	}
+	for i, f := range fields {
+		o := &gql.Object{}
+		f.Eval(o)
+		p.field(o.Fields[0], i == 0, f.Trivia, argTrivia(f.Args))
+	}
+	p.closeBlock(tv)
+}
+
+func (p *printer) interfaceExpression(
+	keyword string,
+	desc ast.DescriptionExpression,
+	name ast.NameExpression,
+	direc []ast.DirectiveExpression,
+	exps []ast.InterfaceInternalExpression,
+	tv ast.Trivia,
+) {
+	p.head(desc.Eval(), "", true, tv)
+	p.printf("%sinterface %s%s", keyword, name.Eval(), directives(evalDirectives(direc)))
+	if !p.openBlock(len(exps), tv) {
+		return
+	}
+	for i, e := range exps {
+		iface := &gql.Interface{}
+		e.Eval(iface)
+		var ftv ast.Trivia
+		var atv []ast.Trivia
+		if f, ok := e.(*ast.DefineInterfaceFieldExpression); ok {
+			ftv, atv = f.Trivia, argTrivia(f.ArgsExp)
+		}
+		p.field(iface.Fields[0], i == 0, ftv, atv)
+	}
+	p.closeBlock(tv)
+}
+
+func (p *printer) unionExpression(
+	keyword string,
+	desc ast.DescriptionExpression,
+	name ast.NameExpression,
+	direc []ast.DirectiveExpression,
+	exps []ast.UnionInternalExpression,
+	tv ast.Trivia,
+) {
+	u := &gql.Union{
+		Description: desc.Eval(),
+		Name:        name.Eval(),
+		Directives:  evalDirectives(direc),
+	}
+	for _, e := range exps {
+		e.Eval(u)
+	}
+	p.union(u, keyword, tv)
+}
+
+func (p *printer) enumExpression(
+	keyword string,
+	desc ast.DescriptionExpression,
+	name ast.NameExpression,
+	direc []ast.DirectiveExpression,
+	exps []ast.EnumInternalExpression,
+	tv ast.Trivia,
+) {
+	p.head(desc.Eval(), "", true, tv)
+	p.printf("%senum %s%s", keyword, name.Eval(), directives(evalDirectives(direc)))
+	if !p.openBlock(len(exps), tv) {
+		return
+	}
+	for i, e := range exps {
+		enum := &gql.Enum{}
+		e.Eval(enum)
+		var vtv ast.Trivia
+		if v, ok := e.(*ast.DefineEnumValueExpression); ok {
+			vtv = v.Trivia
+		}
+		p.enumValue(enum.Values[0], i == 0, vtv)
+	}
+	p.closeBlock(tv)
+}
+
+func (p *printer) inputExpression(
+	keyword string,
+	desc ast.DescriptionExpression,
+	name ast.NameExpression,
+	direc []ast.DirectiveExpression,
+	exps []ast.InputValueExpression,
+	tv ast.Trivia,
+) {
+	p.head(desc.Eval(), "", true, tv)
+	p.printf("%sinput %s%s", keyword, name.Eval(), directives(evalDirectives(direc)))
+	if !p.openBlock(len(exps), tv) {
+		return
+	}
+	tvs := argTrivia(exps)
+	for i, e := range exps {
+		p.inputField(e.Eval(), i == 0, tvs[i])
+	}
+	p.closeBlock(tv)
+}
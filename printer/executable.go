@@ -0,0 +1,88 @@
+package printer
+
+import (
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+/*********************************************************
+ExecutableDocument
+ *********************************************************/
+
+func (p *printer) executableDocument(doc *gql.ExecutableDocument) {
+	for _, op := range doc.Operations {
+		p.separate()
+		p.operation(op)
+	}
+	for _, f := range doc.Fragments {
+		p.separate()
+		p.fragment(f)
+	}
+}
+
+func (p *printer) operation(op *gql.Operation) {
+	p.printf("%s", op.Type)
+	if op.Name != "" {
+		p.printf(" %s", op.Name)
+	}
+	if len(op.Variables) > 0 {
+		var vars []string
+		for _, v := range op.Variables {
+			str := "$" + v.Name + ": " + v.Type.String()
+			if v.Default != nil {
+				str += " = " + v.Default.Value()
+			}
+			vars = append(vars, str+directives(v.Directives))
+		}
+		p.printf("(%s)", strings.Join(vars, ", "))
+	}
+	p.printf("%s", directives(op.Directives))
+	p.selectionSet(op.SelectionSet, "")
+	p.printf("\n")
+}
+
+func (p *printer) fragment(f *gql.Fragment) {
+	p.printf("fragment %s on %s%s", f.Name, f.TypeCondition, directives(f.Directives))
+	p.selectionSet(f.SelectionSet, "")
+	p.printf("\n")
+}
+
+// selectionSet prints sels in a block whose closing bracket is indented by
+// ind. Nothing is printed if sels is empty.
+func (p *printer) selectionSet(sels []gql.Selection, ind string) {
+	if len(sels) == 0 {
+		return
+	}
+	p.printf(" {\n")
+	for _, s := range sels {
+		p.printf("%s%s", ind, indent)
+		switch s := s.(type) {
+		case *gql.Field:
+			if s.Alias != "" {
+				p.printf("%s: ", s.Alias)
+			}
+			p.printf("%s", s.Name)
+			if len(s.ArgNames) > 0 {
+				var args []string
+				for _, n := range s.ArgNames {
+					args = append(args, n+": "+s.Args[n].Value())
+				}
+				p.printf("(%s)", strings.Join(args, ", "))
+			}
+			p.printf("%s", directives(s.Directives))
+			p.selectionSet(s.SelectionSet, ind+indent)
+		case *gql.FragmentSpread:
+			p.printf("...%s%s", s.Name, directives(s.Directives))
+		case *gql.InlineFragment:
+			p.printf("...")
+			if s.TypeCondition != nil {
+				p.printf(" on %s", s.TypeCondition)
+			}
+			p.printf("%s", directives(s.Directives))
+			p.selectionSet(s.SelectionSet, ind+indent)
+		}
+		p.printf("\n")
+	}
+	p.printf("%s}", ind)
+}
@@ -0,0 +1,426 @@
+// Package printer renders schemas as SDL.
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/ast"
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/lexer/token"
+)
+
+const indent = "  "
+
+type printer struct {
+	buff *bytes.Buffer
+	// err is the first error found while printing.
+	err error
+}
+
+// Fprint writes SDL of node to w. node is *gql.TypeSystem, *ast.TopLevel or
+// *gql.ExecutableDocument.
+// A TypeSystem is printed in the canonical order: the schema definition,
+// directive definitions and types grouped by their kinds, each sorted by name.
+// A TopLevel is printed keeping its definitions and extensions as they are,
+// together with comments attached to them.
+// An ExecutableDocument is printed with its operations followed by its
+// fragments.
+func Fprint(w io.Writer, node interface{}) error {
+	p := &printer{buff: bytes.NewBuffer(nil)}
+	switch n := node.(type) {
+	case *gql.TypeSystem:
+		p.typeSystem(n)
+	case *ast.TopLevel:
+		p.topLevel(n)
+	case *gql.ExecutableDocument:
+		p.executableDocument(n)
+	default:
+		return fmt.Errorf("unsupported node %T", node)
+	}
+	if p.err != nil {
+		return p.err
+	}
+	_, e := w.Write(p.buff.Bytes())
+	return e
+}
+
+func (p *printer) printf(format string, args ...interface{}) {
+	fmt.Fprintf(p.buff, format, args...)
+}
+
+// errorf records an error unless one has been recorded already.
+func (p *printer) errorf(format string, args ...interface{}) {
+	if p.err == nil {
+		p.err = fmt.Errorf(format, args...)
+	}
+}
+
+// separate starts a new definition.
+func (p *printer) separate() {
+	if p.buff.Len() > 0 {
+		p.printf("\n")
+	}
+}
+
+/*********************************************************
+TypeSystem
+ *********************************************************/
+
+func (p *printer) typeSystem(ts *gql.TypeSystem) {
+	s := ts.Schema
+	if s.Query != nil || s.Mutation != nil || s.Subscription != nil || len(s.Directives) > 0 {
+		p.separate()
+		p.schema(s)
+	}
+	for _, n := range gql.SortedKeys(ts.Directives) {
+		p.separate()
+		p.directiveDefinition(ts.Directives[n], ast.Trivia{}, nil)
+	}
+	for _, n := range gql.SortedKeys(ts.ScalarTypes) {
+		p.separate()
+		p.scalar(ts.ScalarTypes[n], "", ast.Trivia{})
+	}
+	for _, n := range gql.SortedKeys(ts.InterfaceTypes) {
+		p.separate()
+		p.iface(ts.InterfaceTypes[n], "")
+	}
+	for _, n := range gql.SortedKeys(ts.ObjectTypes) {
+		p.separate()
+		p.object(ts.ObjectTypes[n], "")
+	}
+	for _, n := range gql.SortedKeys(ts.UnionTypes) {
+		p.separate()
+		p.union(ts.UnionTypes[n], "", ast.Trivia{})
+	}
+	for _, n := range gql.SortedKeys(ts.EnumTypes) {
+		p.separate()
+		p.enum(ts.EnumTypes[n], "")
+	}
+	for _, n := range gql.SortedKeys(ts.InputObjectTypes) {
+		p.separate()
+		p.input(ts.InputObjectTypes[n], "")
+	}
+}
+
+func (p *printer) schema(s *gql.Schema) {
+	p.printf("schema%s {\n", directives(s.Directives))
+	if s.Query != nil {
+		p.printf("%squery: %s\n", indent, s.Query)
+	}
+	if s.Mutation != nil {
+		p.printf("%smutation: %s\n", indent, s.Mutation)
+	}
+	if s.Subscription != nil {
+		p.printf("%ssubscription: %s\n", indent, s.Subscription)
+	}
+	p.printf("}\n")
+}
+
+func (p *printer) scalar(s *gql.Scalar, keyword string, tv ast.Trivia) {
+	p.head(s.Description, "", true, tv)
+	p.printf("%sscalar %s%s", keyword, s.Name, directives(s.Directives))
+	p.end(tv)
+}
+
+func (p *printer) object(o *gql.Object, keyword string) {
+	p.description(o.Description, "")
+	p.printf("%stype %s%s%s", keyword, o.Name, implements(o.Implements), directives(o.Directives))
+	p.fields(o.Fields)
+}
+
+func (p *printer) iface(i *gql.Interface, keyword string) {
+	p.description(i.Description, "")
+	p.printf("%sinterface %s%s", keyword, i.Name, directives(i.Directives))
+	p.fields(i.Fields)
+}
+
+func (p *printer) union(u *gql.Union, keyword string, tv ast.Trivia) {
+	p.head(u.Description, "", true, tv)
+	p.printf("%sunion %s%s", keyword, u.Name, directives(u.Directives))
+	var members []string
+	for _, m := range u.Members {
+		members = append(members, m.String())
+	}
+	if len(members) > 0 {
+		p.printf(" = %s", strings.Join(members, " | "))
+	}
+	p.end(tv)
+}
+
+func (p *printer) enum(e *gql.Enum, keyword string) {
+	p.description(e.Description, "")
+	p.printf("%senum %s%s", keyword, e.Name, directives(e.Directives))
+	if len(e.Values) == 0 {
+		p.printf("\n")
+		return
+	}
+	p.printf(" {\n")
+	for i, v := range e.Values {
+		p.enumValue(v, i == 0, ast.Trivia{})
+	}
+	p.printf("}\n")
+}
+
+func (p *printer) enumValue(v *gql.EnumValue, first bool, tv ast.Trivia) {
+	p.head(v.Description, indent, first, tv)
+	p.printf("%s%s%s", indent, v.Name, directives(v.Directives))
+	p.end(tv)
+}
+
+func (p *printer) input(i *gql.InputObject, keyword string) {
+	p.description(i.Description, "")
+	p.printf("%sinput %s%s", keyword, i.Name, directives(i.Directives))
+	if len(i.InputValue) == 0 {
+		p.printf("\n")
+		return
+	}
+	p.printf(" {\n")
+	for j, v := range i.InputValue {
+		p.inputField(v, j == 0, ast.Trivia{})
+	}
+	p.printf("}\n")
+}
+
+func (p *printer) inputField(v *gql.InputValue, first bool, tv ast.Trivia) {
+	p.head(v.Description, indent, first, tv)
+	p.printf("%s%s", indent, inputValue(v))
+	p.end(tv)
+}
+
+// directiveDefinition prints d. argTrivia holds trivia of the arguments and
+// may be nil.
+func (p *printer) directiveDefinition(d *gql.Directive, tv ast.Trivia, argTrivia []ast.Trivia) {
+	p.head(d.Description, "", true, tv)
+	p.printf("directive @%s%s on ", d.Name, p.args(d.Arguments, argTrivia, ""))
+	var locs []string
+	for _, l := range d.Location {
+		locs = append(locs, l.String())
+	}
+	p.printf("%s", strings.Join(locs, " | "))
+	p.end(tv)
+}
+
+func (p *printer) fields(fs []*gql.ObjectField) {
+	if len(fs) == 0 {
+		p.printf("\n")
+		return
+	}
+	p.printf(" {\n")
+	for i, f := range fs {
+		p.field(f, i == 0, ast.Trivia{}, nil)
+	}
+	p.printf("}\n")
+}
+
+func (p *printer) field(f *gql.ObjectField, first bool, tv ast.Trivia, argTrivia []ast.Trivia) {
+	p.head(f.Description, indent, first, tv)
+	p.printf(
+		"%s%s%s: %s%s",
+		indent, f.Name, p.args(f.Args, argTrivia, indent), f.Type, directives(f.Directives),
+	)
+	p.end(tv)
+}
+
+// args returns an argument definition list. Arguments are put on their own
+// lines if any of them has a description or comments. argTrivia holds trivia
+// of args and may be nil.
+func (p *printer) args(args []*gql.InputValue, argTrivia []ast.Trivia, baseIndent string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	var str []string
+	tvs := make([]ast.Trivia, len(args))
+	multiline := false
+	for i, a := range args {
+		str = append(str, inputValue(a))
+		if i < len(argTrivia) {
+			tvs[i] = argTrivia[i]
+		}
+		multiline = multiline || a.Description != "" || hasComments(tvs[i])
+	}
+	if !multiline {
+		return "(" + strings.Join(str, ", ") + ")"
+	}
+
+	sub := &printer{buff: bytes.NewBuffer(nil)}
+	sub.printf("(\n")
+	for i, a := range args {
+		sub.head(a.Description, baseIndent+indent, i == 0, tvs[i])
+		sub.printf("%s%s%s", baseIndent, indent, str[i])
+		sub.end(tvs[i])
+	}
+	sub.printf("%s)", baseIndent)
+	return sub.buff.String()
+}
+
+func inputValue(v *gql.InputValue) string {
+	str := v.Name + ": " + v.Type.String()
+	if v.Default != nil {
+		str += " = " + v.Default.Value()
+	}
+	return str + directives(v.Directives)
+}
+
+func implements(refs []*gql.TypeRef) string {
+	if len(refs) == 0 {
+		return ""
+	}
+	var names []string
+	for _, r := range refs {
+		names = append(names, r.String())
+	}
+	return " implements " + strings.Join(names, " & ")
+}
+
+func directives(refs []*gql.DirectiveRef) string {
+	str := ""
+	for _, d := range refs {
+		str += " @" + d.Name
+		if len(d.Args) == 0 {
+			continue
+		}
+		var args []string
+		for _, n := range argNames(d) {
+			args = append(args, n+": "+d.Args[n].Value())
+		}
+		str += "(" + strings.Join(args, ", ") + ")"
+	}
+	return str
+}
+
+// argNames returns names of arguments of d in the order of appearance.
+// Arguments missing in ArgNames follow in alphabetical order.
+func argNames(d *gql.DirectiveRef) []string {
+	names := append([]string(nil), d.ArgNames...)
+	seen := map[string]struct{}{}
+	for _, n := range names {
+		seen[n] = struct{}{}
+	}
+	var rest []string
+	for n := range d.Args {
+		if _, ok := seen[n]; !ok {
+			rest = append(rest, n)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// head prints comments and the description preceding a node. Descriptions
+// not at the head of a block are preceded by a blank line.
+func (p *printer) head(desc, ind string, first bool, tv ast.Trivia) {
+	if desc != "" && !first {
+		p.printf("\n")
+	}
+	p.comments(tv.Leading, ind, tv.Line)
+	p.description(desc, ind)
+}
+
+// end finishes the line of a node with its trailing comment.
+func (p *printer) end(tv ast.Trivia) {
+	for _, t := range ast.Comments(tv.Trailing) {
+		p.printf(" %s", commentText(t))
+	}
+	p.printf("\n")
+}
+
+// closeBlock prints comments at the end of a block, its closing bracket and
+// the trailing comment.
+func (p *printer) closeBlock(tv ast.Trivia) {
+	p.comments(tv.Closing, indent, 0)
+	p.printf("}")
+	p.end(tv)
+}
+
+// comments prints comments in tokens on their own lines. Blank lines between
+// comments are kept, so is the one between the last comment and the line
+// next, if next is not 0.
+func (p *printer) comments(tokens []*token.Token, ind string, next int) {
+	prev := 0
+	for _, t := range ast.Comments(tokens) {
+		l, _ := t.LineCol()
+		if prev != 0 && l-prev > 1 {
+			p.printf("\n")
+		}
+		p.printf("%s%s\n", ind, commentText(t))
+		prev = l
+	}
+	if prev != 0 && next != 0 && next-prev > 1 {
+		p.printf("\n")
+	}
+}
+
+func commentText(t *token.Token) string {
+	return strings.TrimRight(t.Value(), " \t")
+}
+
+func hasComments(tv ast.Trivia) bool {
+	return len(ast.Comments(tv.Leading)) > 0 ||
+		len(ast.Comments(tv.Trailing)) > 0 ||
+		len(ast.Comments(tv.Closing)) > 0
+}
+
+// description prints the raw description desc. Block strings are reindented
+// with ind. A block string whose value starts with white spaces is printed as
+// a string instead, since the white spaces would be taken as indentation of
+// the block.
+func (p *printer) description(desc, ind string) {
+	if desc == "" {
+		return
+	}
+	if !strings.HasPrefix(desc, `"""`) {
+		p.printf("%s%s\n", ind, desc)
+		return
+	}
+	value := gql.StringValue(desc)
+	if strings.HasPrefix(value, " ") || strings.HasPrefix(value, "\t") {
+		p.printf("%s%s\n", ind, quote(value))
+		return
+	}
+	p.printf("%s\"\"\"\n", ind)
+	value = strings.Replace(value, `"""`, `\"""`, -1)
+	for _, l := range strings.Split(value, "\n") {
+		if l == "" {
+			p.printf("\n")
+			continue
+		}
+		p.printf("%s%s\n", ind, l)
+	}
+	p.printf("%s\"\"\"\n", ind)
+}
+
+// quote returns a string literal of s.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
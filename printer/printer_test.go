@@ -0,0 +1,256 @@
+package printer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/ast"
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/internal/testschema"
+	"github.com/RettyEng/gqlcodegen/parser"
+)
+
+func parse(t *testing.T, src string) *ast.TopLevel {
+	t.Helper()
+	top, e := parser.NewParser(strings.NewReader(src)).Parse()
+	if e != nil {
+		t.Fatalf("%v\n%s", e, src)
+	}
+	return top
+}
+
+func eval(t *testing.T, top *ast.TopLevel) *gql.TypeSystem {
+	t.Helper()
+	ts, e := top.Eval()
+	if e != nil {
+		t.Fatal(e)
+	}
+	return ts
+}
+
+func sprint(t *testing.T, node interface{}) string {
+	t.Helper()
+	var b bytes.Buffer
+	if e := Fprint(&b, node); e != nil {
+		t.Fatal(e)
+	}
+	return b.String()
+}
+
+func TestFprintTypeSystem(t *testing.T) {
+	src := `
+enum E { B A }
+type Q implements I { b(y: Int = 1, x: [String!]): E a: I }
+"desc" scalar S
+schema { query: Q }
+interface I { a: I }
+union U = Q | V
+directive @d(a: Int) on FIELD_DEFINITION | OBJECT
+input In { a: Int = 0 @d }
+extend type Q @d { c: S }
+`
+	want := `schema {
+  query: Q
+}
+
+directive @d(a: Int) on FIELD_DEFINITION | OBJECT
+
+"desc"
+scalar S
+
+interface I {
+  a: I
+}
+
+type Q implements I @d {
+  b(y: Int = 1, x: [String!]): E
+  a: I
+  c: S
+}
+
+union U = Q | V
+
+enum E {
+  B
+  A
+}
+
+input In {
+  a: Int = 0 @d
+}
+`
+	if got := sprint(t, eval(t, parse(t, src))); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFprintTopLevel(t *testing.T) {
+	src := `# head
+
+"""
+  Query root.
+"""
+type Query { # open
+  # field
+  a(
+    # arg
+    x: Int): Int # trailing
+  # closing
+}
+
+extend type Query { b: Int }
+
+enum E {
+  "first"
+  A
+  B # b
+}
+# end
+`
+	want := `# head
+
+# open
+"""
+Query root.
+"""
+type Query {
+  # field
+  a(
+    # arg
+    x: Int
+  ): Int # trailing
+  # closing
+}
+
+extend type Query {
+  b: Int
+}
+
+enum E {
+  "first"
+  A
+  B # b
+}
+
+# end
+`
+	if got := sprint(t, parse(t, src)); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFprintUnsupported(t *testing.T) {
+	top := &ast.TopLevel{Expressions: []ast.DefinitionExpression{nil}}
+	if e := Fprint(ioutil.Discard, top); e == nil {
+		t.Error("unsupported definition is printed without an error")
+	}
+	if e := Fprint(ioutil.Discard, 1); e == nil {
+		t.Error("unsupported node is printed without an error")
+	}
+}
+
+func TestDescription(t *testing.T) {
+	tests := []struct {
+		desc string
+		want string
+	}{
+		{`"a"`, `"a"`},
+		{`"a\nb"`, `"a\nb"`},
+		{`"""a"""`, "\"\"\"\na\n\"\"\""},
+		{`"""  0"""`, `"  0"`},
+		{"\"\"\"\t\"\\\n  b\"\"\"", `"\t\"\\\nb"`},
+		{"\"\"\"\n    a\n      b\n\n    c\n\"\"\"", "\"\"\"\na\n  b\n\nc\n\"\"\""},
+		{`"""a \""" b"""`, "\"\"\"\na \\\"\"\" b\n\"\"\""},
+	}
+	for _, tt := range tests {
+		top := parse(t, tt.desc+" enum A { X }")
+		got := strings.TrimSuffix(sprint(t, top), "\nenum A {\n  X\n}\n")
+		if got != tt.want {
+			t.Errorf("description %s is printed as\n%s\nwant\n%s", tt.desc, got, tt.want)
+		}
+
+		want := gql.StringValue(tt.desc)
+		reparsed := eval(t, parse(t, got+" enum A { X }"))
+		if v := gql.StringValue(reparsed.EnumTypes["A"].Description); v != want {
+			t.Errorf("description %s is printed as %s, whose value is %q, want %q", tt.desc, got, v, want)
+		}
+	}
+}
+
+// TestRoundTrip checks that a printed schema parses to the same type system
+// as the original one.
+func TestRoundTrip(t *testing.T) {
+	sources := []string{
+		testschema.Large(3),
+		`"""  leading""" type A { "  a" a("""
+    x
+  y""" x: Int = 1): [A!]! @deprecated(reason: "no") }`,
+		"schema @a { query: Q mutation: M }\nextend schema { subscription: S }\nscalar S @a(b: {c: [1, 2.5, \"d\", true, null, E]})",
+	}
+	if b, e := ioutil.ReadFile("../example/schema.graphqls"); e == nil {
+		sources = append(sources, string(b))
+	} else {
+		t.Error(e)
+	}
+	for _, src := range sources {
+		printed := sprint(t, parse(t, src))
+		if got, want := canonical(t, printed), canonical(t, src); got != want {
+			t.Errorf("printed schema\n%s\nparses to\n%s\nwant\n%s", printed, got, want)
+		}
+	}
+}
+
+// canonical prints the type system of src in the canonical order with
+// descriptions as strings, so that the same descriptions are printed the same
+// whether they are written as block strings or not.
+func canonical(t *testing.T, src string) string {
+	t.Helper()
+	ts := eval(t, parse(t, src))
+	norm := func(desc *string) {
+		if *desc != "" {
+			*desc = quote(gql.StringValue(*desc))
+		}
+	}
+	args := func(vs []*gql.InputValue) {
+		for _, v := range vs {
+			norm(&v.Description)
+		}
+	}
+	fields := func(fs []*gql.ObjectField) {
+		for _, f := range fs {
+			norm(&f.Description)
+			args(f.Args)
+		}
+	}
+	for _, d := range ts.Directives {
+		norm(&d.Description)
+		args(d.Arguments)
+	}
+	for _, s := range ts.ScalarTypes {
+		norm(&s.Description)
+	}
+	for _, o := range ts.ObjectTypes {
+		norm(&o.Description)
+		fields(o.Fields)
+	}
+	for _, i := range ts.InterfaceTypes {
+		norm(&i.Description)
+		fields(i.Fields)
+	}
+	for _, u := range ts.UnionTypes {
+		norm(&u.Description)
+	}
+	for _, e := range ts.EnumTypes {
+		norm(&e.Description)
+		for _, v := range e.Values {
+			norm(&v.Description)
+		}
+	}
+	for _, i := range ts.InputObjectTypes {
+		norm(&i.Description)
+		args(i.InputValue)
+	}
+	return sprint(t, ts)
+}
@@ -0,0 +1,30 @@
+package validator
+
+import (
+	"github.com/RettyEng/gqlcodegen/ast/directive"
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+func (v *validator) validateDirectives(refs []*gql.DirectiveRef, loc directive.Location) {
+	seen := map[string]bool{}
+	for _, d := range refs {
+		if seen[d.Name] {
+			v.errorf(d.Position, "directive @%s is used more than once at the same location", d.Name)
+		}
+		seen[d.Name] = true
+
+		def, ok := v.directives[d.Name]
+		if !ok {
+			v.errorf(d.Position, "unknown directive @%s", d.Name)
+			continue
+		}
+		allowed := false
+		for _, l := range def.Location {
+			allowed = allowed || l == loc
+		}
+		if !allowed {
+			v.errorf(d.Position, "directive @%s may not be used on %s", d.Name, loc)
+		}
+		v.validateArguments(d.Args, d.ArgNames, def.Arguments, d.Position, "directive @"+d.Name, false)
+	}
+}
@@ -0,0 +1,272 @@
+package validator
+
+import (
+	"github.com/RettyEng/gqlcodegen/ast/directive"
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+func (v *validator) validateOperationNames() {
+	seen := map[string]bool{}
+	for _, op := range v.doc.Operations {
+		if op.Name == "" {
+			if len(v.doc.Operations) > 1 {
+				v.errorf(op.Position, "anonymous operation must be the only defined operation")
+			}
+			continue
+		}
+		if seen[op.Name] {
+			v.errorf(op.Position, "there can be only one operation named %s", op.Name)
+		}
+		seen[op.Name] = true
+	}
+}
+
+func operationName(op *gql.Operation) string {
+	if op.Name == "" {
+		return "anonymous operation"
+	}
+	return "operation " + op.Name
+}
+
+func (v *validator) validateOperation(op *gql.Operation) {
+	v.validateDirectives(op.Directives, operationLocation(op.Type))
+	v.validateVariableDefinitions(op)
+
+	root := v.rootType(op.Type)
+	if root == "" {
+		v.errorf(op.Position, "schema does not support %s", op.Type)
+		return
+	}
+	v.validateSelectionSet(root, op.SelectionSet)
+
+	if op.Type == gql.OperationSubscription {
+		if keys := v.responseKeys(root, op.SelectionSet, map[string]bool{}); len(keys) > 1 {
+			v.errorf(op.Position, "%s must select only one top level field", operationName(op))
+		}
+	}
+	v.validateVariableUsages(op, root)
+}
+
+func operationLocation(t gql.OperationType) directive.Location {
+	switch t {
+	case gql.OperationMutation:
+		return directive.MUTATION
+	case gql.OperationSubscription:
+		return directive.SUBSCRIPTION
+	}
+	return directive.QUERY
+}
+
+// responseKeys returns distinct response keys of fields selected by sels.
+func (v *validator) responseKeys(parent string, sels []gql.Selection, visited map[string]bool) map[string]bool {
+	keys := map[string]bool{}
+	for _, s := range sels {
+		switch s := s.(type) {
+		case *gql.Field:
+			keys[s.ResponseKey()] = true
+		case *gql.InlineFragment:
+			for k := range v.responseKeys(parent, s.SelectionSet, visited) {
+				keys[k] = true
+			}
+		case *gql.FragmentSpread:
+			f := v.doc.Fragment(s.Name)
+			if f == nil || visited[s.Name] {
+				continue
+			}
+			visited[s.Name] = true
+			for k := range v.responseKeys(parent, f.SelectionSet, visited) {
+				keys[k] = true
+			}
+		}
+	}
+	return keys
+}
+
+func (v *validator) validateVariableDefinitions(op *gql.Operation) {
+	seen := map[string]bool{}
+	for _, d := range op.Variables {
+		if seen[d.Name] {
+			v.errorf(d.Position, "there can be only one variable named $%s", d.Name)
+		}
+		seen[d.Name] = true
+
+		if n := d.Type.NamedType(); !v.isInputType(n) {
+			v.errorf(d.Position, "variable $%s cannot be non-input type %s", d.Name, d.Type)
+		} else if d.Default != nil {
+			v.validateValue(d.Default, d.Type, d.Position, "default value of $"+d.Name, true)
+		}
+		v.validateDirectives(d.Directives, directive.VARIABLE_DEFINITION)
+	}
+}
+
+/*********************************************************
+Variable usages
+ *********************************************************/
+
+// usage is a variable used as a value. typ is the type expected at the
+// location, or nil if it is unknown.
+type usage struct {
+	name       string
+	typ        *gql.TypeRef
+	hasDefault bool
+	position   gql.Position
+}
+
+func (v *validator) validateVariableUsages(op *gql.Operation, root string) {
+	var usages []*usage
+	usages = append(usages, v.directiveUsages(op.Directives)...)
+	usages = append(usages, v.selectionUsages(root, op.SelectionSet, map[string]bool{})...)
+
+	defs := map[string]*gql.VariableDefinition{}
+	for _, d := range op.Variables {
+		defs[d.Name] = d
+	}
+	used := map[string]bool{}
+	for _, u := range usages {
+		used[u.name] = true
+		d, ok := defs[u.name]
+		if !ok {
+			v.errorf(u.position, "variable $%s is not defined by %s", u.name, operationName(op))
+			continue
+		}
+		if u.typ != nil && !isVariableUsageAllowed(d, u) {
+			v.errorf(
+				u.position, "variable $%s of type %s is used in position expecting %s",
+				u.name, d.Type, u.typ,
+			)
+		}
+	}
+	for _, d := range op.Variables {
+		if !used[d.Name] {
+			v.errorf(d.Position, "variable $%s is never used in %s", d.Name, operationName(op))
+		}
+	}
+}
+
+func (v *validator) selectionUsages(parent string, sels []gql.Selection, visited map[string]bool) []*usage {
+	var usages []*usage
+	for _, s := range sels {
+		usages = append(usages, v.directiveUsages(s.GetDirectives())...)
+		switch s := s.(type) {
+		case *gql.Field:
+			f := v.field(parent, s.Name)
+			var defs []*gql.InputValue
+			child := ""
+			if f != nil {
+				defs = f.Args
+				child = f.Type.NamedType()
+			}
+			usages = append(usages, v.argumentUsages(s.Args, s.ArgNames, defs, s.Position)...)
+			usages = append(usages, v.selectionUsages(child, s.SelectionSet, visited)...)
+		case *gql.InlineFragment:
+			typ := parent
+			if s.TypeCondition != nil {
+				typ = s.TypeCondition.Name
+			}
+			usages = append(usages, v.selectionUsages(typ, s.SelectionSet, visited)...)
+		case *gql.FragmentSpread:
+			f := v.doc.Fragment(s.Name)
+			if f == nil || visited[s.Name] {
+				continue
+			}
+			visited[s.Name] = true
+			usages = append(usages, v.directiveUsages(f.Directives)...)
+			usages = append(usages, v.selectionUsages(f.TypeCondition.Name, f.SelectionSet, visited)...)
+		}
+	}
+	return usages
+}
+
+func (v *validator) directiveUsages(refs []*gql.DirectiveRef) []*usage {
+	var usages []*usage
+	for _, d := range refs {
+		var defs []*gql.InputValue
+		if def, ok := v.directives[d.Name]; ok {
+			defs = def.Arguments
+		}
+		usages = append(usages, v.argumentUsages(d.Args, d.ArgNames, defs, d.Position)...)
+	}
+	return usages
+}
+
+func (v *validator) argumentUsages(
+	args map[string]gql.Value, names []string, defs []*gql.InputValue, pos gql.Position,
+) []*usage {
+	var usages []*usage
+	for _, name := range names {
+		def := inputValue(defs, name)
+		if def == nil {
+			usages = append(usages, v.valueUsages(args[name], nil, false, pos)...)
+			continue
+		}
+		usages = append(usages, v.valueUsages(args[name], def.Type, def.Default != nil, pos)...)
+	}
+	return usages
+}
+
+func (v *validator) valueUsages(value gql.Value, typ *gql.TypeRef, hasDefault bool, pos gql.Position) []*usage {
+	switch value := value.(type) {
+	case *gql.Variable:
+		return []*usage{{name: value.Name, typ: typ, hasDefault: hasDefault, position: pos}}
+	case *gql.List:
+		inner := typ
+		if typ != nil && typ.Name == "[]" {
+			inner = typ.InnerType
+		}
+		var usages []*usage
+		for _, c := range value.Child {
+			usages = append(usages, v.valueUsages(c, inner, false, pos)...)
+		}
+		return usages
+	case *gql.ObjectValue:
+		var defs []*gql.InputValue
+		if typ != nil {
+			if input, ok := v.ts.InputObjectTypes[typ.Name]; ok {
+				defs = input.InputValue
+			}
+		}
+		return v.argumentUsages(value.Fields, value.FieldNames, defs, pos)
+	}
+	return nil
+}
+
+// isVariableUsageAllowed implements IsVariableUsageAllowed of the
+// specification.
+func isVariableUsageAllowed(d *gql.VariableDefinition, u *usage) bool {
+	if !u.typ.IsNullable && d.Type.IsNullable {
+		hasNonNullDefault := d.Default != nil && !isNull(d.Default)
+		if !hasNonNullDefault && !u.hasDefault {
+			return false
+		}
+		return areTypesCompatible(d.Type, nullable(u.typ))
+	}
+	return areTypesCompatible(d.Type, u.typ)
+}
+
+func areTypesCompatible(varType, locType *gql.TypeRef) bool {
+	if !locType.IsNullable {
+		if varType.IsNullable {
+			return false
+		}
+		return areTypesCompatible(nullable(varType), nullable(locType))
+	}
+	if !varType.IsNullable {
+		return areTypesCompatible(nullable(varType), locType)
+	}
+	if locType.Name == "[]" {
+		if varType.Name != "[]" {
+			return false
+		}
+		return areTypesCompatible(varType.InnerType, locType.InnerType)
+	}
+	if varType.Name == "[]" {
+		return false
+	}
+	return varType.Name == locType.Name
+}
+
+func nullable(t *gql.TypeRef) *gql.TypeRef {
+	c := *t
+	c.IsNullable = true
+	return &c
+}
@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"github.com/RettyEng/gqlcodegen/ast/directive"
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+func (v *validator) validateSelectionSet(parent string, sels []gql.Selection) {
+	for _, s := range sels {
+		switch s := s.(type) {
+		case *gql.Field:
+			v.validateField(parent, s)
+		case *gql.FragmentSpread:
+			v.validateFragmentSpread(parent, s)
+		case *gql.InlineFragment:
+			v.validateInlineFragment(parent, s)
+		}
+	}
+}
+
+func (v *validator) validateField(parent string, f *gql.Field) {
+	v.validateDirectives(f.Directives, directive.FIELD)
+	def := v.field(parent, f.Name)
+	if def == nil {
+		v.errorf(f.Position, "cannot query field %s on type %s", f.Name, parent)
+		return
+	}
+	v.validateArguments(f.Args, f.ArgNames, def.Args, f.Position, "field "+parent+"."+f.Name, false)
+
+	typ := def.Type.NamedType()
+	switch {
+	case isIntrospectionType(typ):
+	case v.isLeafType(typ):
+		if len(f.SelectionSet) > 0 {
+			v.errorf(f.Position, "field %s of type %s must not have a selection", f.Name, def.Type)
+		}
+	case v.isCompositeType(typ):
+		if len(f.SelectionSet) == 0 {
+			v.errorf(
+				f.Position, "field %s of type %s must have a selection of subfields",
+				f.Name, def.Type,
+			)
+		}
+		v.validateSelectionSet(typ, f.SelectionSet)
+	}
+}
+
+func (v *validator) validateFragmentSpread(parent string, s *gql.FragmentSpread) {
+	v.validateDirectives(s.Directives, directive.FRAGMENT_SPREAD)
+	f := v.doc.Fragment(s.Name)
+	if f == nil {
+		v.errorf(s.Position, "unknown fragment %s", s.Name)
+		return
+	}
+	if !v.isCompositeType(f.TypeCondition.Name) {
+		return
+	}
+	if !v.canSpread(parent, f.TypeCondition.Name) {
+		v.errorf(
+			s.Position, "fragment %s cannot be spread here as objects of type %s can never be of type %s",
+			s.Name, parent, f.TypeCondition.Name,
+		)
+	}
+}
+
+func (v *validator) validateInlineFragment(parent string, f *gql.InlineFragment) {
+	v.validateDirectives(f.Directives, directive.INLINE_FRAGMENT)
+	typ := parent
+	if f.TypeCondition != nil {
+		typ = f.TypeCondition.Name
+		if !v.validateTypeCondition(f.TypeCondition) {
+			return
+		}
+		if !v.canSpread(parent, typ) {
+			v.errorf(
+				f.Position, "fragment cannot be spread here as objects of type %s can never be of type %s",
+				parent, typ,
+			)
+		}
+	}
+	v.validateSelectionSet(typ, f.SelectionSet)
+}
+
+// validateTypeCondition reports whether the type condition ref is a defined
+// composite type.
+func (v *validator) validateTypeCondition(ref *gql.TypeRef) bool {
+	if !v.isDefined(ref.Name) {
+		v.errorf(ref.Position, "unknown type %s", ref.Name)
+		return false
+	}
+	if !v.isCompositeType(ref.Name) {
+		v.errorf(ref.Position, "fragment cannot condition on non composite type %s", ref.Name)
+		return false
+	}
+	return true
+}
+
+// canSpread returns whether a fragment on typ applies to some objects of the
+// type parent.
+func (v *validator) canSpread(parent, typ string) bool {
+	typs := v.possibleTypes(typ)
+	for t := range v.possibleTypes(parent) {
+		if typs[t] {
+			return true
+		}
+	}
+	return false
+}
+
+/*********************************************************
+Fragments
+ *********************************************************/
+
+func (v *validator) validateFragmentNames() {
+	seen := map[string]bool{}
+	for _, f := range v.doc.Fragments {
+		if seen[f.Name] {
+			v.errorf(f.Position, "there can be only one fragment named %s", f.Name)
+		}
+		seen[f.Name] = true
+	}
+}
+
+func (v *validator) validateFragment(f *gql.Fragment) {
+	v.validateDirectives(f.Directives, directive.FRAGMENT_DEFINITION)
+	if v.validateTypeCondition(f.TypeCondition) {
+		v.validateSelectionSet(f.TypeCondition.Name, f.SelectionSet)
+	}
+}
+
+// validateFragmentUsage reports fragments which are not used by operations
+// and fragments spreading themselves.
+func (v *validator) validateFragmentUsage() {
+	used := map[string]bool{}
+	var use func(sels []gql.Selection)
+	use = func(sels []gql.Selection) {
+		for _, s := range spreads(sels) {
+			if used[s.Name] {
+				continue
+			}
+			used[s.Name] = true
+			if f := v.doc.Fragment(s.Name); f != nil {
+				use(f.SelectionSet)
+			}
+		}
+	}
+	for _, op := range v.doc.Operations {
+		use(op.SelectionSet)
+	}
+	for _, f := range v.doc.Fragments {
+		if !used[f.Name] {
+			v.errorf(f.Position, "fragment %s is never used", f.Name)
+		}
+	}
+
+	done := map[string]bool{}
+	for _, f := range v.doc.Fragments {
+		v.detectCycles(f, map[string]bool{}, done)
+	}
+}
+
+// detectCycles reports spreads in f which lead back to a fragment in path.
+func (v *validator) detectCycles(f *gql.Fragment, path, done map[string]bool) {
+	if done[f.Name] {
+		return
+	}
+	path[f.Name] = true
+	for _, s := range spreads(f.SelectionSet) {
+		if path[s.Name] {
+			v.errorf(s.Position, "cannot spread fragment %s within itself", s.Name)
+			continue
+		}
+		if next := v.doc.Fragment(s.Name); next != nil {
+			v.detectCycles(next, path, done)
+		}
+	}
+	delete(path, f.Name)
+	done[f.Name] = true
+}
+
+// spreads returns fragment spreads in sels including nested ones.
+func spreads(sels []gql.Selection) []*gql.FragmentSpread {
+	var ret []*gql.FragmentSpread
+	for _, s := range sels {
+		switch s := s.(type) {
+		case *gql.Field:
+			ret = append(ret, spreads(s.SelectionSet)...)
+		case *gql.InlineFragment:
+			ret = append(ret, spreads(s.SelectionSet)...)
+		case *gql.FragmentSpread:
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
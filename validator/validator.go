@@ -0,0 +1,225 @@
+// Package validator validates executable documents against a schema by the
+// validation rules of the GraphQL specification.
+package validator
+
+import (
+	"fmt"
+
+	"github.com/RettyEng/gqlcodegen/ast/directive"
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+// Error is a violation of a validation rule.
+type Error struct {
+	Position gql.Position
+	Message  string
+}
+
+func (e *Error) String() string {
+	return e.Position.String() + ": " + e.Message
+}
+
+func (e *Error) Error() string {
+	return e.String()
+}
+
+type validator struct {
+	ts         *gql.TypeSystem
+	doc        *gql.ExecutableDocument
+	directives map[string]*gql.Directive
+	errors     []*Error
+}
+
+// Validate returns violations of the validation rules in doc against ts.
+// The rules checked are:
+//
+//   - operation names are unique and an anonymous operation is alone
+//   - subscriptions have a single root field
+//   - fields exist on the type they are selected on, and leaf fields have no
+//     selection while the others have one
+//   - arguments are defined, unique, given when required and of their types
+//   - fragments are unique, used, defined, acyclic, on composite types and
+//     spread where they can apply
+//   - variables are unique, of input types, defined, used and used where
+//     their types are allowed
+//   - directives are defined, unique and used in their locations
+func Validate(ts *gql.TypeSystem, doc *gql.ExecutableDocument) []*Error {
+	v := &validator{
+		ts:         ts,
+		doc:        doc,
+		directives: map[string]*gql.Directive{},
+	}
+	for _, d := range builtinDirectives() {
+		v.directives[d.Name] = d
+	}
+	for n, d := range ts.Directives {
+		v.directives[n] = d
+	}
+
+	v.validateOperationNames()
+	v.validateFragmentNames()
+	for _, op := range doc.Operations {
+		v.validateOperation(op)
+	}
+	for _, f := range doc.Fragments {
+		v.validateFragment(f)
+	}
+	v.validateFragmentUsage()
+	return v.errors
+}
+
+func (v *validator) errorf(pos gql.Position, format string, args ...interface{}) {
+	v.errors = append(v.errors, &Error{
+		Position: pos,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+var builtinScalars = map[string]bool{
+	"Int": true, "Float": true, "String": true, "Boolean": true, "ID": true,
+}
+
+func builtinDirectives() []*gql.Directive {
+	ifArg := func() []*gql.InputValue {
+		return []*gql.InputValue{{
+			Name: "if",
+			Type: &gql.TypeRef{Name: "Boolean"},
+		}}
+	}
+	locs := []directive.Location{
+		directive.FIELD, directive.FRAGMENT_SPREAD, directive.INLINE_FRAGMENT,
+	}
+	return []*gql.Directive{
+		{Name: "skip", Arguments: ifArg(), Location: locs},
+		{Name: "include", Arguments: ifArg(), Location: locs},
+		{
+			Name: "deprecated",
+			Arguments: []*gql.InputValue{{
+				Name:    "reason",
+				Type:    &gql.TypeRef{Name: "String", IsNullable: true},
+				Default: &gql.ValueImpl{Val: `"No longer supported"`},
+			}},
+			Location: []directive.Location{
+				directive.FIELD_DEFINITION, directive.ARGUMENT_DEFINITION,
+				directive.INPUT_FIELD_DEFINITION, directive.ENUM_VALUE,
+			},
+		},
+	}
+}
+
+/*********************************************************
+Types
+ *********************************************************/
+
+// rootType returns the name of the root operation type of t, or "" if the
+// schema does not support t.
+func (v *validator) rootType(t gql.OperationType) string {
+	var ref *gql.TypeRef
+	name := ""
+	switch t {
+	case gql.OperationQuery:
+		ref, name = v.ts.Schema.Query, "Query"
+	case gql.OperationMutation:
+		ref, name = v.ts.Schema.Mutation, "Mutation"
+	case gql.OperationSubscription:
+		ref, name = v.ts.Schema.Subscription, "Subscription"
+	}
+	if ref != nil {
+		name = ref.Name
+	}
+	if _, ok := v.ts.ObjectTypes[name]; !ok {
+		return ""
+	}
+	return name
+}
+
+func (v *validator) isDefined(name string) bool {
+	return v.isInputType(name) || v.isCompositeType(name)
+}
+
+func (v *validator) isCompositeType(name string) bool {
+	_, obj := v.ts.ObjectTypes[name]
+	_, iface := v.ts.InterfaceTypes[name]
+	_, union := v.ts.UnionTypes[name]
+	return obj || iface || union
+}
+
+func (v *validator) isLeafType(name string) bool {
+	_, scalar := v.ts.ScalarTypes[name]
+	_, enum := v.ts.EnumTypes[name]
+	return builtinScalars[name] || scalar || enum
+}
+
+func (v *validator) isInputType(name string) bool {
+	_, input := v.ts.InputObjectTypes[name]
+	return v.isLeafType(name) || input
+}
+
+// fields returns fields of the object or the interface named name.
+func (v *validator) fields(name string) []*gql.ObjectField {
+	if o, ok := v.ts.ObjectTypes[name]; ok {
+		return o.Fields
+	}
+	if i, ok := v.ts.InterfaceTypes[name]; ok {
+		return i.Fields
+	}
+	return nil
+}
+
+// field returns the field name of the composite type parent including meta
+// fields, or nil if there is no such field.
+func (v *validator) field(parent, name string) *gql.ObjectField {
+	switch name {
+	case "__typename":
+		return &gql.ObjectField{Name: name, Type: &gql.TypeRef{Name: "String"}}
+	case "__schema":
+		if parent == v.rootType(gql.OperationQuery) {
+			return &gql.ObjectField{Name: name, Type: &gql.TypeRef{Name: "__Schema"}}
+		}
+	case "__type":
+		if parent == v.rootType(gql.OperationQuery) {
+			return &gql.ObjectField{
+				Name: name,
+				Type: &gql.TypeRef{Name: "__Type", IsNullable: true},
+				Args: []*gql.InputValue{{Name: "name", Type: &gql.TypeRef{Name: "String"}}},
+			}
+		}
+	}
+	for _, f := range v.fields(parent) {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// isIntrospectionType returns whether name is a type of the introspection
+// system, which is not part of the schema and whose selections are not
+// validated.
+func isIntrospectionType(name string) bool {
+	return len(name) > 2 && name[:2] == "__"
+}
+
+// possibleTypes returns names of object types which the composite type name
+// may be.
+func (v *validator) possibleTypes(name string) map[string]bool {
+	types := map[string]bool{}
+	if _, ok := v.ts.ObjectTypes[name]; ok {
+		types[name] = true
+	}
+	if _, ok := v.ts.InterfaceTypes[name]; ok {
+		for n, o := range v.ts.ObjectTypes {
+			for _, i := range o.Implements {
+				if i.Name == name {
+					types[n] = true
+				}
+			}
+		}
+	}
+	if u, ok := v.ts.UnionTypes[name]; ok {
+		for _, m := range u.Members {
+			types[m.Name] = true
+		}
+	}
+	return types
+}
@@ -0,0 +1,184 @@
+package validator
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+	"github.com/RettyEng/gqlcodegen/parser"
+)
+
+func loadExample(t *testing.T) *gql.TypeSystem {
+	t.Helper()
+	f, e := os.Open("../example/schema.graphqls")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer f.Close()
+	top, e := parser.NewParser(f).Parse()
+	if e != nil {
+		t.Fatal(e)
+	}
+	ts, e := top.Eval()
+	if e != nil {
+		t.Fatal(e)
+	}
+	return ts
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "valid",
+			doc: `query Q($n: RegistrationNumber, $id: Uint32!) {
+  truck(number: $n) { ...T }
+  garage(id: $id) @include(if: true) {
+    trucks(size: 10) { maker }
+    drivers(class: [ROOKIE]) { name ... on Driver { class } }
+    __typename
+  }
+}
+fragment T on Truck { maker number capacity }`,
+		},
+		{
+			name: "unknown field",
+			doc:  `{ truck { maker color } }`,
+			want: []string{"1:17: cannot query field color on type Truck"},
+		},
+		{
+			name: "selection on leaf",
+			doc:  `{ truck { maker { name } } }`,
+			want: []string{"1:11: field maker of type Maker! must not have a selection"},
+		},
+		{
+			name: "missing selection",
+			doc:  `{ truck }`,
+			want: []string{"1:3: field truck of type Truck must have a selection of subfields"},
+		},
+		{
+			name: "unknown argument",
+			doc:  `{ truck(id: 1) { maker } }`,
+			want: []string{"1:3: unknown argument id on field Query.truck"},
+		},
+		{
+			name: "missing required argument",
+			doc:  `{ garage { id } }`,
+			want: []string{"1:3: argument id of type Uint32! is required on field Query.garage"},
+		},
+		{
+			name: "bad argument value",
+			doc:  `{ garage(id: 1) { drivers(class: [PILOT]) { name } } }`,
+			want: []string{"1:19: argument class of field Garage.drivers: expected Class!, found PILOT"},
+		},
+		{
+			name: "undefined variable",
+			doc:  `query Q { garage(id: $id) { id } }`,
+			want: []string{"1:11: variable $id is not defined by operation Q"},
+		},
+		{
+			name: "unused variable",
+			doc:  `query Q($id: Uint32!) { truck { maker } }`,
+			want: []string{"1:10: variable $id is never used in operation Q"},
+		},
+		{
+			name: "variable of wrong type",
+			doc:  `query Q($id: Uint32) { garage(id: $id) { id } }`,
+			want: []string{"1:24: variable $id of type Uint32 is used in position expecting Uint32!"},
+		},
+		{
+			name: "non-input variable",
+			doc:  `query Q($t: Truck) { truck { maker } }`,
+			want: []string{
+				"1:10: variable $t cannot be non-input type Truck",
+				"1:10: variable $t is never used in operation Q",
+			},
+		},
+		{
+			name: "fragment cycle",
+			doc: `{ truck { ...A } }
+fragment A on Truck { maker ...B }
+fragment B on Truck { number ...A }`,
+			want: []string{"3:33: cannot spread fragment A within itself"},
+		},
+		{
+			name: "fragment spread on mismatching type",
+			doc: `{ truck { ...D } }
+fragment D on Driver { name }`,
+			want: []string{"1:14: fragment D cannot be spread here as objects of type Truck can never be of type Driver"},
+		},
+		{
+			name: "inline fragment on mismatching type",
+			doc:  `{ truck { ... on Trailer { length } } }`,
+			want: []string{"1:11: fragment cannot be spread here as objects of type Truck can never be of type Trailer"},
+		},
+		{
+			name: "fragment on scalar",
+			doc: `{ truck { maker } }
+fragment S on Uint32 { id }`,
+			want: []string{
+				"2:15: fragment cannot condition on non composite type Uint32",
+				"2:10: fragment S is never used",
+			},
+		},
+		{
+			name: "unknown fragment",
+			doc:  `{ truck { ...X } }`,
+			want: []string{"1:14: unknown fragment X"},
+		},
+		{
+			name: "unused fragment",
+			doc: `{ truck { maker } }
+fragment T on Truck { maker }`,
+			want: []string{"2:10: fragment T is never used"},
+		},
+		{
+			name: "duplicate operation",
+			doc:  "query Q { truck { maker } }\nquery Q { truck { number } }",
+			want: []string{"2:1: there can be only one operation named Q"},
+		},
+		{
+			name: "anonymous operation not alone",
+			doc:  "{ truck { maker } }\nquery Q { truck { number } }",
+			want: []string{"1:1: anonymous operation must be the only defined operation"},
+		},
+		{
+			name: "unsupported operation type",
+			doc:  `mutation { truck { maker } }`,
+			want: []string{"1:1: schema does not support mutation"},
+		},
+		{
+			name: "unknown directive",
+			doc:  `{ truck @cache { maker } }`,
+			want: []string{"1:10: unknown directive @cache"},
+		},
+		{
+			name: "directive in wrong location",
+			doc:  `query Q @include(if: true) { truck { maker } }`,
+			want: []string{"1:10: directive @include may not be used on QUERY"},
+		},
+	}
+
+	ts := loadExample(t)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			doc, e := parser.NewParser(strings.NewReader(tt.doc)).ParseExecutable()
+			if e != nil {
+				t.Fatal(e)
+			}
+			var got []string
+			for _, err := range Validate(ts, doc.Eval()) {
+				got = append(got, err.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package validator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/RettyEng/gqlcodegen/gql"
+)
+
+func inputValue(defs []*gql.InputValue, name string) *gql.InputValue {
+	for _, d := range defs {
+		if d.Name == name {
+			return d
+		}
+	}
+	return nil
+}
+
+// validateArguments validates args given to owner, which has the arguments
+// defs. Variables are not allowed in args if constant is true.
+func (v *validator) validateArguments(
+	args map[string]gql.Value,
+	names []string,
+	defs []*gql.InputValue,
+	pos gql.Position,
+	owner string,
+	constant bool,
+) {
+	seen := map[string]bool{}
+	for _, n := range names {
+		if seen[n] {
+			v.errorf(pos, "there can be only one argument named %s", n)
+			continue
+		}
+		seen[n] = true
+		def := inputValue(defs, n)
+		if def == nil {
+			v.errorf(pos, "unknown argument %s on %s", n, owner)
+			continue
+		}
+		v.validateValue(args[n], def.Type, pos, "argument "+n+" of "+owner, constant)
+	}
+	for _, d := range defs {
+		if !d.Type.IsNullable && d.Default == nil && !seen[d.Name] {
+			v.errorf(pos, "argument %s of type %s is required on %s", d.Name, d.Type, owner)
+		}
+	}
+}
+
+// validateValue validates that value is a literal of typ. what describes the
+// value in messages.
+func (v *validator) validateValue(value gql.Value, typ *gql.TypeRef, pos gql.Position, what string, constant bool) {
+	if variable, ok := value.(*gql.Variable); ok {
+		if constant {
+			v.errorf(pos, "%s must not use variable $%s", what, variable.Name)
+		}
+		return
+	}
+	if isNull(value) {
+		if !typ.IsNullable {
+			v.errorf(pos, "%s: expected %s, found null", what, typ)
+		}
+		return
+	}
+	if typ.Name == "[]" {
+		if l, ok := value.(*gql.List); ok {
+			for _, c := range l.Child {
+				v.validateValue(c, typ.InnerType, pos, what, constant)
+			}
+			return
+		}
+		v.validateValue(value, typ.InnerType, pos, what, constant)
+		return
+	}
+
+	if input, ok := v.ts.InputObjectTypes[typ.Name]; ok {
+		obj, ok := value.(*gql.ObjectValue)
+		if !ok {
+			v.errorf(pos, "%s: expected %s, found %s", what, typ, value.Value())
+			return
+		}
+		v.validateArguments(obj.Fields, obj.FieldNames, input.InputValue, pos, "input "+typ.Name, constant)
+		return
+	}
+	if !v.isLiteralOf(value, typ.Name) {
+		v.errorf(pos, "%s: expected %s, found %s", what, typ, value.Value())
+	}
+}
+
+func (v *validator) isLiteralOf(value gql.Value, typ string) bool {
+	kind := literalKind(value)
+	if e, ok := v.ts.EnumTypes[typ]; ok {
+		if kind != "enum" {
+			return false
+		}
+		for _, ev := range e.Values {
+			if ev.Name == value.Value() {
+				return true
+			}
+		}
+		return false
+	}
+	switch typ {
+	case "Int":
+		_, e := strconv.ParseInt(value.Value(), 10, 32)
+		return kind == "int" && e == nil
+	case "Float":
+		return kind == "int" || kind == "float"
+	case "String":
+		return kind == "string"
+	case "Boolean":
+		return kind == "boolean"
+	case "ID":
+		return kind == "string" || kind == "int"
+	}
+	// Custom scalars accept any literal.
+	return true
+}
+
+// literalKind returns the kind of the literal value, which is one of "list",
+// "object", "null", "boolean", "string", "int", "float" and "enum".
+func literalKind(value gql.Value) string {
+	switch value.(type) {
+	case *gql.List:
+		return "list"
+	case *gql.ObjectValue:
+		return "object"
+	}
+	s := value.Value()
+	switch {
+	case s == "null":
+		return "null"
+	case s == "true" || s == "false":
+		return "boolean"
+	case strings.HasPrefix(s, `"`):
+		return "string"
+	case s != "" && (s[0] == '-' || ('0' <= s[0] && s[0] <= '9')):
+		if strings.ContainsAny(s, ".eE") {
+			return "float"
+		}
+		return "int"
+	}
+	return "enum"
+}
+
+func isNull(value gql.Value) bool {
+	return literalKind(value) == "null"
+}